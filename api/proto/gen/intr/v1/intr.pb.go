@@ -21,6 +21,1171 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Reaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Emoji         string                 `protobuf:"bytes,2,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reaction) Reset() {
+	*x = Reaction{}
+	mi := &file_intr_v1_intr_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reaction) ProtoMessage() {}
+
+func (x *Reaction) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reaction.ProtoReflect.Descriptor instead.
+func (*Reaction) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reaction) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Reaction) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type ReactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	Reaction      string                 `protobuf:"bytes,4,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactRequest) Reset() {
+	*x = ReactRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactRequest) ProtoMessage() {}
+
+func (x *ReactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactRequest.ProtoReflect.Descriptor instead.
+func (*ReactRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReactRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *ReactRequest) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *ReactRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ReactRequest) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type ReactResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactResponse) Reset() {
+	*x = ReactResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactResponse) ProtoMessage() {}
+
+func (x *ReactResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactResponse.ProtoReflect.Descriptor instead.
+func (*ReactResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{2}
+}
+
+type CancelReactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,3,opt,name=uid,proto3" json:"uid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReactionRequest) Reset() {
+	*x = CancelReactionRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReactionRequest) ProtoMessage() {}
+
+func (x *CancelReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReactionRequest.ProtoReflect.Descriptor instead.
+func (*CancelReactionRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CancelReactionRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CancelReactionRequest) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *CancelReactionRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+type CancelReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelReactionResponse) Reset() {
+	*x = CancelReactionResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelReactionResponse) ProtoMessage() {}
+
+func (x *CancelReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelReactionResponse.ProtoReflect.Descriptor instead.
+func (*CancelReactionResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{4}
+}
+
+type ListReactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsRequest) Reset() {
+	*x = ListReactionsRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsRequest) ProtoMessage() {}
+
+func (x *ListReactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListReactionsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{5}
+}
+
+type ListReactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*Reaction            `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReactionsResponse) Reset() {
+	*x = ListReactionsResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReactionsResponse) ProtoMessage() {}
+
+func (x *ListReactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListReactionsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListReactionsResponse) GetReactions() []*Reaction {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+// 取消点赞、收藏的算负的，所以可能是负数
+type StatsCnt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReadCnt       int64                  `protobuf:"varint,1,opt,name=read_cnt,json=readCnt,proto3" json:"read_cnt,omitempty"`
+	LikeCnt       int64                  `protobuf:"varint,2,opt,name=like_cnt,json=likeCnt,proto3" json:"like_cnt,omitempty"`
+	CollectCnt    int64                  `protobuf:"varint,3,opt,name=collect_cnt,json=collectCnt,proto3" json:"collect_cnt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsCnt) Reset() {
+	*x = StatsCnt{}
+	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsCnt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsCnt) ProtoMessage() {}
+
+func (x *StatsCnt) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsCnt.ProtoReflect.Descriptor instead.
+func (*StatsCnt) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StatsCnt) GetReadCnt() int64 {
+	if x != nil {
+		return x.ReadCnt
+	}
+	return 0
+}
+
+func (x *StatsCnt) GetLikeCnt() int64 {
+	if x != nil {
+		return x.LikeCnt
+	}
+	return 0
+}
+
+func (x *StatsCnt) GetCollectCnt() int64 {
+	if x != nil {
+		return x.CollectCnt
+	}
+	return 0
+}
+
+type StatsPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 这个小时或者这一天开始的毫秒数
+	Time          int64     `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Cnt           *StatsCnt `protobuf:"bytes,2,opt,name=cnt,proto3" json:"cnt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsPoint) Reset() {
+	*x = StatsPoint{}
+	mi := &file_intr_v1_intr_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsPoint) ProtoMessage() {}
+
+func (x *StatsPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsPoint.ProtoReflect.Descriptor instead.
+func (*StatsPoint) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StatsPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *StatsPoint) GetCnt() *StatsCnt {
+	if x != nil {
+		return x.Cnt
+	}
+	return nil
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Biz   string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	// 几个资源的加在一起
+	BizIds []int64 `protobuf:"varint,2,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	// [start, end) 的毫秒数
+	Start int64 `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	// hour 或者 day
+	Granularity   string `protobuf:"bytes,5,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetStatsRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetStatsRequest) GetBizIds() []int64 {
+	if x != nil {
+		return x.BizIds
+	}
+	return nil
+}
+
+func (x *GetStatsRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+type GetStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*StatsPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetStatsResponse) GetPoints() []*StatsPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type GetStatsByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizIds        []int64                `protobuf:"varint,2,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	Start         int64                  `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End           int64                  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsByIdsRequest) Reset() {
+	*x = GetStatsByIdsRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsByIdsRequest) ProtoMessage() {}
+
+func (x *GetStatsByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetStatsByIdsRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetStatsByIdsRequest) GetBizIds() []int64 {
+	if x != nil {
+		return x.BizIds
+	}
+	return nil
+}
+
+func (x *GetStatsByIdsRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GetStatsByIdsRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type GetStatsByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cnts          map[int64]*StatsCnt    `protobuf:"bytes,1,rep,name=cnts,proto3" json:"cnts,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsByIdsResponse) Reset() {
+	*x = GetStatsByIdsResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsByIdsResponse) ProtoMessage() {}
+
+func (x *GetStatsByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetStatsByIdsResponse) GetCnts() map[int64]*StatsCnt {
+	if x != nil {
+		return x.Cnts
+	}
+	return nil
+}
+
+type ListLikersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Biz   string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// 上一页返回的 next_cursor，第一页不传
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikersRequest) Reset() {
+	*x = ListLikersRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikersRequest) ProtoMessage() {}
+
+func (x *ListLikersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikersRequest.ProtoReflect.Descriptor instead.
+func (*ListLikersRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListLikersRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *ListLikersRequest) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *ListLikersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListLikersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLikersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Records []*LikeRecord          `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// 为空说明没有下一页了
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikersResponse) Reset() {
+	*x = ListLikersResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikersResponse) ProtoMessage() {}
+
+func (x *ListLikersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikersResponse.ProtoReflect.Descriptor instead.
+func (*ListLikersResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListLikersResponse) GetRecords() []*LikeRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListLikersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListLikedByUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikedByUserRequest) Reset() {
+	*x = ListLikedByUserRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedByUserRequest) ProtoMessage() {}
+
+func (x *ListLikedByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListLikedByUserRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListLikedByUserRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *ListLikedByUserRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ListLikedByUserRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListLikedByUserRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLikedByUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*LikeRecord          `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikedByUserResponse) Reset() {
+	*x = ListLikedByUserResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedByUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedByUserResponse) ProtoMessage() {}
+
+func (x *ListLikedByUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedByUserResponse.ProtoReflect.Descriptor instead.
+func (*ListLikedByUserResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListLikedByUserResponse) GetRecords() []*LikeRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListLikedByUserResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type LikeRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uid   int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// 点赞时间，毫秒
+	Utime int64 `protobuf:"varint,4,opt,name=utime,proto3" json:"utime,omitempty"`
+	// 点赞记录的 id，和 utime 一起组成翻页的游标
+	Id            int64 `protobuf:"varint,5,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeRecord) Reset() {
+	*x = LikeRecord{}
+	mi := &file_intr_v1_intr_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeRecord) ProtoMessage() {}
+
+func (x *LikeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeRecord.ProtoReflect.Descriptor instead.
+func (*LikeRecord) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LikeRecord) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LikeRecord) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *LikeRecord) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *LikeRecord) GetUtime() int64 {
+	if x != nil {
+		return x.Utime
+	}
+	return 0
+}
+
+func (x *LikeRecord) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetUserStateByIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	Uid           int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	BizIds        []int64                `protobuf:"varint,3,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStateByIdsRequest) Reset() {
+	*x = GetUserStateByIdsRequest{}
+	mi := &file_intr_v1_intr_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStateByIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStateByIdsRequest) ProtoMessage() {}
+
+func (x *GetUserStateByIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStateByIdsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStateByIdsRequest) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetUserStateByIdsRequest) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetUserStateByIdsRequest) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetUserStateByIdsRequest) GetBizIds() []int64 {
+	if x != nil {
+		return x.BizIds
+	}
+	return nil
+}
+
+type GetUserStateByIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        map[int64]*UserState   `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStateByIdsResponse) Reset() {
+	*x = GetUserStateByIdsResponse{}
+	mi := &file_intr_v1_intr_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStateByIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStateByIdsResponse) ProtoMessage() {}
+
+func (x *GetUserStateByIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStateByIdsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStateByIdsResponse) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetUserStateByIdsResponse) GetStates() map[int64]*UserState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type UserState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BizId         int64                  `protobuf:"varint,1,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Liked         bool                   `protobuf:"varint,2,opt,name=liked,proto3" json:"liked,omitempty"`
+	Collected     bool                   `protobuf:"varint,3,opt,name=collected,proto3" json:"collected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserState) Reset() {
+	*x = UserState{}
+	mi := &file_intr_v1_intr_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserState) ProtoMessage() {}
+
+func (x *UserState) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_v1_intr_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserState.ProtoReflect.Descriptor instead.
+func (*UserState) Descriptor() ([]byte, []int) {
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UserState) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *UserState) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *UserState) GetCollected() bool {
+	if x != nil {
+		return x.Collected
+	}
+	return false
+}
+
 type GetByIdsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
@@ -31,7 +1196,7 @@ type GetByIdsRequest struct {
 
 func (x *GetByIdsRequest) Reset() {
 	*x = GetByIdsRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[0]
+	mi := &file_intr_v1_intr_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -43,7 +1208,7 @@ func (x *GetByIdsRequest) String() string {
 func (*GetByIdsRequest) ProtoMessage() {}
 
 func (x *GetByIdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[0]
+	mi := &file_intr_v1_intr_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -56,7 +1221,7 @@ func (x *GetByIdsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetByIdsRequest.ProtoReflect.Descriptor instead.
 func (*GetByIdsRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{0}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetByIdsRequest) GetBiz() string {
@@ -82,7 +1247,7 @@ type GetByIdsResponse struct {
 
 func (x *GetByIdsResponse) Reset() {
 	*x = GetByIdsResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[1]
+	mi := &file_intr_v1_intr_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -94,7 +1259,7 @@ func (x *GetByIdsResponse) String() string {
 func (*GetByIdsResponse) ProtoMessage() {}
 
 func (x *GetByIdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[1]
+	mi := &file_intr_v1_intr_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -107,7 +1272,7 @@ func (x *GetByIdsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetByIdsResponse.ProtoReflect.Descriptor instead.
 func (*GetByIdsResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{1}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetByIdsResponse) GetIntrs() map[int64]*Interactive {
@@ -128,7 +1293,7 @@ type GetRequest struct {
 
 func (x *GetRequest) Reset() {
 	*x = GetRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	mi := &file_intr_v1_intr_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +1305,7 @@ func (x *GetRequest) String() string {
 func (*GetRequest) ProtoMessage() {}
 
 func (x *GetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[2]
+	mi := &file_intr_v1_intr_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +1318,7 @@ func (x *GetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRequest.ProtoReflect.Descriptor instead.
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{2}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetRequest) GetBiz() string {
@@ -186,7 +1351,7 @@ type GetResponse struct {
 
 func (x *GetResponse) Reset() {
 	*x = GetResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	mi := &file_intr_v1_intr_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -198,7 +1363,7 @@ func (x *GetResponse) String() string {
 func (*GetResponse) ProtoMessage() {}
 
 func (x *GetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[3]
+	mi := &file_intr_v1_intr_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +1376,7 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{3}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetResponse) GetIntr() *Interactive {
@@ -222,21 +1387,27 @@ func (x *GetResponse) GetIntr() *Interactive {
 }
 
 type Interactive struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
-	ReadCnt       int64                  `protobuf:"varint,3,opt,name=read_cnt,json=readCnt,proto3" json:"read_cnt,omitempty"`
-	LikeCnt       int64                  `protobuf:"varint,4,opt,name=like_cnt,json=likeCnt,proto3" json:"like_cnt,omitempty"`
-	CollectCnt    int64                  `protobuf:"varint,5,opt,name=collect_cnt,json=collectCnt,proto3" json:"collect_cnt,omitempty"`
-	Liked         bool                   `protobuf:"varint,6,opt,name=liked,proto3" json:"liked,omitempty"`
-	Collected     bool                   `protobuf:"varint,7,opt,name=collected,proto3" json:"collected,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Biz        string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId      int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	ReadCnt    int64                  `protobuf:"varint,3,opt,name=read_cnt,json=readCnt,proto3" json:"read_cnt,omitempty"`
+	LikeCnt    int64                  `protobuf:"varint,4,opt,name=like_cnt,json=likeCnt,proto3" json:"like_cnt,omitempty"`
+	CollectCnt int64                  `protobuf:"varint,5,opt,name=collect_cnt,json=collectCnt,proto3" json:"collect_cnt,omitempty"`
+	Liked      bool                   `protobuf:"varint,6,opt,name=liked,proto3" json:"liked,omitempty"`
+	Collected  bool                   `protobuf:"varint,7,opt,name=collected,proto3" json:"collected,omitempty"`
+	// 读过的人数，HyperLogLog 估算的，定时更新
+	UvCnt int64 `protobuf:"varint,8,opt,name=uv_cnt,json=uvCnt,proto3" json:"uv_cnt,omitempty"`
+	// 每个表情的数量，点赞的就是 like_cnt
+	ReactionCnts map[string]int64 `protobuf:"bytes,9,rep,name=reaction_cnts,json=reactionCnts,proto3" json:"reaction_cnts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// 用户自己的表情，没有就是空的
+	Reaction      string `protobuf:"bytes,10,opt,name=reaction,proto3" json:"reaction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Interactive) Reset() {
 	*x = Interactive{}
-	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	mi := &file_intr_v1_intr_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -248,7 +1419,7 @@ func (x *Interactive) String() string {
 func (*Interactive) ProtoMessage() {}
 
 func (x *Interactive) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[4]
+	mi := &file_intr_v1_intr_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +1432,7 @@ func (x *Interactive) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Interactive.ProtoReflect.Descriptor instead.
 func (*Interactive) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{4}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *Interactive) GetBiz() string {
@@ -313,6 +1484,27 @@ func (x *Interactive) GetCollected() bool {
 	return false
 }
 
+func (x *Interactive) GetUvCnt() int64 {
+	if x != nil {
+		return x.UvCnt
+	}
+	return 0
+}
+
+func (x *Interactive) GetReactionCnts() map[string]int64 {
+	if x != nil {
+		return x.ReactionCnts
+	}
+	return nil
+}
+
+func (x *Interactive) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
 type CollectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
@@ -325,7 +1517,7 @@ type CollectRequest struct {
 
 func (x *CollectRequest) Reset() {
 	*x = CollectRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	mi := &file_intr_v1_intr_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +1529,7 @@ func (x *CollectRequest) String() string {
 func (*CollectRequest) ProtoMessage() {}
 
 func (x *CollectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[5]
+	mi := &file_intr_v1_intr_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +1542,7 @@ func (x *CollectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectRequest.ProtoReflect.Descriptor instead.
 func (*CollectRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{5}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *CollectRequest) GetBiz() string {
@@ -389,7 +1581,7 @@ type CollectResponse struct {
 
 func (x *CollectResponse) Reset() {
 	*x = CollectResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	mi := &file_intr_v1_intr_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -401,7 +1593,7 @@ func (x *CollectResponse) String() string {
 func (*CollectResponse) ProtoMessage() {}
 
 func (x *CollectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[6]
+	mi := &file_intr_v1_intr_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -414,7 +1606,7 @@ func (x *CollectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectResponse.ProtoReflect.Descriptor instead.
 func (*CollectResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{6}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{27}
 }
 
 type CancelCollectRequest struct {
@@ -429,7 +1621,7 @@ type CancelCollectRequest struct {
 
 func (x *CancelCollectRequest) Reset() {
 	*x = CancelCollectRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	mi := &file_intr_v1_intr_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +1633,7 @@ func (x *CancelCollectRequest) String() string {
 func (*CancelCollectRequest) ProtoMessage() {}
 
 func (x *CancelCollectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[7]
+	mi := &file_intr_v1_intr_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,7 +1646,7 @@ func (x *CancelCollectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelCollectRequest.ProtoReflect.Descriptor instead.
 func (*CancelCollectRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{7}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *CancelCollectRequest) GetBiz() string {
@@ -493,7 +1685,7 @@ type CancelCollectResponse struct {
 
 func (x *CancelCollectResponse) Reset() {
 	*x = CancelCollectResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[8]
+	mi := &file_intr_v1_intr_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -505,7 +1697,7 @@ func (x *CancelCollectResponse) String() string {
 func (*CancelCollectResponse) ProtoMessage() {}
 
 func (x *CancelCollectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[8]
+	mi := &file_intr_v1_intr_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -518,7 +1710,7 @@ func (x *CancelCollectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelCollectResponse.ProtoReflect.Descriptor instead.
 func (*CancelCollectResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{8}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{29}
 }
 
 type CancelLikeRequest struct {
@@ -532,7 +1724,7 @@ type CancelLikeRequest struct {
 
 func (x *CancelLikeRequest) Reset() {
 	*x = CancelLikeRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[9]
+	mi := &file_intr_v1_intr_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -544,7 +1736,7 @@ func (x *CancelLikeRequest) String() string {
 func (*CancelLikeRequest) ProtoMessage() {}
 
 func (x *CancelLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[9]
+	mi := &file_intr_v1_intr_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +1749,7 @@ func (x *CancelLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelLikeRequest.ProtoReflect.Descriptor instead.
 func (*CancelLikeRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{9}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *CancelLikeRequest) GetBiz() string {
@@ -589,7 +1781,7 @@ type CancelLikeResponse struct {
 
 func (x *CancelLikeResponse) Reset() {
 	*x = CancelLikeResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[10]
+	mi := &file_intr_v1_intr_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -601,7 +1793,7 @@ func (x *CancelLikeResponse) String() string {
 func (*CancelLikeResponse) ProtoMessage() {}
 
 func (x *CancelLikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[10]
+	mi := &file_intr_v1_intr_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,7 +1806,7 @@ func (x *CancelLikeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CancelLikeResponse.ProtoReflect.Descriptor instead.
 func (*CancelLikeResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{10}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{31}
 }
 
 type LikeRequest struct {
@@ -628,7 +1820,7 @@ type LikeRequest struct {
 
 func (x *LikeRequest) Reset() {
 	*x = LikeRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	mi := &file_intr_v1_intr_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -640,7 +1832,7 @@ func (x *LikeRequest) String() string {
 func (*LikeRequest) ProtoMessage() {}
 
 func (x *LikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[11]
+	mi := &file_intr_v1_intr_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +1845,7 @@ func (x *LikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeRequest.ProtoReflect.Descriptor instead.
 func (*LikeRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{11}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *LikeRequest) GetBiz() string {
@@ -685,7 +1877,7 @@ type LikeResponse struct {
 
 func (x *LikeResponse) Reset() {
 	*x = LikeResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	mi := &file_intr_v1_intr_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +1889,7 @@ func (x *LikeResponse) String() string {
 func (*LikeResponse) ProtoMessage() {}
 
 func (x *LikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[12]
+	mi := &file_intr_v1_intr_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +1902,7 @@ func (x *LikeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeResponse.ProtoReflect.Descriptor instead.
 func (*LikeResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{12}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{33}
 }
 
 type IncrReadCntRequest struct {
@@ -723,7 +1915,7 @@ type IncrReadCntRequest struct {
 
 func (x *IncrReadCntRequest) Reset() {
 	*x = IncrReadCntRequest{}
-	mi := &file_intr_v1_intr_proto_msgTypes[13]
+	mi := &file_intr_v1_intr_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -735,7 +1927,7 @@ func (x *IncrReadCntRequest) String() string {
 func (*IncrReadCntRequest) ProtoMessage() {}
 
 func (x *IncrReadCntRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[13]
+	mi := &file_intr_v1_intr_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,7 +1940,7 @@ func (x *IncrReadCntRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrReadCntRequest.ProtoReflect.Descriptor instead.
 func (*IncrReadCntRequest) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{13}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *IncrReadCntRequest) GetBiz() string {
@@ -773,7 +1965,7 @@ type IncrReadCntResponse struct {
 
 func (x *IncrReadCntResponse) Reset() {
 	*x = IncrReadCntResponse{}
-	mi := &file_intr_v1_intr_proto_msgTypes[14]
+	mi := &file_intr_v1_intr_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -785,7 +1977,7 @@ func (x *IncrReadCntResponse) String() string {
 func (*IncrReadCntResponse) ProtoMessage() {}
 
 func (x *IncrReadCntResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_v1_intr_proto_msgTypes[14]
+	mi := &file_intr_v1_intr_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,14 +1990,96 @@ func (x *IncrReadCntResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IncrReadCntResponse.ProtoReflect.Descriptor instead.
 func (*IncrReadCntResponse) Descriptor() ([]byte, []int) {
-	return file_intr_v1_intr_proto_rawDescGZIP(), []int{14}
+	return file_intr_v1_intr_proto_rawDescGZIP(), []int{35}
 }
 
 var File_intr_v1_intr_proto protoreflect.FileDescriptor
 
 const file_intr_v1_intr_proto_rawDesc = "" +
 	"\n" +
-	"\x12intr/v1/intr.proto\x12\aintr.v1\"<\n" +
+	"\x12intr/v1/intr.proto\x12\aintr.v1\"2\n" +
+	"\bReaction\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05emoji\x18\x02 \x01(\tR\x05emoji\"e\n" +
+	"\fReactRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
+	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\x03R\x03uid\x12\x1a\n" +
+	"\breaction\x18\x04 \x01(\tR\breaction\"\x0f\n" +
+	"\rReactResponse\"R\n" +
+	"\x15CancelReactionRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
+	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x10\n" +
+	"\x03uid\x18\x03 \x01(\x03R\x03uid\"\x18\n" +
+	"\x16CancelReactionResponse\"\x16\n" +
+	"\x14ListReactionsRequest\"H\n" +
+	"\x15ListReactionsResponse\x12/\n" +
+	"\treactions\x18\x01 \x03(\v2\x11.intr.v1.ReactionR\treactions\"a\n" +
+	"\bStatsCnt\x12\x19\n" +
+	"\bread_cnt\x18\x01 \x01(\x03R\areadCnt\x12\x19\n" +
+	"\blike_cnt\x18\x02 \x01(\x03R\alikeCnt\x12\x1f\n" +
+	"\vcollect_cnt\x18\x03 \x01(\x03R\n" +
+	"collectCnt\"E\n" +
+	"\n" +
+	"StatsPoint\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\x03R\x04time\x12#\n" +
+	"\x03cnt\x18\x02 \x01(\v2\x11.intr.v1.StatsCntR\x03cnt\"\x86\x01\n" +
+	"\x0fGetStatsRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x17\n" +
+	"\abiz_ids\x18\x02 \x03(\x03R\x06bizIds\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x03R\x03end\x12 \n" +
+	"\vgranularity\x18\x05 \x01(\tR\vgranularity\"?\n" +
+	"\x10GetStatsResponse\x12+\n" +
+	"\x06points\x18\x01 \x03(\v2\x13.intr.v1.StatsPointR\x06points\"i\n" +
+	"\x14GetStatsByIdsRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x17\n" +
+	"\abiz_ids\x18\x02 \x03(\x03R\x06bizIds\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\x03R\x03end\"\xa1\x01\n" +
+	"\x15GetStatsByIdsResponse\x12<\n" +
+	"\x04cnts\x18\x01 \x03(\v2(.intr.v1.GetStatsByIdsResponse.CntsEntryR\x04cnts\x1aJ\n" +
+	"\tCntsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12'\n" +
+	"\x05value\x18\x02 \x01(\v2\x11.intr.v1.StatsCntR\x05value:\x028\x01\"j\n" +
+	"\x11ListLikersRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
+	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"d\n" +
+	"\x12ListLikersResponse\x12-\n" +
+	"\arecords\x18\x01 \x03(\v2\x13.intr.v1.LikeRecordR\arecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"j\n" +
+	"\x16ListLikedByUserRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"i\n" +
+	"\x17ListLikedByUserResponse\x12-\n" +
+	"\arecords\x18\x01 \x03(\v2\x13.intr.v1.LikeRecordR\arecords\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"m\n" +
+	"\n" +
+	"LikeRecord\x12\x10\n" +
+	"\x03uid\x18\x01 \x01(\x03R\x03uid\x12\x10\n" +
+	"\x03biz\x18\x02 \x01(\tR\x03biz\x12\x15\n" +
+	"\x06biz_id\x18\x03 \x01(\x03R\x05bizId\x12\x14\n" +
+	"\x05utime\x18\x04 \x01(\x03R\x05utime\x12\x0e\n" +
+	"\x02id\x18\x05 \x01(\x03R\x02id\"W\n" +
+	"\x18GetUserStateByIdsRequest\x12\x10\n" +
+	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\x03R\x03uid\x12\x17\n" +
+	"\abiz_ids\x18\x03 \x03(\x03R\x06bizIds\"\xb2\x01\n" +
+	"\x19GetUserStateByIdsResponse\x12F\n" +
+	"\x06states\x18\x01 \x03(\v2..intr.v1.GetUserStateByIdsResponse.StatesEntryR\x06states\x1aM\n" +
+	"\vStatesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x03R\x03key\x12(\n" +
+	"\x05value\x18\x02 \x01(\v2\x12.intr.v1.UserStateR\x05value:\x028\x01\"V\n" +
+	"\tUserState\x12\x15\n" +
+	"\x06biz_id\x18\x01 \x01(\x03R\x05bizId\x12\x14\n" +
+	"\x05liked\x18\x02 \x01(\bR\x05liked\x12\x1c\n" +
+	"\tcollected\x18\x03 \x01(\bR\tcollected\"<\n" +
 	"\x0fGetByIdsRequest\x12\x10\n" +
 	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x17\n" +
 	"\abiz_ids\x18\x02 \x03(\x03R\x06bizIds\"\x9e\x01\n" +
@@ -821,7 +2095,7 @@ const file_intr_v1_intr_proto_rawDesc = "" +
 	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x10\n" +
 	"\x03uid\x18\x03 \x01(\x03R\x03uid\"7\n" +
 	"\vGetResponse\x12(\n" +
-	"\x04intr\x18\x01 \x01(\v2\x14.intr.v1.InteractiveR\x04intr\"\xc1\x01\n" +
+	"\x04intr\x18\x01 \x01(\v2\x14.intr.v1.InteractiveR\x04intr\"\x82\x03\n" +
 	"\vInteractive\x12\x10\n" +
 	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
 	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x19\n" +
@@ -830,7 +2104,14 @@ const file_intr_v1_intr_proto_rawDesc = "" +
 	"\vcollect_cnt\x18\x05 \x01(\x03R\n" +
 	"collectCnt\x12\x14\n" +
 	"\x05liked\x18\x06 \x01(\bR\x05liked\x12\x1c\n" +
-	"\tcollected\x18\a \x01(\bR\tcollected\"]\n" +
+	"\tcollected\x18\a \x01(\bR\tcollected\x12\x15\n" +
+	"\x06uv_cnt\x18\b \x01(\x03R\x05uvCnt\x12K\n" +
+	"\rreaction_cnts\x18\t \x03(\v2&.intr.v1.Interactive.ReactionCntsEntryR\freactionCnts\x12\x1a\n" +
+	"\breaction\x18\n" +
+	" \x01(\tR\breaction\x1a?\n" +
+	"\x11ReactionCntsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"]\n" +
 	"\x0eCollectRequest\x12\x10\n" +
 	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
 	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\x12\x10\n" +
@@ -856,7 +2137,7 @@ const file_intr_v1_intr_proto_rawDesc = "" +
 	"\x12IncrReadCntRequest\x12\x10\n" +
 	"\x03biz\x18\x01 \x01(\tR\x03biz\x12\x15\n" +
 	"\x06biz_id\x18\x02 \x01(\x03R\x05bizId\"\x15\n" +
-	"\x13IncrReadCntResponse2\xdb\x03\n" +
+	"\x13IncrReadCntResponse2\xc0\b\n" +
 	"\x12InteractiveService\x12H\n" +
 	"\vIncrReadCnt\x12\x1b.intr.v1.IncrReadCntRequest\x1a\x1c.intr.v1.IncrReadCntResponse\x123\n" +
 	"\x04Like\x12\x14.intr.v1.LikeRequest\x1a\x15.intr.v1.LikeResponse\x12E\n" +
@@ -865,7 +2146,16 @@ const file_intr_v1_intr_proto_rawDesc = "" +
 	"\aCollect\x12\x17.intr.v1.CollectRequest\x1a\x18.intr.v1.CollectResponse\x12N\n" +
 	"\rCancelCollect\x12\x1d.intr.v1.CancelCollectRequest\x1a\x1e.intr.v1.CancelCollectResponse\x120\n" +
 	"\x03Get\x12\x13.intr.v1.GetRequest\x1a\x14.intr.v1.GetResponse\x12?\n" +
-	"\bGetByIds\x12\x18.intr.v1.GetByIdsRequest\x1a\x19.intr.v1.GetByIdsResponseB|\n" +
+	"\bGetByIds\x12\x18.intr.v1.GetByIdsRequest\x1a\x19.intr.v1.GetByIdsResponse\x12Z\n" +
+	"\x11GetUserStateByIds\x12!.intr.v1.GetUserStateByIdsRequest\x1a\".intr.v1.GetUserStateByIdsResponse\x12E\n" +
+	"\n" +
+	"ListLikers\x12\x1a.intr.v1.ListLikersRequest\x1a\x1b.intr.v1.ListLikersResponse\x12T\n" +
+	"\x0fListLikedByUser\x12\x1f.intr.v1.ListLikedByUserRequest\x1a .intr.v1.ListLikedByUserResponse\x12?\n" +
+	"\bGetStats\x12\x18.intr.v1.GetStatsRequest\x1a\x19.intr.v1.GetStatsResponse\x12N\n" +
+	"\rGetStatsByIds\x12\x1d.intr.v1.GetStatsByIdsRequest\x1a\x1e.intr.v1.GetStatsByIdsResponse\x126\n" +
+	"\x05React\x12\x15.intr.v1.ReactRequest\x1a\x16.intr.v1.ReactResponse\x12Q\n" +
+	"\x0eCancelReaction\x12\x1e.intr.v1.CancelReactionRequest\x1a\x1f.intr.v1.CancelReactionResponse\x12N\n" +
+	"\rListReactions\x12\x1d.intr.v1.ListReactionsRequest\x1a\x1e.intr.v1.ListReactionsResponseB|\n" +
 	"\vcom.intr.v1B\tIntrProtoP\x01Z%red-feed/api/proto/gen/intr/v1;intrv1\xa2\x02\x03IXX\xaa\x02\aIntr.V1\xca\x02\aIntr\\V1\xe2\x02\x13Intr\\V1\\GPBMetadata\xea\x02\bIntr::V1b\x06proto3"
 
 var (
@@ -880,48 +2170,98 @@ func file_intr_v1_intr_proto_rawDescGZIP() []byte {
 	return file_intr_v1_intr_proto_rawDescData
 }
 
-var file_intr_v1_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_intr_v1_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_intr_v1_intr_proto_goTypes = []any{
-	(*GetByIdsRequest)(nil),       // 0: intr.v1.GetByIdsRequest
-	(*GetByIdsResponse)(nil),      // 1: intr.v1.GetByIdsResponse
-	(*GetRequest)(nil),            // 2: intr.v1.GetRequest
-	(*GetResponse)(nil),           // 3: intr.v1.GetResponse
-	(*Interactive)(nil),           // 4: intr.v1.Interactive
-	(*CollectRequest)(nil),        // 5: intr.v1.CollectRequest
-	(*CollectResponse)(nil),       // 6: intr.v1.CollectResponse
-	(*CancelCollectRequest)(nil),  // 7: intr.v1.CancelCollectRequest
-	(*CancelCollectResponse)(nil), // 8: intr.v1.CancelCollectResponse
-	(*CancelLikeRequest)(nil),     // 9: intr.v1.CancelLikeRequest
-	(*CancelLikeResponse)(nil),    // 10: intr.v1.CancelLikeResponse
-	(*LikeRequest)(nil),           // 11: intr.v1.LikeRequest
-	(*LikeResponse)(nil),          // 12: intr.v1.LikeResponse
-	(*IncrReadCntRequest)(nil),    // 13: intr.v1.IncrReadCntRequest
-	(*IncrReadCntResponse)(nil),   // 14: intr.v1.IncrReadCntResponse
-	nil,                           // 15: intr.v1.GetByIdsResponse.IntrsEntry
+	(*Reaction)(nil),                  // 0: intr.v1.Reaction
+	(*ReactRequest)(nil),              // 1: intr.v1.ReactRequest
+	(*ReactResponse)(nil),             // 2: intr.v1.ReactResponse
+	(*CancelReactionRequest)(nil),     // 3: intr.v1.CancelReactionRequest
+	(*CancelReactionResponse)(nil),    // 4: intr.v1.CancelReactionResponse
+	(*ListReactionsRequest)(nil),      // 5: intr.v1.ListReactionsRequest
+	(*ListReactionsResponse)(nil),     // 6: intr.v1.ListReactionsResponse
+	(*StatsCnt)(nil),                  // 7: intr.v1.StatsCnt
+	(*StatsPoint)(nil),                // 8: intr.v1.StatsPoint
+	(*GetStatsRequest)(nil),           // 9: intr.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),          // 10: intr.v1.GetStatsResponse
+	(*GetStatsByIdsRequest)(nil),      // 11: intr.v1.GetStatsByIdsRequest
+	(*GetStatsByIdsResponse)(nil),     // 12: intr.v1.GetStatsByIdsResponse
+	(*ListLikersRequest)(nil),         // 13: intr.v1.ListLikersRequest
+	(*ListLikersResponse)(nil),        // 14: intr.v1.ListLikersResponse
+	(*ListLikedByUserRequest)(nil),    // 15: intr.v1.ListLikedByUserRequest
+	(*ListLikedByUserResponse)(nil),   // 16: intr.v1.ListLikedByUserResponse
+	(*LikeRecord)(nil),                // 17: intr.v1.LikeRecord
+	(*GetUserStateByIdsRequest)(nil),  // 18: intr.v1.GetUserStateByIdsRequest
+	(*GetUserStateByIdsResponse)(nil), // 19: intr.v1.GetUserStateByIdsResponse
+	(*UserState)(nil),                 // 20: intr.v1.UserState
+	(*GetByIdsRequest)(nil),           // 21: intr.v1.GetByIdsRequest
+	(*GetByIdsResponse)(nil),          // 22: intr.v1.GetByIdsResponse
+	(*GetRequest)(nil),                // 23: intr.v1.GetRequest
+	(*GetResponse)(nil),               // 24: intr.v1.GetResponse
+	(*Interactive)(nil),               // 25: intr.v1.Interactive
+	(*CollectRequest)(nil),            // 26: intr.v1.CollectRequest
+	(*CollectResponse)(nil),           // 27: intr.v1.CollectResponse
+	(*CancelCollectRequest)(nil),      // 28: intr.v1.CancelCollectRequest
+	(*CancelCollectResponse)(nil),     // 29: intr.v1.CancelCollectResponse
+	(*CancelLikeRequest)(nil),         // 30: intr.v1.CancelLikeRequest
+	(*CancelLikeResponse)(nil),        // 31: intr.v1.CancelLikeResponse
+	(*LikeRequest)(nil),               // 32: intr.v1.LikeRequest
+	(*LikeResponse)(nil),              // 33: intr.v1.LikeResponse
+	(*IncrReadCntRequest)(nil),        // 34: intr.v1.IncrReadCntRequest
+	(*IncrReadCntResponse)(nil),       // 35: intr.v1.IncrReadCntResponse
+	nil,                               // 36: intr.v1.GetStatsByIdsResponse.CntsEntry
+	nil,                               // 37: intr.v1.GetUserStateByIdsResponse.StatesEntry
+	nil,                               // 38: intr.v1.GetByIdsResponse.IntrsEntry
+	nil,                               // 39: intr.v1.Interactive.ReactionCntsEntry
 }
 var file_intr_v1_intr_proto_depIdxs = []int32{
-	15, // 0: intr.v1.GetByIdsResponse.intrs:type_name -> intr.v1.GetByIdsResponse.IntrsEntry
-	4,  // 1: intr.v1.GetResponse.intr:type_name -> intr.v1.Interactive
-	4,  // 2: intr.v1.GetByIdsResponse.IntrsEntry.value:type_name -> intr.v1.Interactive
-	13, // 3: intr.v1.InteractiveService.IncrReadCnt:input_type -> intr.v1.IncrReadCntRequest
-	11, // 4: intr.v1.InteractiveService.Like:input_type -> intr.v1.LikeRequest
-	9,  // 5: intr.v1.InteractiveService.CancelLike:input_type -> intr.v1.CancelLikeRequest
-	5,  // 6: intr.v1.InteractiveService.Collect:input_type -> intr.v1.CollectRequest
-	7,  // 7: intr.v1.InteractiveService.CancelCollect:input_type -> intr.v1.CancelCollectRequest
-	2,  // 8: intr.v1.InteractiveService.Get:input_type -> intr.v1.GetRequest
-	0,  // 9: intr.v1.InteractiveService.GetByIds:input_type -> intr.v1.GetByIdsRequest
-	14, // 10: intr.v1.InteractiveService.IncrReadCnt:output_type -> intr.v1.IncrReadCntResponse
-	12, // 11: intr.v1.InteractiveService.Like:output_type -> intr.v1.LikeResponse
-	10, // 12: intr.v1.InteractiveService.CancelLike:output_type -> intr.v1.CancelLikeResponse
-	6,  // 13: intr.v1.InteractiveService.Collect:output_type -> intr.v1.CollectResponse
-	8,  // 14: intr.v1.InteractiveService.CancelCollect:output_type -> intr.v1.CancelCollectResponse
-	3,  // 15: intr.v1.InteractiveService.Get:output_type -> intr.v1.GetResponse
-	1,  // 16: intr.v1.InteractiveService.GetByIds:output_type -> intr.v1.GetByIdsResponse
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: intr.v1.ListReactionsResponse.reactions:type_name -> intr.v1.Reaction
+	7,  // 1: intr.v1.StatsPoint.cnt:type_name -> intr.v1.StatsCnt
+	8,  // 2: intr.v1.GetStatsResponse.points:type_name -> intr.v1.StatsPoint
+	36, // 3: intr.v1.GetStatsByIdsResponse.cnts:type_name -> intr.v1.GetStatsByIdsResponse.CntsEntry
+	17, // 4: intr.v1.ListLikersResponse.records:type_name -> intr.v1.LikeRecord
+	17, // 5: intr.v1.ListLikedByUserResponse.records:type_name -> intr.v1.LikeRecord
+	37, // 6: intr.v1.GetUserStateByIdsResponse.states:type_name -> intr.v1.GetUserStateByIdsResponse.StatesEntry
+	38, // 7: intr.v1.GetByIdsResponse.intrs:type_name -> intr.v1.GetByIdsResponse.IntrsEntry
+	25, // 8: intr.v1.GetResponse.intr:type_name -> intr.v1.Interactive
+	39, // 9: intr.v1.Interactive.reaction_cnts:type_name -> intr.v1.Interactive.ReactionCntsEntry
+	7,  // 10: intr.v1.GetStatsByIdsResponse.CntsEntry.value:type_name -> intr.v1.StatsCnt
+	20, // 11: intr.v1.GetUserStateByIdsResponse.StatesEntry.value:type_name -> intr.v1.UserState
+	25, // 12: intr.v1.GetByIdsResponse.IntrsEntry.value:type_name -> intr.v1.Interactive
+	34, // 13: intr.v1.InteractiveService.IncrReadCnt:input_type -> intr.v1.IncrReadCntRequest
+	32, // 14: intr.v1.InteractiveService.Like:input_type -> intr.v1.LikeRequest
+	30, // 15: intr.v1.InteractiveService.CancelLike:input_type -> intr.v1.CancelLikeRequest
+	26, // 16: intr.v1.InteractiveService.Collect:input_type -> intr.v1.CollectRequest
+	28, // 17: intr.v1.InteractiveService.CancelCollect:input_type -> intr.v1.CancelCollectRequest
+	23, // 18: intr.v1.InteractiveService.Get:input_type -> intr.v1.GetRequest
+	21, // 19: intr.v1.InteractiveService.GetByIds:input_type -> intr.v1.GetByIdsRequest
+	18, // 20: intr.v1.InteractiveService.GetUserStateByIds:input_type -> intr.v1.GetUserStateByIdsRequest
+	13, // 21: intr.v1.InteractiveService.ListLikers:input_type -> intr.v1.ListLikersRequest
+	15, // 22: intr.v1.InteractiveService.ListLikedByUser:input_type -> intr.v1.ListLikedByUserRequest
+	9,  // 23: intr.v1.InteractiveService.GetStats:input_type -> intr.v1.GetStatsRequest
+	11, // 24: intr.v1.InteractiveService.GetStatsByIds:input_type -> intr.v1.GetStatsByIdsRequest
+	1,  // 25: intr.v1.InteractiveService.React:input_type -> intr.v1.ReactRequest
+	3,  // 26: intr.v1.InteractiveService.CancelReaction:input_type -> intr.v1.CancelReactionRequest
+	5,  // 27: intr.v1.InteractiveService.ListReactions:input_type -> intr.v1.ListReactionsRequest
+	35, // 28: intr.v1.InteractiveService.IncrReadCnt:output_type -> intr.v1.IncrReadCntResponse
+	33, // 29: intr.v1.InteractiveService.Like:output_type -> intr.v1.LikeResponse
+	31, // 30: intr.v1.InteractiveService.CancelLike:output_type -> intr.v1.CancelLikeResponse
+	27, // 31: intr.v1.InteractiveService.Collect:output_type -> intr.v1.CollectResponse
+	29, // 32: intr.v1.InteractiveService.CancelCollect:output_type -> intr.v1.CancelCollectResponse
+	24, // 33: intr.v1.InteractiveService.Get:output_type -> intr.v1.GetResponse
+	22, // 34: intr.v1.InteractiveService.GetByIds:output_type -> intr.v1.GetByIdsResponse
+	19, // 35: intr.v1.InteractiveService.GetUserStateByIds:output_type -> intr.v1.GetUserStateByIdsResponse
+	14, // 36: intr.v1.InteractiveService.ListLikers:output_type -> intr.v1.ListLikersResponse
+	16, // 37: intr.v1.InteractiveService.ListLikedByUser:output_type -> intr.v1.ListLikedByUserResponse
+	10, // 38: intr.v1.InteractiveService.GetStats:output_type -> intr.v1.GetStatsResponse
+	12, // 39: intr.v1.InteractiveService.GetStatsByIds:output_type -> intr.v1.GetStatsByIdsResponse
+	2,  // 40: intr.v1.InteractiveService.React:output_type -> intr.v1.ReactResponse
+	4,  // 41: intr.v1.InteractiveService.CancelReaction:output_type -> intr.v1.CancelReactionResponse
+	6,  // 42: intr.v1.InteractiveService.ListReactions:output_type -> intr.v1.ListReactionsResponse
+	28, // [28:43] is the sub-list for method output_type
+	13, // [13:28] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_intr_v1_intr_proto_init() }
@@ -935,7 +2275,7 @@ func file_intr_v1_intr_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_intr_v1_intr_proto_rawDesc), len(file_intr_v1_intr_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InteractiveService_IncrReadCnt_FullMethodName   = "/intr.v1.InteractiveService/IncrReadCnt"
-	InteractiveService_Like_FullMethodName          = "/intr.v1.InteractiveService/Like"
-	InteractiveService_CancelLike_FullMethodName    = "/intr.v1.InteractiveService/CancelLike"
-	InteractiveService_Collect_FullMethodName       = "/intr.v1.InteractiveService/Collect"
-	InteractiveService_CancelCollect_FullMethodName = "/intr.v1.InteractiveService/CancelCollect"
-	InteractiveService_Get_FullMethodName           = "/intr.v1.InteractiveService/Get"
-	InteractiveService_GetByIds_FullMethodName      = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_IncrReadCnt_FullMethodName       = "/intr.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName              = "/intr.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName        = "/intr.v1.InteractiveService/CancelLike"
+	InteractiveService_Collect_FullMethodName           = "/intr.v1.InteractiveService/Collect"
+	InteractiveService_CancelCollect_FullMethodName     = "/intr.v1.InteractiveService/CancelCollect"
+	InteractiveService_Get_FullMethodName               = "/intr.v1.InteractiveService/Get"
+	InteractiveService_GetByIds_FullMethodName          = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_GetUserStateByIds_FullMethodName = "/intr.v1.InteractiveService/GetUserStateByIds"
+	InteractiveService_ListLikers_FullMethodName        = "/intr.v1.InteractiveService/ListLikers"
+	InteractiveService_ListLikedByUser_FullMethodName   = "/intr.v1.InteractiveService/ListLikedByUser"
+	InteractiveService_GetStats_FullMethodName          = "/intr.v1.InteractiveService/GetStats"
+	InteractiveService_GetStatsByIds_FullMethodName     = "/intr.v1.InteractiveService/GetStatsByIds"
+	InteractiveService_React_FullMethodName             = "/intr.v1.InteractiveService/React"
+	InteractiveService_CancelReaction_FullMethodName    = "/intr.v1.InteractiveService/CancelReaction"
+	InteractiveService_ListReactions_FullMethodName     = "/intr.v1.InteractiveService/ListReactions"
 )
 
 // InteractiveServiceClient is the client API for InteractiveService service.
@@ -39,6 +47,14 @@ type InteractiveServiceClient interface {
 	CancelCollect(ctx context.Context, in *CancelCollectRequest, opts ...grpc.CallOption) (*CancelCollectResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error)
+	GetUserStateByIds(ctx context.Context, in *GetUserStateByIdsRequest, opts ...grpc.CallOption) (*GetUserStateByIdsResponse, error)
+	ListLikers(ctx context.Context, in *ListLikersRequest, opts ...grpc.CallOption) (*ListLikersResponse, error)
+	ListLikedByUser(ctx context.Context, in *ListLikedByUserRequest, opts ...grpc.CallOption) (*ListLikedByUserResponse, error)
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	GetStatsByIds(ctx context.Context, in *GetStatsByIdsRequest, opts ...grpc.CallOption) (*GetStatsByIdsResponse, error)
+	React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
+	CancelReaction(ctx context.Context, in *CancelReactionRequest, opts ...grpc.CallOption) (*CancelReactionResponse, error)
+	ListReactions(ctx context.Context, in *ListReactionsRequest, opts ...grpc.CallOption) (*ListReactionsResponse, error)
 }
 
 type interactiveServiceClient struct {
@@ -119,6 +135,86 @@ func (c *interactiveServiceClient) GetByIds(ctx context.Context, in *GetByIdsReq
 	return out, nil
 }
 
+func (c *interactiveServiceClient) GetUserStateByIds(ctx context.Context, in *GetUserStateByIdsRequest, opts ...grpc.CallOption) (*GetUserStateByIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserStateByIdsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetUserStateByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListLikers(ctx context.Context, in *ListLikersRequest, opts ...grpc.CallOption) (*ListLikersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikersResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListLikers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListLikedByUser(ctx context.Context, in *ListLikedByUserRequest, opts ...grpc.CallOption) (*ListLikedByUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikedByUserResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListLikedByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) GetStatsByIds(ctx context.Context, in *GetStatsByIdsRequest, opts ...grpc.CallOption) (*GetStatsByIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsByIdsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetStatsByIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_React_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CancelReaction(ctx context.Context, in *CancelReactionRequest, opts ...grpc.CallOption) (*CancelReactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelReactionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) ListReactions(ctx context.Context, in *ListReactionsRequest, opts ...grpc.CallOption) (*ListReactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReactionsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_ListReactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractiveServiceServer is the server API for InteractiveService service.
 // All implementations must embed UnimplementedInteractiveServiceServer
 // for forward compatibility.
@@ -130,6 +226,14 @@ type InteractiveServiceServer interface {
 	CancelCollect(context.Context, *CancelCollectRequest) (*CancelCollectResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error)
+	GetUserStateByIds(context.Context, *GetUserStateByIdsRequest) (*GetUserStateByIdsResponse, error)
+	ListLikers(context.Context, *ListLikersRequest) (*ListLikersResponse, error)
+	ListLikedByUser(context.Context, *ListLikedByUserRequest) (*ListLikedByUserResponse, error)
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	GetStatsByIds(context.Context, *GetStatsByIdsRequest) (*GetStatsByIdsResponse, error)
+	React(context.Context, *ReactRequest) (*ReactResponse, error)
+	CancelReaction(context.Context, *CancelReactionRequest) (*CancelReactionResponse, error)
+	ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error)
 	mustEmbedUnimplementedInteractiveServiceServer()
 }
 
@@ -161,6 +265,30 @@ func (UnimplementedInteractiveServiceServer) Get(context.Context, *GetRequest) (
 func (UnimplementedInteractiveServiceServer) GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByIds not implemented")
 }
+func (UnimplementedInteractiveServiceServer) GetUserStateByIds(context.Context, *GetUserStateByIdsRequest) (*GetUserStateByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserStateByIds not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListLikers(context.Context, *ListLikersRequest) (*ListLikersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikers not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListLikedByUser(context.Context, *ListLikedByUserRequest) (*ListLikedByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikedByUser not implemented")
+}
+func (UnimplementedInteractiveServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedInteractiveServiceServer) GetStatsByIds(context.Context, *GetStatsByIdsRequest) (*GetStatsByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatsByIds not implemented")
+}
+func (UnimplementedInteractiveServiceServer) React(context.Context, *ReactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method React not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CancelReaction(context.Context, *CancelReactionRequest) (*CancelReactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReaction not implemented")
+}
+func (UnimplementedInteractiveServiceServer) ListReactions(context.Context, *ListReactionsRequest) (*ListReactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReactions not implemented")
+}
 func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
 func (UnimplementedInteractiveServiceServer) testEmbeddedByValue()                            {}
 
@@ -308,6 +436,150 @@ func _InteractiveService_GetByIds_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractiveService_GetUserStateByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserStateByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetUserStateByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetUserStateByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetUserStateByIds(ctx, req.(*GetUserStateByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListLikers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListLikers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListLikers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListLikers(ctx, req.(*ListLikersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListLikedByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikedByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListLikedByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListLikedByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListLikedByUser(ctx, req.(*ListLikedByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_GetStatsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetStatsByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetStatsByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetStatsByIds(ctx, req.(*GetStatsByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).React(ctx, req.(*ReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CancelReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelReaction(ctx, req.(*CancelReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_ListReactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).ListReactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_ListReactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).ListReactions(ctx, req.(*ListReactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -343,6 +615,38 @@ var InteractiveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByIds",
 			Handler:    _InteractiveService_GetByIds_Handler,
 		},
+		{
+			MethodName: "GetUserStateByIds",
+			Handler:    _InteractiveService_GetUserStateByIds_Handler,
+		},
+		{
+			MethodName: "ListLikers",
+			Handler:    _InteractiveService_ListLikers_Handler,
+		},
+		{
+			MethodName: "ListLikedByUser",
+			Handler:    _InteractiveService_ListLikedByUser_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _InteractiveService_GetStats_Handler,
+		},
+		{
+			MethodName: "GetStatsByIds",
+			Handler:    _InteractiveService_GetStatsByIds_Handler,
+		},
+		{
+			MethodName: "React",
+			Handler:    _InteractiveService_React_Handler,
+		},
+		{
+			MethodName: "CancelReaction",
+			Handler:    _InteractiveService_CancelReaction_Handler,
+		},
+		{
+			MethodName: "ListReactions",
+			Handler:    _InteractiveService_ListReactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "intr/v1/intr.proto",
@@ -4,10 +4,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
 	"red-feed/internal/events"
+	"red-feed/internal/job"
 )
 
 type App struct {
 	web       *gin.Engine
 	consumers []events.Consumer
 	cron      *cron.Cron
+	// scheduler 抢占数据库里面的任务记录，同一个任务多个实例只有一个在跑
+	scheduler *job.Scheduler
 }
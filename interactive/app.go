@@ -1,8 +1,10 @@
 package main
 
 import (
+	"github.com/robfig/cron/v3"
 	"red-feed/interactive/events"
 	"red-feed/pkg/grpcx"
+	"red-feed/pkg/logger"
 )
 
 type App struct {
@@ -10,4 +12,22 @@ type App struct {
 	// 核心就是为了控制生命周期
 	server    *grpcx.Server
 	consumers []events.Consumer
+	cron      *cron.Cron
+	l         logger.Logger
+}
+
+// Shutdown 先停消费者，不再产生新的写入，再停定时任务，最后等 gRPC 正在处理的请求处理完
+func (a *App) Shutdown() {
+	for _, c := range a.consumers {
+		err := c.Stop()
+		if err != nil {
+			a.l.Error("关闭消费者失败", logger.Error(err))
+		}
+	}
+	// 等正在运行的任务跑完
+	<-a.cron.Stop().Done()
+	err := a.server.Shutdown()
+	if err != nil {
+		a.l.Error("从注册中心注销失败", logger.Error(err))
+	}
 }
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrUnknownBiz      = errors.New("未知的业务")
+	ErrCounterDisabled = errors.New("这个业务没有打开这个计数")
+	ErrBizNotFound     = errors.New("资源不存在")
+)
+
+const (
+	BizArticle = "article"
+
+	// DefaultBizCacheTTL 计数缓存的过期时间，没有配置的时候用
+	DefaultBizCacheTTL = time.Minute * 15
+)
+
+// Counter 业务可以打开的计数，表情算在点赞里面
+type Counter string
+
+const (
+	CounterRead    Counter = "read"
+	CounterLike    Counter = "like"
+	CounterCollect Counter = "collect"
+)
+
+// AllCounters 没有配置 Counters 的时候全部打开
+var AllCounters = []Counter{CounterRead, CounterLike, CounterCollect}
+
+// BizExistChecker 点赞、收藏、发表情之前检查资源存不存在，nil 就是不检查
+type BizExistChecker func(ctx context.Context, bizId int64) (bool, error)
+
+// BizConfig 一种业务的配置
+type BizConfig struct {
+	Biz      string          `yaml:"biz"`
+	CacheTTL time.Duration   `yaml:"cacheTTL"`
+	Counters []Counter       `yaml:"counters"`
+	Exists   BizExistChecker `yaml:"-"`
+}
+
+func (c BizConfig) Enabled(counter Counter) bool {
+	for _, cnt := range c.Counters {
+		if cnt == counter {
+			return true
+		}
+	}
+	return false
+}
+
+// BizRegistry 已知的业务，不在里面的 biz 都会被拒绝，免得写错了悄悄多出一份计数。
+// 启动的时候注册完，之后只读
+type BizRegistry struct {
+	bizs map[string]BizConfig
+}
+
+func NewBizRegistry(cfgs ...BizConfig) *BizRegistry {
+	r := &BizRegistry{
+		bizs: make(map[string]BizConfig, len(cfgs)),
+	}
+	for _, cfg := range cfgs {
+		r.Register(cfg)
+	}
+	return r
+}
+
+// Register 重复注册的后面的覆盖前面的
+func (r *BizRegistry) Register(cfg BizConfig) {
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = DefaultBizCacheTTL
+	}
+	if len(cfg.Counters) == 0 {
+		cfg.Counters = AllCounters
+	}
+	r.bizs[cfg.Biz] = cfg
+}
+
+// SetExistChecker 检查资源存不存在一般要调用别的服务，所以和配置分开设置
+func (r *BizRegistry) SetExistChecker(biz string, checker BizExistChecker) error {
+	cfg, ok := r.bizs[biz]
+	if !ok {
+		return ErrUnknownBiz
+	}
+	cfg.Exists = checker
+	r.bizs[biz] = cfg
+	return nil
+}
+
+func (r *BizRegistry) Get(biz string) (BizConfig, error) {
+	cfg, ok := r.bizs[biz]
+	if !ok {
+		return BizConfig{}, ErrUnknownBiz
+	}
+	return cfg, nil
+}
+
+// Check biz 是已知的，并且打开了 counter
+func (r *BizRegistry) Check(biz string, counter Counter) error {
+	cfg, err := r.Get(biz)
+	if err != nil {
+		return err
+	}
+	if !cfg.Enabled(counter) {
+		return ErrCounterDisabled
+	}
+	return nil
+}
+
+// CheckExist 在 Check 的基础上检查资源存不存在
+func (r *BizRegistry) CheckExist(ctx context.Context, biz string, bizId int64, counter Counter) error {
+	if err := r.Check(biz, counter); err != nil {
+		return err
+	}
+	exists := r.bizs[biz].Exists
+	if exists == nil {
+		return nil
+	}
+	ok, err := exists(ctx, bizId)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrBizNotFound
+	}
+	return nil
+}
+
+// CacheTTL 未知的业务用默认的
+func (r *BizRegistry) CacheTTL(biz string) time.Duration {
+	if cfg, ok := r.bizs[biz]; ok {
+		return cfg.CacheTTL
+	}
+	return DefaultBizCacheTTL
+}
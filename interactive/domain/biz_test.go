@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestBizRegistry_CheckExist(t *testing.T) {
+	r := NewBizRegistry(
+		BizConfig{Biz: BizArticle},
+		BizConfig{Biz: "comment", Counters: []Counter{CounterLike}},
+	)
+	err := r.SetExistChecker(BizArticle, func(ctx context.Context, bizId int64) (bool, error) {
+		switch bizId {
+		case 1:
+			return true, nil
+		case 2:
+			return false, nil
+		default:
+			return false, errors.New("db error")
+		}
+	})
+	assert.NoError(t, err)
+	testCases := []struct {
+		name    string
+		biz     string
+		bizId   int64
+		counter Counter
+		wantErr error
+	}{
+		{
+			name:    "资源存在",
+			biz:     BizArticle,
+			bizId:   1,
+			counter: CounterLike,
+		},
+		{
+			name:    "资源不存在",
+			biz:     BizArticle,
+			bizId:   2,
+			counter: CounterCollect,
+			wantErr: ErrBizNotFound,
+		},
+		{
+			name:    "检查失败",
+			biz:     BizArticle,
+			bizId:   3,
+			counter: CounterLike,
+			wantErr: errors.New("db error"),
+		},
+		{
+			name:    "写错了的业务",
+			biz:     "artcle",
+			bizId:   1,
+			counter: CounterLike,
+			wantErr: ErrUnknownBiz,
+		},
+		{
+			name:    "没有打开的计数",
+			biz:     "comment",
+			bizId:   1,
+			counter: CounterCollect,
+			wantErr: ErrCounterDisabled,
+		},
+		{
+			name:    "没有检查函数的不检查",
+			biz:     "comment",
+			bizId:   2,
+			counter: CounterLike,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := r.CheckExist(context.Background(), tc.biz, tc.bizId, tc.counter)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestBizRegistry_CacheTTL(t *testing.T) {
+	r := NewBizRegistry(BizConfig{Biz: "video", CacheTTL: time.Minute})
+	assert.Equal(t, time.Minute, r.CacheTTL("video"))
+	assert.Equal(t, DefaultBizCacheTTL, r.CacheTTL("unknown"))
+	assert.Equal(t, ErrUnknownBiz, r.SetExistChecker("unknown", nil))
+}
@@ -0,0 +1,20 @@
+package domain
+
+// CntDelta 还没有写到数据库的计数变化
+type CntDelta struct {
+	LikeCnt    int64
+	CollectCnt int64
+}
+
+func (d CntDelta) IsZero() bool {
+	return d.LikeCnt == 0 && d.CollectCnt == 0
+}
+
+// CntFlush 一次把某个资源攒下来的计数变化写到数据库。
+// FlushId 在领取的时候生成，重试的时候不变，数据库靠它保证同一次 flush 只生效一次
+type CntFlush struct {
+	FlushId string
+	Biz     string
+	BizId   int64
+	Delta   CntDelta
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// Collection 收藏夹
+type Collection struct {
+	Id          int64
+	Uid         int64
+	Name        string
+	Description string
+	// Public 公开的收藏夹别人也能看
+	Public bool
+	// IsDefault 默认收藏夹，第一次用到的时候自动创建，不能删除
+	IsDefault bool
+	Ctime     time.Time
+	Utime     time.Time
+}
+
+// CollectionItem 收藏夹里面的一条收藏
+type CollectionItem struct {
+	Cid   int64
+	Biz   string
+	BizId int64
+	// Ctime 收藏的时间
+	Ctime time.Time
+}
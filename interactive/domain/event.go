@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+type InteractiveEventType string
+
+const (
+	EventLiked       InteractiveEventType = "liked"
+	EventUnliked     InteractiveEventType = "unliked"
+	EventCollected   InteractiveEventType = "collected"
+	EventUncollected InteractiveEventType = "uncollected"
+)
+
+// InteractiveEvent 点赞、收藏和取消，状态真的变了才会有。
+// Id 是 outbox 里面的 id，EventId 发出去之后给下游去重
+type InteractiveEvent struct {
+	Id      int64
+	EventId string
+	Type    InteractiveEventType
+	Biz     string
+	BizId   int64
+	Uid     int64
+	Ctime   time.Time
+}
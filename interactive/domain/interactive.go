@@ -1,11 +1,98 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLikeCursor = errors.New("游标不对")
+
 type Interactive struct {
 	Biz        string `json:"biz"`
 	BizId      int64  `json:"biz_id"`
 	ReadCnt    int64  `json:"read_cnt"`
 	LikeCnt    int64  `json:"like_cnt"`
 	CollectCnt int64  `json:"collect_cnt"`
-	Liked      bool   `json:"liked"`
-	Collected  bool   `json:"collected"`
+	// UvCnt 读过的人数，同一个人读多少次都只算一次，是个估计值
+	UvCnt int64 `json:"uv_cnt"`
+	// ReactionCnts 每种表情的数量，默认的表情就是 LikeCnt
+	ReactionCnts map[string]int64 `json:"reaction_cnts"`
+	Liked        bool             `json:"liked"`
+	Collected    bool             `json:"collected"`
+	// Reaction 当前用户用的表情，没有就是空字符串
+	Reaction string `json:"reaction"`
+}
+
+// UserState 用户对某个资源是否点赞、收藏了
+type UserState struct {
+	BizId     int64
+	Liked     bool
+	Collected bool
+}
+
+// LikedItem 用户点赞的一个资源
+type LikedItem struct {
+	BizId int64
+	Utime time.Time
+}
+
+// LikeRecord 一条点赞记录
+type LikeRecord struct {
+	Id    int64
+	Uid   int64
+	Biz   string
+	BizId int64
+	// Utime 点赞的时间
+	Utime time.Time
+}
+
+// Cursor 从这一条往后翻页的游标
+func (r LikeRecord) Cursor() LikeCursor {
+	return LikeCursor{Utime: r.Utime.UnixMilli(), Id: r.Id}
+}
+
+// LikeCursor 点赞列表按照点赞时间倒序翻页，时间相同的再按照 id 倒序。
+// 零值表示从头开始
+type LikeCursor struct {
+	Utime int64
+	Id    int64
+}
+
+func (c LikeCursor) IsZero() bool {
+	return c.Utime == 0 && c.Id == 0
+}
+
+// String 给客户端的游标，零值返回空字符串
+func (c LikeCursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d_%d", c.Utime, c.Id)
+}
+
+// ParseLikeCursor 解析 String 生成的游标，空字符串就是零值
+func ParseLikeCursor(s string) (LikeCursor, error) {
+	if s == "" {
+		return LikeCursor{}, nil
+	}
+	utime, id, ok := strings.Cut(s, "_")
+	if !ok {
+		return LikeCursor{}, ErrInvalidLikeCursor
+	}
+	var (
+		c   LikeCursor
+		err error
+	)
+	c.Utime, err = strconv.ParseInt(utime, 10, 64)
+	if err != nil {
+		return LikeCursor{}, ErrInvalidLikeCursor
+	}
+	c.Id, err = strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return LikeCursor{}, ErrInvalidLikeCursor
+	}
+	return c, nil
 }
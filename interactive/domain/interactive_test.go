@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseLikeCursor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		cursor string
+
+		wantCursor LikeCursor
+		wantErr    error
+	}{
+		{
+			name: "第一页",
+		},
+		{
+			name:       "正常的游标",
+			cursor:     "1700000000000_12",
+			wantCursor: LikeCursor{Utime: 1700000000000, Id: 12},
+		},
+		{
+			name:    "没有分隔符",
+			cursor:  "1700000000000",
+			wantErr: ErrInvalidLikeCursor,
+		},
+		{
+			name:    "不是数字",
+			cursor:  "abc_12",
+			wantErr: ErrInvalidLikeCursor,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseLikeCursor(tc.cursor)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCursor, c)
+		})
+	}
+}
+
+func TestLikeRecord_Cursor(t *testing.T) {
+	r := LikeRecord{Id: 12, Utime: time.UnixMilli(1700000000000)}
+	c, err := ParseLikeCursor(r.Cursor().String())
+	assert.NoError(t, err)
+	assert.Equal(t, r.Cursor(), c)
+	assert.Equal(t, "", LikeCursor{}.String())
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "errors"
+
+var ErrInvalidReaction = errors.New("不支持这个表情")
+
+// ReactionLike 默认的表情，就是原来的点赞，记录和计数都还是点赞的
+const ReactionLike = "like"
+
+// Reaction 用户可以对资源使用的表情，Key 存到数据库里面，Emoji 给客户端展示
+type Reaction struct {
+	Key   string `yaml:"key"`
+	Emoji string `yaml:"emoji"`
+}
+
+// Reactions 配置的表情，必须包含 ReactionLike
+type Reactions []Reaction
+
+func (rs Reactions) Contains(key string) bool {
+	for _, r := range rs {
+		if r.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultReactions 没有配置的时候用
+var DefaultReactions = Reactions{
+	{Key: ReactionLike, Emoji: "👍"},
+	{Key: "heart", Emoji: "❤️"},
+	{Key: "laugh", Emoji: "😂"},
+	{Key: "tada", Emoji: "🎉"},
+}
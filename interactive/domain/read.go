@@ -0,0 +1,18 @@
+package domain
+
+// ReadView 一次阅读，用来判断是不是重复阅读或者刷量
+type ReadView struct {
+	BizId int64
+	// Reader 登录用户是 uid，匿名读者是设备 id，为空的时候不判断重复阅读
+	Reader string
+	// Ip 为空的时候不判断同一个 ip 的突发流量
+	Ip string
+}
+
+// ReadViewResult 记录阅读之后的结果
+type ReadViewResult struct {
+	// First 窗口期内第一次阅读
+	First bool
+	// IpCnt 窗口期内这个 ip 的阅读次数，包括这一次
+	IpCnt int64
+}
@@ -0,0 +1,14 @@
+package domain
+
+// CntMismatch 计数和点赞、收藏记录对不上的资源。
+// Expected 开头的是按照记录数出来的
+type CntMismatch struct {
+	Biz                string
+	BizId              int64
+	LikeCnt            int64
+	ExpectedLikeCnt    int64
+	CollectCnt         int64
+	ExpectedCollectCnt int64
+	// Repaired 已经改成了按照记录数出来的值
+	Repaired bool
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidStatsRange = errors.New("统计的时间范围不对")
+
+// StatsGranularity 按小时还是按天统计
+type StatsGranularity string
+
+const (
+	StatsGranularityHour StatsGranularity = "hour"
+	StatsGranularityDay  StatsGranularity = "day"
+)
+
+func (g StatsGranularity) Valid() bool {
+	return g == StatsGranularityHour || g == StatsGranularityDay
+}
+
+// Truncate 时间点所在的那个小时或者那一天的开始，按照本地时间
+func (g StatsGranularity) Truncate(t time.Time) time.Time {
+	if g == StatsGranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(time.Hour)
+}
+
+// Next 下一个小时或者下一天的开始
+func (g StatsGranularity) Next(t time.Time) time.Time {
+	if g == StatsGranularityDay {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Hour)
+}
+
+// StatsCnt 一段时间里面新增的阅读、点赞、收藏。取消点赞、收藏的算负的，所以可能是负数
+type StatsCnt struct {
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+}
+
+func (c StatsCnt) Add(other StatsCnt) StatsCnt {
+	return StatsCnt{
+		ReadCnt:    c.ReadCnt + other.ReadCnt,
+		LikeCnt:    c.LikeCnt + other.LikeCnt,
+		CollectCnt: c.CollectCnt + other.CollectCnt,
+	}
+}
+
+// StatsPoint 时间序列里面的一个点，Time 是这个小时或者这一天的开始
+type StatsPoint struct {
+	Time time.Time
+	StatsCnt
+}
+
+// StatsIncr 一次计数变化，算在 Time 所在的那个小时里面
+type StatsIncr struct {
+	Biz   string
+	BizId int64
+	Time  time.Time
+	StatsCnt
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+// Visit 一个读者读了一次资源
+type Visit struct {
+	Biz   string
+	BizId int64
+	// Visitor 登录用户是 uid，匿名读者是设备 id，都没有的时候是 ip
+	Visitor string
+	Time    time.Time
+}
+
+// UvCnt 某一天有人读过的资源，Date 那天的人数和一共的人数
+type UvCnt struct {
+	Biz   string
+	BizId int64
+	// Date 格式是 20060102
+	Date       string
+	DailyUvCnt int64
+	UvCnt      int64
+}
@@ -0,0 +1,9 @@
+package events
+
+const topicDeleteEvent = "article_delete_event"
+
+// DeleteEvent 帖子被彻底删除
+type DeleteEvent struct {
+	Aid int64
+	Uid int64
+}
@@ -1,8 +1,69 @@
 package events
 
+import (
+	"red-feed/interactive/domain"
+	"strconv"
+	"time"
+)
+
 const topicReadEvent = "article_read_event"
 
+// ReadEvent 匿名读者 Uid 为 0，用 DeviceId 区分
 type ReadEvent struct {
-	Uid int64
-	Aid int64
+	// EventId 发送的时候生成，消费者用来去重。老版本发出来的事件没有
+	EventId   string
+	Uid       int64
+	Aid       int64
+	DeviceId  string
+	Ip        string
+	UserAgent string
+	// Ctime 阅读的时间，毫秒数。老版本发出来的事件没有
+	Ctime int64
+}
+
+// readTime 消息积压的时候消费的时间会晚很多，按天统计的 UV 要记到阅读的那一天
+func (e ReadEvent) readTime(now time.Time) time.Time {
+	if e.Ctime > 0 {
+		return time.UnixMilli(e.Ctime)
+	}
+	return now
+}
+
+// toVisits 有效的阅读才算读者，登录用户按照 uid 算，匿名读者按照设备算，都没有的只能按照 ip 算
+func toVisits(biz string, evts []ReadEvent, now time.Time) []domain.Visit {
+	res := make([]domain.Visit, 0, len(evts))
+	for _, evt := range evts {
+		var visitor string
+		switch {
+		case evt.Uid > 0:
+			visitor = "u" + strconv.FormatInt(evt.Uid, 10)
+		case evt.DeviceId != "":
+			visitor = "d" + evt.DeviceId
+		case evt.Ip != "":
+			visitor = "i" + evt.Ip
+		default:
+			continue
+		}
+		res = append(res, domain.Visit{
+			Biz:     biz,
+			BizId:   evt.Aid,
+			Visitor: visitor,
+			Time:    evt.readTime(now),
+		})
+	}
+	return res
+}
+
+// toReadStats 有效的阅读按照阅读的时间记到每个小时的阅读数上
+func toReadStats(biz string, evts []ReadEvent, now time.Time) []domain.StatsIncr {
+	res := make([]domain.StatsIncr, 0, len(evts))
+	for _, evt := range evts {
+		res = append(res, domain.StatsIncr{
+			Biz:      biz,
+			BizId:    evt.Aid,
+			Time:     evt.readTime(now),
+			StatsCnt: domain.StatsCnt{ReadCnt: 1},
+		})
+	}
+	return res
 }
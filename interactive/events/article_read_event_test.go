@@ -0,0 +1,38 @@
+package events
+
+import (
+	"github.com/stretchr/testify/assert"
+	"red-feed/interactive/domain"
+	"testing"
+	"time"
+)
+
+func TestToVisits(t *testing.T) {
+	now := time.UnixMilli(2000)
+	testCases := []struct {
+		name string
+		evts []ReadEvent
+		want []domain.Visit
+	}{
+		{
+			name: "按照阅读的时间记",
+			evts: []ReadEvent{{Uid: 123, Aid: 1, Ctime: 1000}},
+			want: []domain.Visit{{Biz: "article", BizId: 1, Visitor: "u123", Time: time.UnixMilli(1000)}},
+		},
+		{
+			name: "老版本的事件没有阅读时间，用消费的时间",
+			evts: []ReadEvent{{DeviceId: "dev", Aid: 1}},
+			want: []domain.Visit{{Biz: "article", BizId: 1, Visitor: "ddev", Time: now}},
+		},
+		{
+			name: "认不出读者的不算",
+			evts: []ReadEvent{{Aid: 1, Ctime: 1000}, {Ip: "1.1.1.1", Aid: 2, Ctime: 1000}},
+			want: []domain.Visit{{Biz: "article", BizId: 2, Visitor: "i1.1.1.1", Time: time.UnixMilli(1000)}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, toVisits(domain.BizArticle, tc.evts, now))
+		})
+	}
+}
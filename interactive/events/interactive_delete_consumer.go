@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/saramax"
+	"time"
+)
+
+// InteractiveDeleteEventConsumer 帖子被彻底删除之后，清理掉对应的计数、点赞和收藏记录
+type InteractiveDeleteEventConsumer struct {
+	client sarama.Client
+	cg     sarama.ConsumerGroup
+	repo   repository.InteractiveRepository
+	l      logger.Logger
+}
+
+func NewInteractiveDeleteEventConsumer(client sarama.Client,
+	repo repository.InteractiveRepository,
+	l logger.Logger) *InteractiveDeleteEventConsumer {
+	return &InteractiveDeleteEventConsumer{client: client, repo: repo, l: l}
+}
+
+func (r *InteractiveDeleteEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_delete",
+		r.client)
+	if err != nil {
+		return err
+	}
+	r.cg = cg
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicDeleteEvent},
+			saramax.NewHandler[DeleteEvent](r.l, r.Consume))
+		if err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			r.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Stop 关闭消费者组，删除是幂等的，没有提交的消息重新投递也没关系
+func (r *InteractiveDeleteEventConsumer) Stop() error {
+	if r.cg == nil {
+		return nil
+	}
+	return r.cg.Close()
+}
+
+// Consume 删除本身是幂等的，重复消费没有影响
+func (r *InteractiveDeleteEventConsumer) Consume(msg *sarama.ConsumerMessage, evt DeleteEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	return r.repo.DeleteBiz(ctx, domain.BizArticle, evt.Aid)
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/IBM/sarama"
+)
+
+const topicInteractiveEvent = "interactive_event"
+
+// InteractiveEvent 点赞、取消点赞、收藏、取消收藏。
+// 至少发送一次，下游按照 EventId 去重；同一个资源的事件在同一个分区，按照发生的顺序
+type InteractiveEvent struct {
+	EventId string
+	// Type 是 liked、unliked、collected、uncollected
+	Type  string
+	Biz   string
+	BizId int64
+	Uid   int64
+	// Ctime 毫秒数
+	Ctime int64
+}
+
+//go:generate mockgen -source=./interactive_event.go -package=evtmocks -destination=mocks/interactive_event.mock.go InteractiveEventProducer
+type InteractiveEventProducer interface {
+	// ProduceInteractiveEvents 一批事件一起发，要么都成功，要么返回错误
+	ProduceInteractiveEvents(ctx context.Context, evts []InteractiveEvent) error
+}
+
+type KafkaInteractiveEventProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewKafkaInteractiveEventProducer(pc sarama.SyncProducer) InteractiveEventProducer {
+	return &KafkaInteractiveEventProducer{
+		producer: pc,
+	}
+}
+
+func (k *KafkaInteractiveEventProducer) ProduceInteractiveEvents(ctx context.Context, evts []InteractiveEvent) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(evts))
+	for _, evt := range evts {
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: topicInteractiveEvent,
+			Key:   sarama.StringEncoder(fmt.Sprintf("%s:%d", evt.Biz, evt.BizId)),
+			Value: sarama.ByteEncoder(data),
+		})
+	}
+	return k.producer.SendMessages(msgs)
+}
@@ -2,8 +2,11 @@ package events
 
 import (
 	"context"
+	"errors"
 	"github.com/IBM/sarama"
+	"red-feed/interactive/domain"
 	"red-feed/interactive/repository"
+	"red-feed/interactive/repository/cache"
 	"red-feed/pkg/logger"
 	"red-feed/pkg/saramax"
 	"time"
@@ -11,12 +14,28 @@ import (
 
 type InteractiveReadEventBatchConsumer struct {
 	client sarama.Client
+	cg     sarama.ConsumerGroup
 	repo   repository.InteractiveRepository
+	uv     repository.UvRepository
+	stats  repository.StatsRepository
+	dedup  readEventDedup
+	guard  *ReadGuard
+	batch  saramax.BatchConfig
 	l      logger.Logger
 }
 
-func NewInteractiveReadEventBatchConsumer(client sarama.Client, repo repository.InteractiveRepository, l logger.Logger) Consumer {
-	return &InteractiveReadEventBatchConsumer{client: client, repo: repo, l: l}
+func NewInteractiveReadEventBatchConsumer(client sarama.Client, repo repository.InteractiveRepository,
+	uv repository.UvRepository, stats repository.StatsRepository, dedup cache.EventDedupCache, guard *ReadGuard, batch saramax.BatchConfig, l logger.Logger) Consumer {
+	return &InteractiveReadEventBatchConsumer{
+		client: client,
+		repo:   repo,
+		uv:     uv,
+		stats:  stats,
+		dedup:  newReadEventDedup(dedup, l, "batch"),
+		guard:  guard,
+		batch:  batch,
+		l:      l,
+	}
 }
 
 func (r *InteractiveReadEventBatchConsumer) Start() error {
@@ -25,32 +44,89 @@ func (r *InteractiveReadEventBatchConsumer) Start() error {
 	if err != nil {
 		return err
 	}
+	r.cg = cg
 	go func() {
 		err := cg.Consume(context.Background(),
 			[]string{topicReadEvent},
-			saramax.NewBatchHandler[ReadEvent](r.l, r.Consume))
-		if err != nil {
+			saramax.NewBatchHandler[ReadEvent](r.l, r.batch, r.Consume))
+		if err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
 			r.l.Error("退出了消费循环异常", logger.Error(err))
 		}
 	}()
 	return err
 }
 
-// Consume 这个不是幂等的
+// Stop 关闭消费者组，正在攒的那一批不会提交，下次重新投递，靠 EventId 去重
+func (r *InteractiveReadEventBatchConsumer) Stop() error {
+	if r.cg == nil {
+		return nil
+	}
+	return r.cg.Close()
+}
+
+// Consume 先按照 EventId 去重，重复投递的事件不会重复计数。
+// 再把重复阅读和疑似刷量的挑出来，这部分单独计数。
+// 计数失败的事件去掉去重标记和阅读标记，这一批也不提交，重新投递的时候还能计数
 func (r *InteractiveReadEventBatchConsumer) Consume(msg []*sarama.ConsumerMessage, ts []ReadEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ts = r.dedup.filter(ctx, ts)
+	if len(ts) == 0 {
+		return nil
+	}
+	var res error
+	valid, invalid := r.guard.Split(ctx, domain.BizArticle, ts)
+	if len(valid.Events) > 0 {
+		bizs, ids := r.bizIds(valid.Events)
+		err := r.repo.BatchIncrReadCnt(ctx, bizs, ids)
+		if err != nil {
+			r.l.Error("批量增加阅读计数失败",
+				logger.Field{Key: "ids", Value: ids},
+				logger.Error(err))
+			r.guard.Release(ctx, domain.BizArticle, valid)
+			r.dedup.release(ctx, valid.Events)
+			res = err
+		} else {
+			r.addVisits(ctx, valid.Events)
+			r.incrStats(ctx, valid.Events)
+		}
+	}
+	if len(invalid.Events) > 0 {
+		bizs, ids := r.bizIds(invalid.Events)
+		err := r.repo.BatchIncrInvalidReadCnt(ctx, bizs, ids)
+		if err != nil {
+			r.l.Error("批量增加无效阅读计数失败",
+				logger.Field{Key: "ids", Value: ids},
+				logger.Error(err))
+			r.guard.Release(ctx, domain.BizArticle, invalid)
+			r.dedup.release(ctx, invalid.Events)
+			res = err
+		}
+	}
+	return res
+}
+
+// addVisits UV 和分时计数少记一点可以接受，失败了不重试
+func (r *InteractiveReadEventBatchConsumer) addVisits(ctx context.Context, ts []ReadEvent) {
+	err := r.uv.AddVisits(ctx, toVisits(domain.BizArticle, ts, time.Now()))
+	if err != nil {
+		r.l.Error("记录读者失败", logger.Error(err))
+	}
+}
+
+func (r *InteractiveReadEventBatchConsumer) incrStats(ctx context.Context, ts []ReadEvent) {
+	err := r.stats.Incr(ctx, toReadStats(domain.BizArticle, ts, time.Now()))
+	if err != nil {
+		r.l.Error("记录阅读的分时计数失败", logger.Error(err))
+	}
+}
+
+func (r *InteractiveReadEventBatchConsumer) bizIds(ts []ReadEvent) ([]string, []int64) {
 	ids := make([]int64, 0, len(ts))
 	bizs := make([]string, 0, len(ts))
 	for _, evt := range ts {
 		ids = append(ids, evt.Aid)
-		bizs = append(bizs, "article")
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-	err := r.repo.BatchIncrReadCnt(ctx, bizs, ids)
-	if err != nil {
-		r.l.Error("批量增加阅读计数失败",
-			logger.Field{Key: "ids", Value: ids},
-			logger.Error(err))
+		bizs = append(bizs, domain.BizArticle)
 	}
-	return nil
+	return bizs, ids
 }
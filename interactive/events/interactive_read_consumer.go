@@ -2,8 +2,11 @@ package events
 
 import (
 	"context"
+	"errors"
 	"github.com/IBM/sarama"
+	"red-feed/interactive/domain"
 	"red-feed/interactive/repository"
+	"red-feed/interactive/repository/cache"
 	"red-feed/pkg/logger"
 	"red-feed/pkg/saramax"
 	"time"
@@ -11,7 +14,12 @@ import (
 
 type InteractiveReadEventConsumer struct {
 	client sarama.Client
+	cg     sarama.ConsumerGroup
 	repo   repository.InteractiveRepository
+	uv     repository.UvRepository
+	stats  repository.StatsRepository
+	dedup  readEventDedup
+	guard  *ReadGuard
 	l      logger.Logger
 }
 
@@ -21,11 +29,12 @@ func (r *InteractiveReadEventConsumer) Start() error {
 	if err != nil {
 		return err
 	}
+	r.cg = cg
 	go func() {
 		err := cg.Consume(context.Background(),
 			[]string{topicReadEvent},
 			saramax.NewHandler[ReadEvent](r.l, r.Consume))
-		if err != nil {
+		if err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
 			r.l.Error("退出了消费循环异常", logger.Error(err))
 		}
 	}()
@@ -33,20 +42,63 @@ func (r *InteractiveReadEventConsumer) Start() error {
 
 }
 
-// Consume 这个不是幂等的
+// Stop 关闭消费者组，正在处理的消息处理完才返回，没有提交的消息下次会重新投递
+func (r *InteractiveReadEventConsumer) Stop() error {
+	if r.cg == nil {
+		return nil
+	}
+	return r.cg.Close()
+}
+
+// Consume 先按照 EventId 去重，重复投递的事件不会重复计数。
+// 重复阅读和疑似刷量的单独计数。计数失败的去掉去重标记和阅读标记，重新投递的时候还能计数
 func (r *InteractiveReadEventConsumer) Consume(msg *sarama.ConsumerMessage, t ReadEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return r.repo.IncrReadCnt(ctx, "article", t.Aid)
+	evts := r.dedup.filter(ctx, []ReadEvent{t})
+	if len(evts) == 0 {
+		return nil
+	}
+	valid, invalid := r.guard.Split(ctx, domain.BizArticle, evts)
+	if len(valid.Events) == 0 {
+		err := r.repo.BatchIncrInvalidReadCnt(ctx, []string{domain.BizArticle}, []int64{t.Aid})
+		if err != nil {
+			r.guard.Release(ctx, domain.BizArticle, invalid)
+			r.dedup.release(ctx, evts)
+		}
+		return err
+	}
+	if err := r.repo.IncrReadCnt(ctx, domain.BizArticle, t.Aid); err != nil {
+		r.guard.Release(ctx, domain.BizArticle, valid)
+		r.dedup.release(ctx, evts)
+		return err
+	}
+	// UV 和分时计数少记一点可以接受，失败了不重试
+	now := time.Now()
+	if err := r.uv.AddVisits(ctx, toVisits(domain.BizArticle, valid.Events, now)); err != nil {
+		r.l.Error("记录读者失败", logger.Error(err))
+	}
+	if err := r.stats.Incr(ctx, toReadStats(domain.BizArticle, valid.Events, now)); err != nil {
+		r.l.Error("记录阅读的分时计数失败", logger.Error(err))
+	}
+	return nil
 }
 
 func NewInteractiveReadEventConsumer(
 	client sarama.Client,
 	l logger.Logger,
-	repo repository.InteractiveRepository) Consumer {
+	repo repository.InteractiveRepository,
+	uv repository.UvRepository,
+	stats repository.StatsRepository,
+	dedup cache.EventDedupCache,
+	guard *ReadGuard) Consumer {
 	return &InteractiveReadEventConsumer{
 		client: client,
 		l:      l,
 		repo:   repo,
+		uv:     uv,
+		stats:  stats,
+		dedup:  newReadEventDedup(dedup, l, "single"),
+		guard:  guard,
 	}
 }
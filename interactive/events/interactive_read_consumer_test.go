@@ -0,0 +1,141 @@
+package events
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	repomocks "red-feed/interactive/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestInteractiveReadEventConsumer_Consume(t *testing.T) {
+	evt := ReadEvent{EventId: "a", Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.UvRepository,
+			repository.StatsRepository, *cachemocks.MockEventDedupCache, *cachemocks.MockReadLimitCache)
+
+		wantErr error
+	}{
+		{
+			name: "计数成功",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.UvRepository,
+				repository.StatsRepository, *cachemocks.MockEventDedupCache, *cachemocks.MockReadLimitCache) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				uv := repomocks.NewMockUvRepository(ctrl)
+				stats := repomocks.NewMockStatsRepository(ctrl)
+				dedup := cachemocks.NewMockEventDedupCache(ctrl)
+				limit := cachemocks.NewMockReadLimitCache(ctrl)
+				dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{true}, nil)
+				limit.EXPECT().MarkViews(gomock.Any(), "article", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.ReadViewResult{{First: true, IpCnt: 1}}, nil)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(nil)
+				uv.EXPECT().AddVisits(gomock.Any(), gomock.Any()).Return(nil)
+				stats.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(nil)
+				return repo, uv, stats, dedup, limit
+			},
+		},
+		{
+			name: "重复投递的不计数",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.UvRepository,
+				repository.StatsRepository, *cachemocks.MockEventDedupCache, *cachemocks.MockReadLimitCache) {
+				dedup := cachemocks.NewMockEventDedupCache(ctrl)
+				dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{false}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), repomocks.NewMockUvRepository(ctrl),
+					repomocks.NewMockStatsRepository(ctrl), dedup, cachemocks.NewMockReadLimitCache(ctrl)
+			},
+		},
+		{
+			name: "计数失败，去掉标记等重新投递",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.UvRepository,
+				repository.StatsRepository, *cachemocks.MockEventDedupCache, *cachemocks.MockReadLimitCache) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				dedup := cachemocks.NewMockEventDedupCache(ctrl)
+				limit := cachemocks.NewMockReadLimitCache(ctrl)
+				dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{true}, nil)
+				limit.EXPECT().MarkViews(gomock.Any(), "article", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.ReadViewResult{{First: true, IpCnt: 1}}, nil)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(errors.New("db错误"))
+				limit.EXPECT().UnmarkViews(gomock.Any(), "article",
+					[]domain.ReadView{{BizId: 1, Reader: "u123", Ip: "1.1.1.1"}},
+					[]domain.ReadViewResult{{First: true, IpCnt: 1}}).Return(nil)
+				dedup.EXPECT().Unmark(gomock.Any(), topicReadEvent, []string{"a"}).Return(nil)
+				return repo, repomocks.NewMockUvRepository(ctrl), repomocks.NewMockStatsRepository(ctrl), dedup, limit
+			},
+			wantErr: errors.New("db错误"),
+		},
+		{
+			name: "无效阅读计数失败，也去掉标记",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.UvRepository,
+				repository.StatsRepository, *cachemocks.MockEventDedupCache, *cachemocks.MockReadLimitCache) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				dedup := cachemocks.NewMockEventDedupCache(ctrl)
+				limit := cachemocks.NewMockReadLimitCache(ctrl)
+				dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{true}, nil)
+				limit.EXPECT().MarkViews(gomock.Any(), "article", gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]domain.ReadViewResult{{First: false, IpCnt: 1}}, nil)
+				repo.EXPECT().BatchIncrInvalidReadCnt(gomock.Any(), []string{"article"}, []int64{1}).
+					Return(errors.New("db错误"))
+				limit.EXPECT().UnmarkViews(gomock.Any(), "article",
+					[]domain.ReadView{{BizId: 1, Reader: "u123", Ip: "1.1.1.1"}},
+					[]domain.ReadViewResult{{First: false, IpCnt: 1}}).Return(nil)
+				dedup.EXPECT().Unmark(gomock.Any(), topicReadEvent, []string{"a"}).Return(nil)
+				return repo, repomocks.NewMockUvRepository(ctrl), repomocks.NewMockStatsRepository(ctrl), dedup, limit
+			},
+			wantErr: errors.New("db错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, uv, stats, dedup, limit := tc.mock(ctrl)
+			guard := NewReadGuard(limit, ReadGuardConfig{ViewWindow: time.Minute * 30, IpWindow: time.Minute, IpBurst: 2},
+				&logger.NopLogger{})
+			c := NewInteractiveReadEventConsumer(nil, &logger.NopLogger{}, repo, uv, stats, dedup, guard).(*InteractiveReadEventConsumer)
+			err := c.Consume(nil, evt)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+// 第一次计数失败之后重新投递，还是按照第一次阅读算，只算一次
+func TestInteractiveReadEventConsumer_ConsumeRetry(t *testing.T) {
+	evt := ReadEvent{EventId: "a", Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"}
+	views := []domain.ReadView{{BizId: 1, Reader: "u123", Ip: "1.1.1.1"}}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockInteractiveRepository(ctrl)
+	uv := repomocks.NewMockUvRepository(ctrl)
+	stats := repomocks.NewMockStatsRepository(ctrl)
+	dedup := cachemocks.NewMockEventDedupCache(ctrl)
+	limit := cachemocks.NewMockReadLimitCache(ctrl)
+	gomock.InOrder(
+		dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{true}, nil),
+		limit.EXPECT().MarkViews(gomock.Any(), "article", views, gomock.Any(), gomock.Any()).
+			Return([]domain.ReadViewResult{{First: true, IpCnt: 1}}, nil),
+		repo.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(errors.New("db错误")),
+		limit.EXPECT().UnmarkViews(gomock.Any(), "article", views,
+			[]domain.ReadViewResult{{First: true, IpCnt: 1}}).Return(nil),
+		dedup.EXPECT().Unmark(gomock.Any(), topicReadEvent, []string{"a"}).Return(nil),
+
+		// 标记都撤销了，重新投递的时候还是第一次阅读，ip 的次数也没有多算
+		dedup.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).Return([]bool{true}, nil),
+		limit.EXPECT().MarkViews(gomock.Any(), "article", views, gomock.Any(), gomock.Any()).
+			Return([]domain.ReadViewResult{{First: true, IpCnt: 1}}, nil),
+		repo.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(nil),
+	)
+	uv.EXPECT().AddVisits(gomock.Any(), gomock.Any()).Return(nil)
+	stats.EXPECT().Incr(gomock.Any(), gomock.Any()).Return(nil)
+	guard := NewReadGuard(limit, ReadGuardConfig{ViewWindow: time.Minute * 30, IpWindow: time.Minute, IpBurst: 2},
+		&logger.NopLogger{})
+	c := NewInteractiveReadEventConsumer(nil, &logger.NopLogger{}, repo, uv, stats, dedup, guard).(*InteractiveReadEventConsumer)
+
+	assert.Equal(t, errors.New("db错误"), c.Consume(nil, evt))
+	assert.NoError(t, c.Consume(nil, evt))
+}
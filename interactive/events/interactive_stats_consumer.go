@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+	"red-feed/interactive/repository/cache"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/saramax"
+	"time"
+)
+
+// InteractiveStatsConsumer 把点赞、收藏事件按照发生的时间记到每个小时的计数上。
+// 阅读数在阅读事件的消费者里面记，那边才知道是不是有效的阅读
+type InteractiveStatsConsumer struct {
+	client sarama.Client
+	cg     sarama.ConsumerGroup
+	repo   repository.StatsRepository
+	dedup  cache.EventDedupCache
+	l      logger.Logger
+}
+
+func NewInteractiveStatsConsumer(client sarama.Client, repo repository.StatsRepository,
+	dedup cache.EventDedupCache, l logger.Logger) *InteractiveStatsConsumer {
+	return &InteractiveStatsConsumer{
+		client: client,
+		repo:   repo,
+		dedup:  dedup,
+		l:      l,
+	}
+}
+
+func (c *InteractiveStatsConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("interactive_stats",
+		c.client)
+	if err != nil {
+		return err
+	}
+	c.cg = cg
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicInteractiveEvent},
+			saramax.NewBatchHandler[InteractiveEvent](c.l, saramax.DefaultBatchConfig, c.Consume))
+		if err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
+			c.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+// Stop 关闭消费者组，等正在处理的那一批处理完
+func (c *InteractiveStatsConsumer) Stop() error {
+	if c.cg == nil {
+		return nil
+	}
+	return c.cg.Close()
+}
+
+// Consume 事件是至少发送一次的，先按照 EventId 去重，去重出错的时候宁可重复计数。
+// 写数据库失败的这一批去掉去重标记，也不提交，重新投递的时候还能计数
+func (c *InteractiveStatsConsumer) Consume(msgs []*sarama.ConsumerMessage, evts []InteractiveEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	evts = c.filter(ctx, evts)
+	incrs := make([]domain.StatsIncr, 0, len(evts))
+	for _, evt := range evts {
+		var cnt domain.StatsCnt
+		switch domain.InteractiveEventType(evt.Type) {
+		case domain.EventLiked:
+			cnt.LikeCnt = 1
+		case domain.EventUnliked:
+			cnt.LikeCnt = -1
+		case domain.EventCollected:
+			cnt.CollectCnt = 1
+		case domain.EventUncollected:
+			cnt.CollectCnt = -1
+		default:
+			c.l.Warn("未知的互动事件", logger.String("type", evt.Type),
+				logger.String("eventId", evt.EventId))
+			continue
+		}
+		incrs = append(incrs, domain.StatsIncr{
+			Biz:      evt.Biz,
+			BizId:    evt.BizId,
+			Time:     time.UnixMilli(evt.Ctime),
+			StatsCnt: cnt,
+		})
+	}
+	if len(incrs) == 0 {
+		return nil
+	}
+	if err := c.repo.Incr(ctx, incrs); err != nil {
+		c.l.Error("记录点赞、收藏的分时计数失败", logger.Error(err))
+		c.release(ctx, evts)
+		return err
+	}
+	return nil
+}
+
+func (c *InteractiveStatsConsumer) release(ctx context.Context, evts []InteractiveEvent) {
+	ids := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		ids = append(ids, evt.EventId)
+	}
+	if err := c.dedup.Unmark(ctx, topicInteractiveEvent, ids); err != nil {
+		c.l.Error("去掉互动事件的去重标记失败", logger.Error(err))
+	}
+}
+
+func (c *InteractiveStatsConsumer) filter(ctx context.Context, evts []InteractiveEvent) []InteractiveEvent {
+	ids := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		ids = append(ids, evt.EventId)
+	}
+	if len(ids) == 0 {
+		return evts
+	}
+	firsts, err := c.dedup.MarkProcessed(ctx, topicInteractiveEvent, ids)
+	if err != nil {
+		c.l.Error("互动事件去重失败", logger.Error(err))
+		return evts
+	}
+	res := make([]InteractiveEvent, 0, len(evts))
+	for i, evt := range evts {
+		if firsts[i] {
+			res = append(res, evt)
+		}
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive_event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive_event.go -package=evtmocks -destination=mocks/interactive_event.mock.go InteractiveEventProducer
+//
+
+// Package evtmocks is a generated GoMock package.
+package evtmocks
+
+import (
+	context "context"
+	events "red-feed/interactive/events"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveEventProducer is a mock of InteractiveEventProducer interface.
+type MockInteractiveEventProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveEventProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractiveEventProducerMockRecorder is the mock recorder for MockInteractiveEventProducer.
+type MockInteractiveEventProducerMockRecorder struct {
+	mock *MockInteractiveEventProducer
+}
+
+// NewMockInteractiveEventProducer creates a new mock instance.
+func NewMockInteractiveEventProducer(ctrl *gomock.Controller) *MockInteractiveEventProducer {
+	mock := &MockInteractiveEventProducer{ctrl: ctrl}
+	mock.recorder = &MockInteractiveEventProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveEventProducer) EXPECT() *MockInteractiveEventProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceInteractiveEvents mocks base method.
+func (m *MockInteractiveEventProducer) ProduceInteractiveEvents(ctx context.Context, evts []events.InteractiveEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceInteractiveEvents", ctx, evts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceInteractiveEvents indicates an expected call of ProduceInteractiveEvents.
+func (mr *MockInteractiveEventProducerMockRecorder) ProduceInteractiveEvents(ctx, evts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceInteractiveEvents", reflect.TypeOf((*MockInteractiveEventProducer)(nil).ProduceInteractiveEvents), ctx, evts)
+}
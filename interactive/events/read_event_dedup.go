@@ -0,0 +1,94 @@
+package events
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"red-feed/interactive/repository/cache"
+	"red-feed/pkg/logger"
+	"sync"
+)
+
+var (
+	// duplicateReadEvents 重复投递被丢掉的阅读事件，两个阅读事件的消费者共用
+	duplicateReadEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "internal_test",
+		Subsystem: "red_feed",
+		Name:      "interactive_read_event_duplicated",
+		Help:      "重复投递被丢掉的阅读事件数量",
+	}, []string{"consumer"})
+	registerMetricsOnce sync.Once
+)
+
+func registerMetrics() {
+	registerMetricsOnce.Do(func() {
+		prometheus.MustRegister(duplicateReadEvents, invalidReadEvents)
+	})
+}
+
+// readEventDedup 阅读事件去重，rebalance 或者重试的时候同一个事件可能会被消费多次
+type readEventDedup struct {
+	cache    cache.EventDedupCache
+	l        logger.Logger
+	consumer string
+}
+
+func newReadEventDedup(cache cache.EventDedupCache, l logger.Logger, consumer string) readEventDedup {
+	registerMetrics()
+	return readEventDedup{
+		cache:    cache,
+		l:        l,
+		consumer: consumer,
+	}
+}
+
+// filter 返回第一次处理的事件。没有 EventId 的是老版本发出来的，没办法去重，照样计数。
+// 去重本身出错的时候宁可重复计数也不丢掉
+func (d readEventDedup) filter(ctx context.Context, evts []ReadEvent) []ReadEvent {
+	ids := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		if evt.EventId != "" {
+			ids = append(ids, evt.EventId)
+		}
+	}
+	if len(ids) == 0 {
+		return evts
+	}
+	firsts, err := d.cache.MarkProcessed(ctx, topicReadEvent, ids)
+	if err != nil {
+		d.l.Error("阅读事件去重失败", logger.Error(err))
+		return evts
+	}
+	res := make([]ReadEvent, 0, len(evts))
+	i := 0
+	for _, evt := range evts {
+		if evt.EventId == "" {
+			res = append(res, evt)
+			continue
+		}
+		if firsts[i] {
+			res = append(res, evt)
+		}
+		i++
+	}
+	if dropped := len(evts) - len(res); dropped > 0 {
+		duplicateReadEvents.WithLabelValues(d.consumer).Add(float64(dropped))
+	}
+	return res
+}
+
+// release 计数失败的事件去掉标记，重新投递的时候还能计数。
+// 去掉标记失败就只能少计了
+func (d readEventDedup) release(ctx context.Context, evts []ReadEvent) {
+	ids := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		if evt.EventId != "" {
+			ids = append(ids, evt.EventId)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := d.cache.Unmark(ctx, topicReadEvent, ids); err != nil {
+		d.l.Error("去掉阅读事件的去重标记失败", logger.Error(err))
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestReadEventDedup_Filter(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) cache.EventDedupCache
+		evts []ReadEvent
+
+		want []ReadEvent
+	}{
+		{
+			name: "丢掉重复的事件",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				c := cachemocks.NewMockEventDedupCache(ctrl)
+				c.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a", "b", "a"}).
+					Return([]bool{true, false, false}, nil)
+				return c
+			},
+			evts: []ReadEvent{{EventId: "a", Aid: 1}, {EventId: "b", Aid: 2}, {EventId: "a", Aid: 1}},
+			want: []ReadEvent{{EventId: "a", Aid: 1}},
+		},
+		{
+			name: "老版本的事件没有 EventId，照样计数",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				c := cachemocks.NewMockEventDedupCache(ctrl)
+				c.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a"}).
+					Return([]bool{false}, nil)
+				return c
+			},
+			evts: []ReadEvent{{Aid: 1}, {EventId: "a", Aid: 2}, {Aid: 3}},
+			want: []ReadEvent{{Aid: 1}, {Aid: 3}},
+		},
+		{
+			name: "全都没有 EventId，不用查缓存",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				return cachemocks.NewMockEventDedupCache(ctrl)
+			},
+			evts: []ReadEvent{{Aid: 1}},
+			want: []ReadEvent{{Aid: 1}},
+		},
+		{
+			name: "去重出错，宁可重复计数",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				c := cachemocks.NewMockEventDedupCache(ctrl)
+				c.EXPECT().MarkProcessed(gomock.Any(), topicReadEvent, []string{"a", "b"}).
+					Return(nil, errors.New("redis错误"))
+				return c
+			},
+			evts: []ReadEvent{{EventId: "a", Aid: 1}, {EventId: "b", Aid: 2}},
+			want: []ReadEvent{{EventId: "a", Aid: 1}, {EventId: "b", Aid: 2}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d := newReadEventDedup(tc.mock(ctrl), &logger.NopLogger{}, "test")
+			assert.Equal(t, tc.want, d.filter(context.Background(), tc.evts))
+		})
+	}
+}
+
+func TestReadEventDedup_Release(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) cache.EventDedupCache
+		evts []ReadEvent
+	}{
+		{
+			name: "去掉有 EventId 的标记",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				c := cachemocks.NewMockEventDedupCache(ctrl)
+				c.EXPECT().Unmark(gomock.Any(), topicReadEvent, []string{"a", "b"}).Return(nil)
+				return c
+			},
+			evts: []ReadEvent{{EventId: "a", Aid: 1}, {Aid: 2}, {EventId: "b", Aid: 3}},
+		},
+		{
+			name: "全都没有 EventId，不用动缓存",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				return cachemocks.NewMockEventDedupCache(ctrl)
+			},
+			evts: []ReadEvent{{Aid: 1}},
+		},
+		{
+			name: "去掉标记失败只记日志",
+			mock: func(ctrl *gomock.Controller) cache.EventDedupCache {
+				c := cachemocks.NewMockEventDedupCache(ctrl)
+				c.EXPECT().Unmark(gomock.Any(), topicReadEvent, []string{"a"}).Return(errors.New("redis错误"))
+				return c
+			},
+			evts: []ReadEvent{{EventId: "a", Aid: 1}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d := newReadEventDedup(tc.mock(ctrl), &logger.NopLogger{}, "test")
+			d.release(context.Background(), tc.evts)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	"red-feed/pkg/logger"
+	"strconv"
+	"time"
+)
+
+// invalidReadEvents 没有算进阅读数的阅读，按照原因区分
+var invalidReadEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "internal_test",
+	Subsystem: "red_feed",
+	Name:      "interactive_read_event_invalid",
+	Help:      "没有算进阅读数的阅读事件数量",
+}, []string{"reason"})
+
+type ReadGuardConfig struct {
+	// ViewWindow 同一个读者在窗口期内反复看同一个资源只算一次
+	ViewWindow time.Duration `yaml:"viewWindow"`
+	// IpWindow 内同一个 ip 超过 IpBurst 次之后的阅读都算疑似刷量
+	IpWindow time.Duration `yaml:"ipWindow"`
+	IpBurst  int64         `yaml:"ipBurst"`
+}
+
+// ReadGuard 防止刷阅读数：重复阅读和疑似机器的阅读都不算进阅读数，但是会单独记下来
+type ReadGuard struct {
+	cache cache.ReadLimitCache
+	cfg   ReadGuardConfig
+	l     logger.Logger
+}
+
+func NewReadGuard(cache cache.ReadLimitCache, cfg ReadGuardConfig, l logger.Logger) *ReadGuard {
+	registerMetrics()
+	return &ReadGuard{
+		cache: cache,
+		cfg:   cfg,
+		l:     l,
+	}
+}
+
+// GuardedReads Split 分出来的一组阅读，带着这组阅读在 Redis 里面留下的标记
+type GuardedReads struct {
+	Events  []ReadEvent
+	views   []domain.ReadView
+	results []domain.ReadViewResult
+}
+
+// Split 把阅读分成有效的和无效的。Redis 出错的时候只能靠 UserAgent 判断
+func (g *ReadGuard) Split(ctx context.Context, biz string, evts []ReadEvent) (valid GuardedReads, invalid GuardedReads) {
+	views := make([]domain.ReadView, 0, len(evts))
+	for _, evt := range evts {
+		views = append(views, domain.ReadView{
+			BizId:  evt.Aid,
+			Reader: g.reader(evt),
+			Ip:     evt.Ip,
+		})
+	}
+	results, err := g.cache.MarkViews(ctx, biz, views, g.cfg.ViewWindow, g.cfg.IpWindow)
+	if err != nil {
+		g.l.Error("记录阅读失败，不判断重复阅读", logger.Error(err))
+		results = nil
+	}
+	valid.Events = make([]ReadEvent, 0, len(evts))
+	for i, evt := range evts {
+		var res domain.ReadViewResult
+		if results != nil {
+			res = results[i]
+		} else {
+			res = domain.ReadViewResult{First: true}
+		}
+		reason := g.check(evt, res)
+		group := &valid
+		if reason != "" {
+			invalidReadEvents.WithLabelValues(reason).Inc()
+			group = &invalid
+		}
+		group.Events = append(group.Events, evt)
+		// 没有记下来的也就不用撤销
+		if results != nil {
+			group.views = append(group.views, views[i])
+			group.results = append(group.results, res)
+		}
+	}
+	return valid, invalid
+}
+
+// Release 计数失败的时候撤销这组阅读留下的标记，不然重新投递的时候会被当成重复阅读，
+// ip 的阅读次数也会越来越多。撤销失败就只能少计了
+func (g *ReadGuard) Release(ctx context.Context, biz string, reads GuardedReads) {
+	if len(reads.views) == 0 {
+		return
+	}
+	if err := g.cache.UnmarkViews(ctx, biz, reads.views, reads.results); err != nil {
+		g.l.Error("撤销阅读标记失败", logger.Error(err))
+	}
+}
+
+// check 返回不算阅读数的原因，空字符串就是有效的阅读
+func (g *ReadGuard) check(evt ReadEvent, res domain.ReadViewResult) string {
+	switch {
+	// 浏览器和 App 都会带上 UserAgent，没有的基本上是脚本
+	case evt.UserAgent == "":
+		return "no_ua"
+	case g.cfg.IpBurst > 0 && res.IpCnt > g.cfg.IpBurst:
+		return "ip_burst"
+	case !res.First:
+		return "repeat"
+	default:
+		return ""
+	}
+}
+
+// reader 登录用户按照 uid 算，匿名读者按照设备算
+func (g *ReadGuard) reader(evt ReadEvent) string {
+	if evt.Uid > 0 {
+		return "u" + strconv.FormatInt(evt.Uid, 10)
+	}
+	if evt.DeviceId != "" {
+		return "d" + evt.DeviceId
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestReadGuard_Split(t *testing.T) {
+	cfg := ReadGuardConfig{ViewWindow: time.Minute * 30, IpWindow: time.Minute, IpBurst: 2}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) cache.ReadLimitCache
+		evts []ReadEvent
+
+		wantValid   []ReadEvent
+		wantInvalid []ReadEvent
+	}{
+		{
+			name: "重复阅读和刷量都不算",
+			mock: func(ctrl *gomock.Controller) cache.ReadLimitCache {
+				c := cachemocks.NewMockReadLimitCache(ctrl)
+				c.EXPECT().MarkViews(gomock.Any(), "article", []domain.ReadView{
+					{BizId: 1, Reader: "u123", Ip: "1.1.1.1"},
+					{BizId: 1, Reader: "u123", Ip: "1.1.1.1"},
+					{BizId: 2, Reader: "ddevice", Ip: "2.2.2.2"},
+					{BizId: 3, Reader: "u456", Ip: "2.2.2.2"},
+				}, cfg.ViewWindow, cfg.IpWindow).Return([]domain.ReadViewResult{
+					{First: true, IpCnt: 1},
+					{First: false, IpCnt: 2},
+					{First: true, IpCnt: 2},
+					{First: true, IpCnt: 3},
+				}, nil)
+				return c
+			},
+			evts: []ReadEvent{
+				{Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"},
+				{Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"},
+				{Aid: 2, DeviceId: "device", Ip: "2.2.2.2", UserAgent: "Mozilla"},
+				{Uid: 456, Aid: 3, Ip: "2.2.2.2", UserAgent: "Mozilla"},
+			},
+			wantValid: []ReadEvent{
+				{Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"},
+				{Aid: 2, DeviceId: "device", Ip: "2.2.2.2", UserAgent: "Mozilla"},
+			},
+			wantInvalid: []ReadEvent{
+				{Uid: 123, Aid: 1, Ip: "1.1.1.1", UserAgent: "Mozilla"},
+				{Uid: 456, Aid: 3, Ip: "2.2.2.2", UserAgent: "Mozilla"},
+			},
+		},
+		{
+			name: "Redis 出错，只看 UserAgent",
+			mock: func(ctrl *gomock.Controller) cache.ReadLimitCache {
+				c := cachemocks.NewMockReadLimitCache(ctrl)
+				c.EXPECT().MarkViews(gomock.Any(), "article", gomock.Any(), cfg.ViewWindow, cfg.IpWindow).
+					Return(nil, errors.New("redis错误"))
+				return c
+			},
+			evts: []ReadEvent{
+				{Uid: 123, Aid: 1, UserAgent: "Mozilla"},
+				{Uid: 123, Aid: 1},
+			},
+			wantValid:   []ReadEvent{{Uid: 123, Aid: 1, UserAgent: "Mozilla"}},
+			wantInvalid: []ReadEvent{{Uid: 123, Aid: 1}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			g := NewReadGuard(tc.mock(ctrl), cfg, &logger.NopLogger{})
+			valid, invalid := g.Split(context.Background(), "article", tc.evts)
+			assert.Equal(t, tc.wantValid, valid.Events)
+			assert.Equal(t, tc.wantInvalid, invalid.Events)
+		})
+	}
+}
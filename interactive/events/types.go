@@ -2,4 +2,6 @@ package events
 
 type Consumer interface {
 	Start() error
+	// Stop 停止消费，退出之前调用
+	Stop() error
 }
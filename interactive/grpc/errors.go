@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/service"
+	"red-feed/pkg/logger"
+)
+
+const (
+	// maxBatchSize 一次最多查多少个资源，创作者看全部帖子的时候最多 1000 篇
+	maxBatchSize = 1000
+	maxPageSize  = 100
+)
+
+// ErrorDomain 放在 ErrorInfo 里面，客户端只还原这个 domain 下面的错误
+const ErrorDomain = "interactive.red-feed"
+
+// bizErr 业务错误对应的 gRPC 状态码和 ErrorInfo 的 reason，客户端按照这两个还原成原来的错误，不看错误信息
+type bizErr struct {
+	err    error
+	code   codes.Code
+	reason string
+}
+
+var bizErrs = []bizErr{
+	{err: domain.ErrUnknownBiz, code: codes.InvalidArgument, reason: "UNKNOWN_BIZ"},
+	{err: domain.ErrInvalidReaction, code: codes.InvalidArgument, reason: "INVALID_REACTION"},
+	{err: domain.ErrInvalidStatsRange, code: codes.InvalidArgument, reason: "INVALID_STATS_RANGE"},
+	{err: domain.ErrInvalidLikeCursor, code: codes.InvalidArgument, reason: "INVALID_LIKE_CURSOR"},
+	{err: domain.ErrBizNotFound, code: codes.NotFound, reason: "BIZ_NOT_FOUND"},
+	{err: service.ErrCollectionNotFound, code: codes.NotFound, reason: "COLLECTION_NOT_FOUND"},
+	{err: domain.ErrCounterDisabled, code: codes.FailedPrecondition, reason: "COUNTER_DISABLED"},
+	{err: service.ErrDefaultCollection, code: codes.FailedPrecondition, reason: "DEFAULT_COLLECTION"},
+}
+
+// toStatus 不认识的错误都是 Internal，原来的错误只记日志，不返回给调用方
+func (i *InteractiveServiceServer) toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	for _, known := range bizErrs {
+		if errors.Is(err, known.err) {
+			st, detailErr := status.New(known.code, known.err.Error()).WithDetails(&errdetails.ErrorInfo{
+				Reason: known.reason,
+				Domain: ErrorDomain,
+			})
+			if detailErr != nil {
+				return status.Error(known.code, known.err.Error())
+			}
+			return st.Err()
+		}
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	i.l.Error("interactive 服务内部错误", logger.Error(err))
+	return status.Error(codes.Internal, "系统错误")
+}
+
+// FromStatus 客户端用，认识的错误还原成原来的，调用方才能用 == 判断
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if err == nil || !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorDomain {
+			continue
+		}
+		for _, known := range bizErrs {
+			if known.code == st.Code() && known.reason == info.GetReason() {
+				return known.err
+			}
+		}
+	}
+	return err
+}
+
+func invalidArgument(msg string) error {
+	return status.Error(codes.InvalidArgument, msg)
+}
+
+// validateBiz 资源的 biz 和 id 都要有
+func validateBiz(biz string, bizId int64) error {
+	if biz == "" {
+		return invalidArgument("biz 不能为空")
+	}
+	if bizId <= 0 {
+		return invalidArgument("biz_id 不对")
+	}
+	return nil
+}
+
+func validateUid(uid int64) error {
+	if uid <= 0 {
+		return invalidArgument("uid 不对")
+	}
+	return nil
+}
+
+func validateBizIds(biz string, bizIds []int64) error {
+	if biz == "" {
+		return invalidArgument("biz 不能为空")
+	}
+	if len(bizIds) > maxBatchSize {
+		return invalidArgument("biz_ids 太多了")
+	}
+	return nil
+}
+
+func validatePage(limit int32) error {
+	if limit <= 0 || limit > maxPageSize {
+		return invalidArgument("limit 不对")
+	}
+	return nil
+}
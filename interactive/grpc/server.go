@@ -6,16 +6,19 @@ import (
 	intrv1 "red-feed/api/proto/gen/intr/v1"
 	"red-feed/interactive/domain"
 	"red-feed/interactive/service"
+	"red-feed/pkg/logger"
+	"time"
 )
 
 // InteractiveServiceServer 把service包装成一个grpc server
 type InteractiveServiceServer struct {
 	intrv1.UnimplementedInteractiveServiceServer
 	svc service.InteractiveService
+	l   logger.Logger
 }
 
-func NewInteractiveServiceServer(svc service.InteractiveService) *InteractiveServiceServer {
-	return &InteractiveServiceServer{svc: svc}
+func NewInteractiveServiceServer(svc service.InteractiveService, l logger.Logger) *InteractiveServiceServer {
+	return &InteractiveServiceServer{svc: svc, l: l}
 }
 
 func (i *InteractiveServiceServer) Register(server *grpc.Server) {
@@ -23,34 +26,79 @@ func (i *InteractiveServiceServer) Register(server *grpc.Server) {
 }
 
 func (i *InteractiveServiceServer) IncrReadCnt(ctx context.Context, request *intrv1.IncrReadCntRequest) (*intrv1.IncrReadCntResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
 	err := i.svc.IncrReadCnt(ctx, request.GetBiz(), request.GetBizId())
-	return &intrv1.IncrReadCntResponse{}, err
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.IncrReadCntResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) Like(ctx context.Context, request *intrv1.LikeRequest) (*intrv1.LikeResponse, error) {
-	err := i.svc.Like(ctx, request.GetBiz(), request.GetBizId(), request.GetBizId())
-	return &intrv1.LikeResponse{}, err
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.Like(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.LikeResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) CancelLike(ctx context.Context, request *intrv1.CancelLikeRequest) (*intrv1.CancelLikeResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
 	err := i.svc.CancelLike(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
-	return &intrv1.CancelLikeResponse{}, err
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.CancelLikeResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) Collect(ctx context.Context, request *intrv1.CollectRequest) (*intrv1.CollectResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
 	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
-	return &intrv1.CollectResponse{}, err
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.CollectResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) CancelCollect(ctx context.Context, request *intrv1.CancelCollectRequest) (*intrv1.CancelCollectResponse, error) {
-	err := i.svc.Collect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
-	return &intrv1.CancelCollectResponse{}, err
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.CancelCollect(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetCid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.CancelCollectResponse{}, nil
 }
 
 func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetRequest) (*intrv1.GetResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
 	res, err := i.svc.Get(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
 	if err != nil {
-		return nil, err
+		return nil, i.toStatus(err)
 	}
 	return &intrv1.GetResponse{
 		Intr: i.toDTO(res),
@@ -58,9 +106,12 @@ func (i *InteractiveServiceServer) Get(ctx context.Context, request *intrv1.GetR
 }
 
 func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1.GetByIdsRequest) (*intrv1.GetByIdsResponse, error) {
+	if err := validateBizIds(request.GetBiz(), request.GetBizIds()); err != nil {
+		return nil, err
+	}
 	res, err := i.svc.GetByIds(ctx, request.GetBiz(), request.GetBizIds())
 	if err != nil {
-		return nil, err
+		return nil, i.toStatus(err)
 	}
 	m := make(map[int64]*intrv1.Interactive, len(res))
 	for k, v := range res {
@@ -71,15 +122,203 @@ func (i *InteractiveServiceServer) GetByIds(ctx context.Context, request *intrv1
 	}, nil
 }
 
+func (i *InteractiveServiceServer) GetUserStateByIds(ctx context.Context, request *intrv1.GetUserStateByIdsRequest) (*intrv1.GetUserStateByIdsResponse, error) {
+	if err := validateBizIds(request.GetBiz(), request.GetBizIds()); err != nil {
+		return nil, err
+	}
+	res, err := i.svc.GetUserStateByIds(ctx, request.GetBiz(), request.GetUid(), request.GetBizIds())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	m := make(map[int64]*intrv1.UserState, len(res))
+	for k, v := range res {
+		m[k] = &intrv1.UserState{
+			BizId:     v.BizId,
+			Liked:     v.Liked,
+			Collected: v.Collected,
+		}
+	}
+	return &intrv1.GetUserStateByIdsResponse{
+		States: m,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListLikers(ctx context.Context, request *intrv1.ListLikersRequest) (*intrv1.ListLikersResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validatePage(request.GetLimit()); err != nil {
+		return nil, err
+	}
+	cursor, err := domain.ParseLikeCursor(request.GetCursor())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	limit := int(request.GetLimit())
+	res, err := i.svc.ListLikers(ctx, request.GetBiz(), request.GetBizId(), cursor, limit)
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	records, next := i.toLikeRecords(res, limit)
+	return &intrv1.ListLikersResponse{
+		Records:    records,
+		NextCursor: next,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) ListLikedByUser(ctx context.Context, request *intrv1.ListLikedByUserRequest) (*intrv1.ListLikedByUserResponse, error) {
+	if request.GetBiz() == "" {
+		return nil, invalidArgument("biz 不能为空")
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
+	if err := validatePage(request.GetLimit()); err != nil {
+		return nil, err
+	}
+	cursor, err := domain.ParseLikeCursor(request.GetCursor())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	limit := int(request.GetLimit())
+	res, err := i.svc.ListLikedByUser(ctx, request.GetBiz(), request.GetUid(), cursor, limit)
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	records, next := i.toLikeRecords(res, limit)
+	return &intrv1.ListLikedByUserResponse{
+		Records:    records,
+		NextCursor: next,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) GetStats(ctx context.Context, request *intrv1.GetStatsRequest) (*intrv1.GetStatsResponse, error) {
+	if err := validateBizIds(request.GetBiz(), request.GetBizIds()); err != nil {
+		return nil, err
+	}
+	res, err := i.svc.GetStats(ctx, request.GetBiz(), request.GetBizIds(),
+		time.UnixMilli(request.GetStart()), time.UnixMilli(request.GetEnd()),
+		domain.StatsGranularity(request.GetGranularity()))
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	points := make([]*intrv1.StatsPoint, 0, len(res))
+	for _, p := range res {
+		points = append(points, &intrv1.StatsPoint{
+			Time: p.Time.UnixMilli(),
+			Cnt:  i.toStatsCnt(p.StatsCnt),
+		})
+	}
+	return &intrv1.GetStatsResponse{
+		Points: points,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) GetStatsByIds(ctx context.Context, request *intrv1.GetStatsByIdsRequest) (*intrv1.GetStatsByIdsResponse, error) {
+	if err := validateBizIds(request.GetBiz(), request.GetBizIds()); err != nil {
+		return nil, err
+	}
+	res, err := i.svc.GetStatsByIds(ctx, request.GetBiz(), request.GetBizIds(),
+		time.UnixMilli(request.GetStart()), time.UnixMilli(request.GetEnd()))
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	m := make(map[int64]*intrv1.StatsCnt, len(res))
+	for k, v := range res {
+		m[k] = i.toStatsCnt(v)
+	}
+	return &intrv1.GetStatsByIdsResponse{
+		Cnts: m,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) React(ctx context.Context, request *intrv1.ReactRequest) (*intrv1.ReactResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
+	if request.GetReaction() == "" {
+		return nil, invalidArgument("reaction 不能为空")
+	}
+	err := i.svc.React(ctx, request.GetBiz(), request.GetBizId(), request.GetUid(), request.GetReaction())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.ReactResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) CancelReaction(ctx context.Context, request *intrv1.CancelReactionRequest) (*intrv1.CancelReactionResponse, error) {
+	if err := validateBiz(request.GetBiz(), request.GetBizId()); err != nil {
+		return nil, err
+	}
+	if err := validateUid(request.GetUid()); err != nil {
+		return nil, err
+	}
+	err := i.svc.CancelReaction(ctx, request.GetBiz(), request.GetBizId(), request.GetUid())
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	return &intrv1.CancelReactionResponse{}, nil
+}
+
+func (i *InteractiveServiceServer) ListReactions(ctx context.Context, request *intrv1.ListReactionsRequest) (*intrv1.ListReactionsResponse, error) {
+	res, err := i.svc.ListReactions(ctx)
+	if err != nil {
+		return nil, i.toStatus(err)
+	}
+	reactions := make([]*intrv1.Reaction, 0, len(res))
+	for _, r := range res {
+		reactions = append(reactions, &intrv1.Reaction{
+			Key:   r.Key,
+			Emoji: r.Emoji,
+		})
+	}
+	return &intrv1.ListReactionsResponse{
+		Reactions: reactions,
+	}, nil
+}
+
+func (i *InteractiveServiceServer) toStatsCnt(cnt domain.StatsCnt) *intrv1.StatsCnt {
+	return &intrv1.StatsCnt{
+		ReadCnt:    cnt.ReadCnt,
+		LikeCnt:    cnt.LikeCnt,
+		CollectCnt: cnt.CollectCnt,
+	}
+}
+
+// toLikeRecords 不满一页说明没有下一页了
+func (i *InteractiveServiceServer) toLikeRecords(res []domain.LikeRecord, limit int) ([]*intrv1.LikeRecord, string) {
+	records := make([]*intrv1.LikeRecord, 0, len(res))
+	for _, r := range res {
+		records = append(records, &intrv1.LikeRecord{
+			Id:    r.Id,
+			Uid:   r.Uid,
+			Biz:   r.Biz,
+			BizId: r.BizId,
+			Utime: r.Utime.UnixMilli(),
+		})
+	}
+	var next string
+	if len(res) > 0 && len(res) >= limit {
+		next = res[len(res)-1].Cursor().String()
+	}
+	return records, next
+}
+
 // DTO data transfer object
 func (i *InteractiveServiceServer) toDTO(intr domain.Interactive) *intrv1.Interactive {
 	return &intrv1.Interactive{
-		Biz:        intr.Biz,
-		BizId:      intr.BizId,
-		CollectCnt: intr.CollectCnt,
-		Collected:  intr.Collected,
-		LikeCnt:    intr.LikeCnt,
-		Liked:      intr.Liked,
-		ReadCnt:    intr.ReadCnt,
+		Biz:          intr.Biz,
+		BizId:        intr.BizId,
+		CollectCnt:   intr.CollectCnt,
+		Collected:    intr.Collected,
+		LikeCnt:      intr.LikeCnt,
+		Liked:        intr.Liked,
+		ReadCnt:      intr.ReadCnt,
+		UvCnt:        intr.UvCnt,
+		ReactionCnts: intr.ReactionCnts,
+		Reaction:     intr.Reaction,
 	}
 }
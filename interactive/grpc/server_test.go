@@ -0,0 +1,159 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "red-feed/api/proto/gen/intr/v1"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/service"
+	svcmocks "red-feed/internal/service/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestInteractiveServiceServer_Like(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.InteractiveService
+		req      *intrv1.LikeRequest
+		wantCode codes.Code
+	}{
+		{
+			name: "点赞的是用户，不是资源",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(nil)
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 2},
+			wantCode: codes.OK,
+		},
+		{
+			name: "没有 biz",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				return svcmocks.NewMockInteractiveService(ctrl)
+			},
+			req:      &intrv1.LikeRequest{BizId: 1, Uid: 2},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "没有 uid",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				return svcmocks.NewMockInteractiveService(ctrl)
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "未知的业务",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "artcle", int64(1), int64(2)).Return(domain.ErrUnknownBiz)
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "artcle", BizId: 1, Uid: 2},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "资源不存在",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(domain.ErrBizNotFound)
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 2},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "计数没有打开",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(domain.ErrCounterDisabled)
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 2},
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "系统错误",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(errors.New("db error"))
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 2},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "超时",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(context.DeadlineExceeded)
+				return svc
+			},
+			req:      &intrv1.LikeRequest{Biz: "article", BizId: 1, Uid: 2},
+			wantCode: codes.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := NewInteractiveServiceServer(tc.mock(ctrl), &logger.NopLogger{})
+			_, err := server.Like(context.Background(), tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			if tc.wantCode == codes.Internal {
+				// 原来的错误不返回给调用方
+				assert.Equal(t, "系统错误", status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestInteractiveServiceServer_CancelCollect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := svcmocks.NewMockInteractiveService(ctrl)
+	// 取消收藏不能变成收藏
+	svc.EXPECT().CancelCollect(gomock.Any(), "article", int64(1), int64(2), int64(3)).
+		Return(service.ErrCollectionNotFound)
+	server := NewInteractiveServiceServer(svc, &logger.NopLogger{})
+	_, err := server.CancelCollect(context.Background(),
+		&intrv1.CancelCollectRequest{Biz: "article", BizId: 1, Uid: 2, Cid: 3})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	// 信息原样返回，客户端才能还原成原来的错误
+	assert.Equal(t, service.ErrCollectionNotFound.Error(), st.Message())
+}
+
+func TestInteractiveServiceServer_ListLikers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      *intrv1.ListLikersRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "limit 太大",
+			req:      &intrv1.ListLikersRequest{Biz: "article", BizId: 1, Limit: 1000},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "游标不对",
+			req:      &intrv1.ListLikersRequest{Biz: "article", BizId: 1, Limit: 10, Cursor: "abc"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			server := NewInteractiveServiceServer(svcmocks.NewMockInteractiveService(ctrl), &logger.NopLogger{})
+			_, err := server.ListLikers(context.Background(), tc.req)
+			assert.Equal(t, tc.wantCode, status.Code(err))
+		})
+	}
+}
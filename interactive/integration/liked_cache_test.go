@@ -0,0 +1,129 @@
+package integration
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/integration/startup"
+	"red-feed/interactive/repository/cache"
+	"testing"
+	"time"
+)
+
+// LikedCacheTestSuite 用户最近点赞的 ZSET，主要测 interactive_add_liked.lua
+type LikedCacheTestSuite struct {
+	suite.Suite
+	rdb   redis.Cmdable
+	cache cache.InteractiveCache
+}
+
+func (s *LikedCacheTestSuite) SetupSuite() {
+	s.rdb = startup.InitRedis()
+	s.cache = cache.NewRedisInteractiveCache(s.rdb, startup.InitBizRegistry())
+}
+
+func (s *LikedCacheTestSuite) TearDownTest() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	err := s.rdb.FlushDB(ctx).Err()
+	assert.NoError(s.T(), err)
+}
+
+func (s *LikedCacheTestSuite) TestAddLikedIfPresent() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	key := "interactive:liked:article:2"
+	now := time.UnixMilli(time.Now().UnixMilli())
+
+	// 没有加载过，不会凭空创建一个不完整的缓存
+	err := s.cache.AddLikedIfPresent(ctx, "article", 2, domain.LikedItem{BizId: 1, Utime: now})
+	require.NoError(t, err)
+	exists, err := s.rdb.Exists(ctx, key).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+
+	// 加载了 RecentLikedLimit-1 个，再加一个刚好到上限，还是完整的
+	items := make([]domain.LikedItem, 0, cache.RecentLikedLimit)
+	for i := int64(1); i < cache.RecentLikedLimit; i++ {
+		items = append(items, domain.LikedItem{BizId: i, Utime: now.Add(time.Duration(i) * time.Millisecond)})
+	}
+	err = s.cache.SetLiked(ctx, "article", 2, items, true)
+	require.NoError(t, err)
+	err = s.cache.AddLikedIfPresent(ctx, "article", 2, domain.LikedItem{
+		BizId: cache.RecentLikedLimit, Utime: now.Add(time.Hour)})
+	require.NoError(t, err)
+	liked, complete, err := s.cache.GetLiked(ctx, "article", 2, []int64{1, cache.RecentLikedLimit, 5000})
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, []int64{1, cache.RecentLikedLimit}, liked)
+
+	// 超过上限，哨兵和最早的点赞都被挤出去，缓存变成不完整的
+	err = s.cache.AddLikedIfPresent(ctx, "article", 2, domain.LikedItem{
+		BizId: 5000, Utime: now.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	liked, complete, err = s.cache.GetLiked(ctx, "article", 2, []int64{1, 2, cache.RecentLikedLimit, 5000})
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, []int64{2, cache.RecentLikedLimit, 5000}, liked)
+	cnt, err := s.rdb.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(cache.RecentLikedLimit), cnt)
+
+	// 已经不完整了，再加一个还是只留最近的 RecentLikedLimit 个
+	err = s.cache.AddLikedIfPresent(ctx, "article", 2, domain.LikedItem{
+		BizId: 5001, Utime: now.Add(3 * time.Hour)})
+	require.NoError(t, err)
+	liked, complete, err = s.cache.GetLiked(ctx, "article", 2, []int64{2, 3, 5001})
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, []int64{3, 5001}, liked)
+	cnt, err = s.rdb.ZCard(ctx, key).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(cache.RecentLikedLimit), cnt)
+}
+
+func (s *LikedCacheTestSuite) TestSetLiked() {
+	t := s.T()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	key := "interactive:liked:article:2"
+
+	_, _, err := s.cache.GetLiked(ctx, "article", 2, []int64{1})
+	assert.Equal(t, cache.ErrKeyNotExist, err)
+
+	// 用户一个点赞都没有，也要缓存一个完整的空集合
+	err = s.cache.SetLiked(ctx, "article", 2, nil, true)
+	require.NoError(t, err)
+	liked, complete, err := s.cache.GetLiked(ctx, "article", 2, []int64{1})
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, []int64{}, liked)
+	ttl, err := s.rdb.TTL(ctx, key).Result()
+	require.NoError(t, err)
+	assert.True(t, ttl > 0)
+
+	// 重新加载覆盖掉原来的
+	err = s.cache.SetLiked(ctx, "article", 2, []domain.LikedItem{
+		{BizId: 3, Utime: time.UnixMilli(2000)},
+		{BizId: 4, Utime: time.UnixMilli(1000)},
+	}, false)
+	require.NoError(t, err)
+	liked, complete, err = s.cache.GetLiked(ctx, "article", 2, []int64{1, 3})
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, []int64{3}, liked)
+
+	err = s.cache.RemoveLiked(ctx, "article", 2, 3)
+	require.NoError(t, err)
+	liked, _, err = s.cache.GetLiked(ctx, "article", 2, []int64{3})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{}, liked)
+}
+
+func TestLikedCache(t *testing.T) {
+	suite.Run(t, &LikedCacheTestSuite{})
+}
@@ -0,0 +1,7 @@
+package startup
+
+import "red-feed/interactive/domain"
+
+func InitBizRegistry() *domain.BizRegistry {
+	return domain.NewBizRegistry(domain.BizConfig{Biz: domain.BizArticle})
+}
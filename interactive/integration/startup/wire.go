@@ -4,6 +4,7 @@ package startup
 
 import (
 	"github.com/google/wire"
+	"red-feed/interactive/domain"
 	"red-feed/interactive/grpc"
 	"red-feed/interactive/repository"
 	"red-feed/interactive/repository/cache"
@@ -12,18 +13,23 @@ import (
 )
 
 var thirdProvider = wire.NewSet(InitRedis,
-	InitTestDB, InitTestLogger)
+	InitTestDB, InitTestLogger, InitBizRegistry)
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
 	repository.NewInteractiveRepository,
+	repository.NewCollectionRepository,
+	repository.NewStatsRepository,
 	dao.NewInteractiveDAO,
+	dao.NewStatsDAO,
+	dao.NewCollectionDAO,
 	cache.NewRedisInteractiveCache,
+	wire.Value(domain.DefaultReactions),
 )
 
 func InitInteractiveService() service.InteractiveService {
 	wire.Build(thirdProvider, interactiveSvcProvider)
-	return service.NewInteractiveService(nil, nil)
+	return service.NewInteractiveService(nil, nil, nil, nil, nil, nil)
 }
 
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
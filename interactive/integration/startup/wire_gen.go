@@ -8,6 +8,7 @@ package startup
 
 import (
 	"github.com/google/wire"
+	"red-feed/interactive/domain"
 	"red-feed/interactive/grpc"
 	"red-feed/interactive/repository"
 	"red-feed/interactive/repository/cache"
@@ -21,28 +22,44 @@ func InitInteractiveService() service.InteractiveService {
 	gormDB := InitTestDB()
 	interactiveDAO := dao.NewInteractiveDAO(gormDB)
 	cmdable := InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	bizRegistry := InitBizRegistry()
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, bizRegistry)
 	logger := InitTestLogger()
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
+	collectionDAO := dao.NewCollectionDAO(gormDB)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	statsDAO := dao.NewStatsDAO(gormDB)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	reactions := _wireReactionsValue
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, statsRepository, reactions, bizRegistry, logger)
 	return interactiveService
 }
 
+var (
+	_wireReactionsValue = domain.DefaultReactions
+)
+
 func InitInteractiveGRPCServer() *grpc.InteractiveServiceServer {
 	gormDB := InitTestDB()
 	interactiveDAO := dao.NewInteractiveDAO(gormDB)
 	cmdable := InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	bizRegistry := InitBizRegistry()
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, bizRegistry)
 	logger := InitTestLogger()
 	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
-	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
+	collectionDAO := dao.NewCollectionDAO(gormDB)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	statsDAO := dao.NewStatsDAO(gormDB)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	reactions := _wireReactionsValue
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, statsRepository, reactions, bizRegistry, logger)
+	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService, logger)
 	return interactiveServiceServer
 }
 
 // wire.go:
 
 var thirdProvider = wire.NewSet(InitRedis,
-	InitTestDB, InitTestLogger)
+	InitTestDB, InitTestLogger, InitBizRegistry)
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewInteractiveRepository, dao.NewInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewInteractiveRepository, repository.NewCollectionRepository, repository.NewStatsRepository, dao.NewInteractiveDAO, dao.NewStatsDAO, dao.NewCollectionDAO, cache.NewRedisInteractiveCache, wire.Value(domain.DefaultReactions))
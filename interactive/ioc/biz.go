@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"red-feed/interactive/domain"
+)
+
+// InitBizRegistry 已知的业务，没有配置就只有 article。
+// 这个服务查不到别的服务的资源，不检查资源存不存在，由调用方在转发过来之前检查
+func InitBizRegistry() *domain.BizRegistry {
+	if !viper.IsSet("bizs") {
+		return domain.NewBizRegistry(domain.BizConfig{Biz: domain.BizArticle})
+	}
+	var cfgs []domain.BizConfig
+	err := viper.UnmarshalKey("bizs", &cfgs)
+	if err != nil {
+		panic(err)
+	}
+	return domain.NewBizRegistry(cfgs...)
+}
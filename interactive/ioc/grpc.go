@@ -5,22 +5,74 @@ import (
 	"google.golang.org/grpc"
 	igrpc "red-feed/interactive/grpc"
 	"red-feed/pkg/grpcx"
+	"red-feed/pkg/grpcx/interceptors/logging"
+	"red-feed/pkg/grpcx/interceptors/metric"
+	"red-feed/pkg/grpcx/interceptors/recovery"
+	"red-feed/pkg/grpcx/interceptors/timeout"
+	"red-feed/pkg/grpcx/interceptors/trace"
+	"red-feed/pkg/grpcx/registry"
+	"red-feed/pkg/logger"
+	"time"
 )
 
-func InitGRPCXServer(intrGRPCServer *igrpc.InteractiveServiceServer) *grpcx.Server {
+func InitGRPCXServer(intrGRPCServer *igrpc.InteractiveServiceServer, reg registry.Registry, l logger.Logger) *grpcx.Server {
+	type MethodTimeout struct {
+		// Method 方法名，不带服务名，例如 GetStats
+		Method  string        `yaml:"method"`
+		Timeout time.Duration `yaml:"timeout"`
+	}
 	type Config struct {
 		Addr string `yaml:"addr"`
+		// Timeout 每个请求默认的超时
+		Timeout        time.Duration   `yaml:"timeout"`
+		MethodTimeouts []MethodTimeout `yaml:"methodTimeouts"`
+		// DrainTimeout 优雅退出最多等多久
+		DrainTimeout time.Duration `yaml:"drainTimeout"`
+		// DeregisterDelay 注销之后等多久再停止接收请求
+		DeregisterDelay time.Duration `yaml:"deregisterDelay"`
+		// AdvertiseAddr 注册给客户端连的地址，Addr 一般是 ":8090"，别的机器连不上
+		AdvertiseAddr string `yaml:"advertiseAddr"`
+		// Weight 这个实例的权重，机器配置不一样的时候调整
+		Weight int32 `yaml:"weight"`
+	}
+	cfg := Config{
+		Addr:            ":8090",
+		Timeout:         time.Second,
+		DrainTimeout:    grpcx.DefaultDrainTimeout,
+		DeregisterDelay: grpcx.DefaultDeregisterDelay,
+		Weight:          registry.DefaultWeight,
 	}
-	var cfg Config
 	err := viper.UnmarshalKey("grpc.server", &cfg)
 	if err != nil {
 		panic(err)
 	}
 
-	server := grpc.NewServer()
+	timeouts := timeout.NewInterceptorBuilder(cfg.Timeout)
+	for _, mt := range cfg.MethodTimeouts {
+		timeouts.Method(mt.Method, mt.Timeout)
+	}
+	// 从外到里：trace 包住整个请求，recovery 在里面把 panic 转成错误，日志和监控才能看到
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		trace.NewInterceptorBuilder().BuildServerUnaryInterceptor(),
+		(&metric.InterceptorBuilder{
+			Namespace:  "internal_test",
+			Subsystem:  "red_feed",
+			Name:       "interactive_grpc",
+			Help:       "interactive gRPC 服务的请求",
+			InstanceID: "interactive",
+		}).BuildServerUnaryInterceptor(),
+		logging.NewInterceptorBuilder(l).BuildServerUnaryInterceptor(),
+		recovery.NewInterceptorBuilder(l).BuildServerUnaryInterceptor(),
+		timeouts.BuildServerUnaryInterceptor(),
+	))
 	intrGRPCServer.Register(server)
-	return &grpcx.Server{
-		Server: server,
-		Addr:   cfg.Addr,
+	res := grpcx.NewServer(server, cfg.Addr, cfg.DrainTimeout)
+	res.DeregisterDelay = cfg.DeregisterDelay
+	res.Registry = reg
+	res.Instance = registry.ServiceInstance{
+		Name:   "interactive",
+		Addr:   cfg.AdvertiseAddr,
+		Weight: cfg.Weight,
 	}
+	return res
 }
@@ -0,0 +1,79 @@
+package ioc
+
+import (
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/viper"
+	"red-feed/interactive/events"
+	"red-feed/interactive/job"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// InitCntReconcileJob reconcile.repair 打开之后才会修复，不然只上报
+func InitCntReconcileJob(repo repository.CntReconcileRepository, l logger.Logger) *job.CntReconcileJob {
+	type Config struct {
+		Repair    bool `yaml:"repair"`
+		BatchSize int  `yaml:"batchSize"`
+	}
+	cfg := Config{
+		BatchSize: 500,
+	}
+	err := viper.UnmarshalKey("reconcile", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return job.NewCntReconcileJob(repo, l, cfg.BatchSize, cfg.Repair, time.Hour)
+}
+
+func InitOutboxRelayJob(repo repository.InteractiveEventRepository,
+	producer events.InteractiveEventProducer) *job.OutboxRelayJob {
+	return job.NewOutboxRelayJob(repo, producer, 100, time.Second*10)
+}
+
+func InitUvPersistJob(repo repository.UvRepository, l logger.Logger) *job.UvPersistJob {
+	return job.NewUvPersistJob(repo, l, 500, time.Second*30)
+}
+
+func InitJobs(l logger.Logger, flushJob *job.CntFlushJob, cleanJob *job.FlushCleanJob, reconcileJob *job.CntReconcileJob,
+	relayJob *job.OutboxRelayJob, uvJob *job.UvPersistJob) *cron.Cron {
+	res := cron.New(cron.WithSeconds())
+	// 每秒把 outbox 里面的点赞、收藏事件发出去
+	_, err := res.AddFunc("* * * * * ?", runJob(l, relayJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每五秒写回一次，数据库里面的计数最多落后这么久
+	_, err = res.AddFunc("*/5 * * * * ?", runJob(l, flushJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每天凌晨三点半清理过期的写回去重记录
+	_, err = res.AddFunc("0 30 3 * * ?", runJob(l, cleanJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每分钟把 UV 写进数据库
+	_, err = res.AddFunc("0 * * * * ?", runJob(l, uvJob))
+	if err != nil {
+		panic(err)
+	}
+	// 每天凌晨四点核对一次计数
+	_, err = res.AddFunc("0 0 4 * * ?", runJob(l, reconcileJob))
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+func runJob(l logger.Logger, j interface {
+	Name() string
+	Run() error
+}) func() {
+	return func() {
+		if err := j.Run(); err != nil {
+			l.Error("运行任务失败", logger.Error(err),
+				logger.String("job", j.Name()))
+		}
+	}
+}
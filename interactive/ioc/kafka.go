@@ -24,7 +24,16 @@ func InitKafka() sarama.Client {
 	return client
 }
 
+func InitSyncProducer(client sarama.Client) sarama.SyncProducer {
+	res, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
 // NewConsumers 面临的问题依旧是所有的 Consumer 在这里注册一下
-func NewConsumers(c1 events.Consumer) []events.Consumer {
-	return []events.Consumer{c1}
+func NewConsumers(c1 events.Consumer, deleteConsumer *events.InteractiveDeleteEventConsumer,
+	statsConsumer *events.InteractiveStatsConsumer) []events.Consumer {
+	return []events.Consumer{c1, deleteConsumer, statsConsumer}
 }
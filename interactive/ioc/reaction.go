@@ -0,0 +1,24 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"red-feed/interactive/domain"
+)
+
+// InitReactions 可以用的表情，没有配置就用默认的。点赞一定要有，原来的点赞数据都算在它上面。
+// 单体应用也用这个，读的是同一个 key
+func InitReactions() domain.Reactions {
+	if !viper.IsSet("reactions") {
+		return domain.DefaultReactions
+	}
+	var reactions domain.Reactions
+	err := viper.UnmarshalKey("reactions", &reactions)
+	if err != nil {
+		panic(err)
+	}
+	if !reactions.Contains(domain.ReactionLike) {
+		panic(fmt.Errorf("表情里面没有 %s", domain.ReactionLike))
+	}
+	return reactions
+}
@@ -0,0 +1,23 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"red-feed/interactive/events"
+	"red-feed/interactive/repository/cache"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// InitReadGuard 阅读计数防刷，主进程在本地消费阅读事件的时候也用这一份，配置都是 read
+func InitReadGuard(c cache.ReadLimitCache, l logger.Logger) *events.ReadGuard {
+	cfg := events.ReadGuardConfig{
+		ViewWindow: time.Minute * 30,
+		IpWindow:   time.Minute,
+		IpBurst:    120,
+	}
+	err := viper.UnmarshalKey("read", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return events.NewReadGuard(c, cfg, l)
+}
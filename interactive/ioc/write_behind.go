@@ -0,0 +1,52 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"red-feed/interactive/job"
+	"red-feed/interactive/repository"
+	"red-feed/interactive/repository/cache"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+type writeBehindConfig struct {
+	// Enabled 打开之后点赞数、收藏数先攒在 Redis 里面，定时写回数据库
+	Enabled bool `yaml:"enabled"`
+	// BatchSize 写回的时候一次领取多少个资源
+	BatchSize int `yaml:"batchSize"`
+	// FlushTTL 写回的去重记录保留多久
+	FlushTTL time.Duration `yaml:"flushTTL"`
+}
+
+// loadWriteBehindConfig 主进程和 interactive 服务共用，配置都是 writeBehind
+func loadWriteBehindConfig() writeBehindConfig {
+	cfg := writeBehindConfig{
+		BatchSize: 100,
+		FlushTTL:  time.Hour * 24 * 7,
+	}
+	err := viper.UnmarshalKey("writeBehind", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// InitInteractiveRepository 按照配置决定计数是直接写数据库，还是先写 Redis 再写回
+func InitInteractiveRepository(d dao.InteractiveDAO, c cache.InteractiveCache,
+	wb *repository.WriteBehindInteractiveRepository, l logger.Logger) repository.InteractiveRepository {
+	if loadWriteBehindConfig().Enabled {
+		return wb
+	}
+	return repository.NewInteractiveRepository(d, c, l)
+}
+
+// InitCntFlushJob 写回模式关掉了也要跑，把之前攒下来的写完
+func InitCntFlushJob(wb *repository.WriteBehindInteractiveRepository, l logger.Logger) *job.CntFlushJob {
+	return job.NewCntFlushJob(wb, l, loadWriteBehindConfig().BatchSize, time.Second*30)
+}
+
+// InitFlushCleanJob 每天清理一次过期的写回去重记录
+func InitFlushCleanJob(wb *repository.WriteBehindInteractiveRepository, l logger.Logger) *job.FlushCleanJob {
+	return job.NewFlushCleanJob(wb, l, loadWriteBehindConfig().FlushTTL, 1000, time.Minute)
+}
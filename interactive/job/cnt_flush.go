@@ -0,0 +1,88 @@
+package job
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+var (
+	flushedCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "internal_test",
+		Subsystem: "red_feed",
+		Name:      "interactive_cnt_flushed",
+		Help:      "写回模式下计数写回数据库的资源数，result 是 applied、duplicated、failed",
+	}, []string{"result"})
+	// pendingCnt 和数据库对账用，一直涨说明写回跟不上，或者一直在失败
+	pendingCnt = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "internal_test",
+		Subsystem: "red_feed",
+		Name:      "interactive_cnt_pending",
+		Help:      "写回模式下还没有写回数据库的资源数",
+	})
+)
+
+// CntFlushJob 把写回模式下攒在 Redis 里面的点赞数、收藏数写回数据库。
+// 关掉写回模式之后也要继续跑，把剩下的写完。
+// 领取是原子的，同一次写回在数据库按照 flush id 去重，多个实例同时跑也没关系
+type CntFlushJob struct {
+	repo      repository.CntFlushRepository
+	l         logger.Logger
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewCntFlushJob(repo repository.CntFlushRepository, l logger.Logger,
+	batchSize int, timeout time.Duration) *CntFlushJob {
+	prometheus.MustRegister(flushedCnt, pendingCnt)
+	return &CntFlushJob{
+		repo:      repo,
+		l:         l,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (j *CntFlushJob) Name() string {
+	return "interactive_cnt_flush"
+}
+
+func (j *CntFlushJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Flush(ctx)
+}
+
+// Flush 一批一批地写回，直到领取不满一批，或者 ctx 超时
+func (j *CntFlushJob) Flush(ctx context.Context) error {
+	defer j.reportPending()
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		res, err := j.repo.FlushCnt(ctx, j.batchSize)
+		if err != nil {
+			return err
+		}
+		flushedCnt.WithLabelValues("applied").Add(float64(res.Applied))
+		flushedCnt.WithLabelValues("duplicated").Add(float64(res.Duplicated))
+		flushedCnt.WithLabelValues("failed").Add(float64(res.Failed))
+		// 全部失败了再领取也是这一批，等下一次调度
+		if res.Claimed < j.batchSize || res.Failed == res.Claimed {
+			return nil
+		}
+	}
+}
+
+func (j *CntFlushJob) reportPending() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cnt, err := j.repo.PendingCnt(ctx)
+	if err != nil {
+		j.l.Error("查询没有写回的计数失败", logger.Error(err))
+		return
+	}
+	pendingCnt.Set(float64(cnt))
+}
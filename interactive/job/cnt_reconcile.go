@@ -0,0 +1,86 @@
+package job
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"strconv"
+	"time"
+)
+
+var mismatchCnt = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "internal_test",
+	Subsystem: "red_feed",
+	Name:      "interactive_cnt_mismatch",
+	Help:      "点赞数、收藏数和点赞、收藏记录对不上的资源数",
+}, []string{"biz", "repaired"})
+
+// CntReconcileJob 按照点赞、收藏记录核对 interactives 表里面的点赞数、收藏数。
+// repair 为 false 的时候只上报，不修复
+type CntReconcileJob struct {
+	repo      repository.CntReconcileRepository
+	l         logger.Logger
+	batchSize int
+	repair    bool
+	timeout   time.Duration
+	// startId 上一次超时没有核对完的，下一次从这里接着核对
+	startId int64
+}
+
+func NewCntReconcileJob(repo repository.CntReconcileRepository, l logger.Logger,
+	batchSize int, repair bool, timeout time.Duration) *CntReconcileJob {
+	prometheus.MustRegister(mismatchCnt)
+	return &CntReconcileJob{
+		repo:      repo,
+		l:         l,
+		batchSize: batchSize,
+		repair:    repair,
+		timeout:   timeout,
+	}
+}
+
+func (j *CntReconcileJob) Name() string {
+	return "interactive_cnt_reconcile"
+}
+
+func (j *CntReconcileJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Reconcile(ctx)
+}
+
+// Reconcile 分批核对，直到全部核对完，或者 ctx 超时
+func (j *CntReconcileJob) Reconcile(ctx context.Context) error {
+	checked, mismatched := 0, 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		res, err := j.repo.Reconcile(ctx, j.startId, j.batchSize, j.repair)
+		if err != nil {
+			return err
+		}
+		checked += res.Checked
+		mismatched += len(res.Mismatches)
+		for _, m := range res.Mismatches {
+			mismatchCnt.WithLabelValues(m.Biz, strconv.FormatBool(m.Repaired)).Inc()
+			j.l.Warn("计数和记录对不上",
+				logger.String("biz", m.Biz),
+				logger.Int64("bizId", m.BizId),
+				logger.Int64("likeCnt", m.LikeCnt),
+				logger.Int64("expectedLikeCnt", m.ExpectedLikeCnt),
+				logger.Int64("collectCnt", m.CollectCnt),
+				logger.Int64("expectedCollectCnt", m.ExpectedCollectCnt),
+				logger.Bool("repaired", m.Repaired))
+		}
+		if res.Checked < j.batchSize {
+			// 核对完了，下一次从头开始
+			j.startId = 0
+			break
+		}
+		j.startId = res.LastId
+	}
+	j.l.Info("核对计数完成", logger.Int("checked", checked), logger.Int("mismatched", mismatched))
+	return nil
+}
@@ -0,0 +1,61 @@
+package job
+
+import (
+	"context"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// FlushCleanJob 清理写回模式下的去重记录。
+// 同一次写回的重试最多隔几个写回周期，超过 ttl 的记录已经没用了
+type FlushCleanJob struct {
+	repo      repository.CntFlushRepository
+	l         logger.Logger
+	ttl       time.Duration
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewFlushCleanJob(repo repository.CntFlushRepository, l logger.Logger,
+	ttl time.Duration, batchSize int, timeout time.Duration) *FlushCleanJob {
+	return &FlushCleanJob{
+		repo:      repo,
+		l:         l,
+		ttl:       ttl,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (j *FlushCleanJob) Name() string {
+	return "interactive_flush_clean"
+}
+
+func (j *FlushCleanJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Clean(ctx)
+}
+
+// Clean 一批一批地删，直到删不满一批，或者 ctx 超时。
+// 一批一条 DELETE，不会长时间锁住正在写回的记录
+func (j *FlushCleanJob) Clean(ctx context.Context) error {
+	before := time.Now().Add(-j.ttl)
+	var total int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cnt, err := j.repo.CleanFlushed(ctx, before, j.batchSize)
+		if err != nil {
+			return err
+		}
+		total += cnt
+		if cnt < int64(j.batchSize) {
+			break
+		}
+	}
+	j.l.Debug("清理写回记录完成", logger.Int64("cnt", total))
+	return nil
+}
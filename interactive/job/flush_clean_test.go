@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/repository"
+	repomocks "red-feed/interactive/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestFlushCleanJob_Clean(t *testing.T) {
+	ttl := time.Hour * 24 * 7
+	// 删的是 ttl 之前的，允许跑测试本身花的一点时间
+	before := gomock.Cond(func(x any) bool {
+		diff := time.Since(x.(time.Time)) - ttl
+		return diff >= 0 && diff < time.Minute
+	})
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.CntFlushRepository
+		wantErr error
+	}{
+		{
+			name: "一批删满了接着删，直到删不满一批",
+			mock: func(ctrl *gomock.Controller) repository.CntFlushRepository {
+				repo := repomocks.NewMockCntFlushRepository(ctrl)
+				repo.EXPECT().CleanFlushed(gomock.Any(), before, 2).Return(int64(2), nil)
+				repo.EXPECT().CleanFlushed(gomock.Any(), before, 2).Return(int64(1), nil)
+				return repo
+			},
+		},
+		{
+			name: "没有过期的",
+			mock: func(ctrl *gomock.Controller) repository.CntFlushRepository {
+				repo := repomocks.NewMockCntFlushRepository(ctrl)
+				repo.EXPECT().CleanFlushed(gomock.Any(), before, 2).Return(int64(0), nil)
+				return repo
+			},
+		},
+		{
+			name: "数据库错误",
+			mock: func(ctrl *gomock.Controller) repository.CntFlushRepository {
+				repo := repomocks.NewMockCntFlushRepository(ctrl)
+				repo.EXPECT().CleanFlushed(gomock.Any(), before, 2).Return(int64(0), errors.New("db error"))
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			j := NewFlushCleanJob(tc.mock(ctrl), &logger.NopLogger{}, ttl, 2, time.Second)
+			err := j.Clean(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
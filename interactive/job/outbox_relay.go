@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/events"
+	"red-feed/interactive/repository"
+	"sync/atomic"
+	"time"
+)
+
+// OutboxRelayJob 把 outbox 里面的点赞、收藏事件发到 Kafka，发成功了才删掉。
+// 先认领再发，单体和 interactive 服务同时跑也不会发同一批。
+// 发成功了但是没删掉的，认领过期之后会再发一遍，下游按照 EventId 去重
+type OutboxRelayJob struct {
+	repo      repository.InteractiveEventRepository
+	producer  events.InteractiveEventProducer
+	batchSize int
+	timeout   time.Duration
+	// running 调度得比较频繁，上一次还没跑完就跳过
+	running atomic.Bool
+}
+
+func NewOutboxRelayJob(repo repository.InteractiveEventRepository, producer events.InteractiveEventProducer,
+	batchSize int, timeout time.Duration) *OutboxRelayJob {
+	return &OutboxRelayJob{
+		repo:      repo,
+		producer:  producer,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (j *OutboxRelayJob) Name() string {
+	return "interactive_outbox_relay"
+}
+
+func (j *OutboxRelayJob) Run() error {
+	if !j.running.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer j.running.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Relay(ctx)
+}
+
+// Relay 一批一批地发，直到发完，或者 ctx 超时
+func (j *OutboxRelayJob) Relay(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// 认领的时间和任务的超时一样，超时之前发不完的让别人接着发
+		evts, err := j.repo.Claim(ctx, j.batchSize, j.timeout)
+		if err != nil || len(evts) == 0 {
+			return err
+		}
+		err = j.producer.ProduceInteractiveEvents(ctx,
+			slice.Map[domain.InteractiveEvent, events.InteractiveEvent](evts,
+				func(idx int, src domain.InteractiveEvent) events.InteractiveEvent {
+					return events.InteractiveEvent{
+						EventId: src.EventId,
+						Type:    string(src.Type),
+						Biz:     src.Biz,
+						BizId:   src.BizId,
+						Uid:     src.Uid,
+						Ctime:   src.Ctime.UnixMilli(),
+					}
+				}))
+		if err != nil {
+			return err
+		}
+		if err = j.repo.MarkSent(ctx, evts); err != nil {
+			return err
+		}
+		if len(evts) < j.batchSize {
+			return nil
+		}
+	}
+}
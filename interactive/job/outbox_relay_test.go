@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/events"
+	evtmocks "red-feed/interactive/events/mocks"
+	"red-feed/interactive/repository"
+	repomocks "red-feed/interactive/repository/mocks"
+	"testing"
+	"time"
+)
+
+func TestOutboxRelayJob_Relay(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	full := []domain.InteractiveEvent{
+		{Id: 1, EventId: "e1", Type: domain.EventLiked, Biz: "article", BizId: 10, Uid: 100, Ctime: now},
+		{Id: 2, EventId: "e2", Type: domain.EventCollected, Biz: "article", BizId: 11, Uid: 100, Ctime: now},
+	}
+	rest := []domain.InteractiveEvent{
+		{Id: 3, EventId: "e3", Type: domain.EventUnliked, Biz: "article", BizId: 10, Uid: 100, Ctime: now},
+	}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.InteractiveEventRepository, events.InteractiveEventProducer)
+		wantErr error
+	}{
+		{
+			name: "一批满了接着发，直到发完",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveEventRepository, events.InteractiveEventProducer) {
+				repo := repomocks.NewMockInteractiveEventRepository(ctrl)
+				producer := evtmocks.NewMockInteractiveEventProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), 2, time.Second).Return(full, nil)
+				producer.EXPECT().ProduceInteractiveEvents(gomock.Any(), []events.InteractiveEvent{
+					{EventId: "e1", Type: "liked", Biz: "article", BizId: 10, Uid: 100, Ctime: now.UnixMilli()},
+					{EventId: "e2", Type: "collected", Biz: "article", BizId: 11, Uid: 100, Ctime: now.UnixMilli()},
+				}).Return(nil)
+				repo.EXPECT().MarkSent(gomock.Any(), full).Return(nil)
+				repo.EXPECT().Claim(gomock.Any(), 2, time.Second).Return(rest, nil)
+				producer.EXPECT().ProduceInteractiveEvents(gomock.Any(), gomock.Len(1)).Return(nil)
+				repo.EXPECT().MarkSent(gomock.Any(), rest).Return(nil)
+				return repo, producer
+			},
+		},
+		{
+			name: "没有要发的",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveEventRepository, events.InteractiveEventProducer) {
+				repo := repomocks.NewMockInteractiveEventRepository(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), 2, time.Second).Return(nil, nil)
+				return repo, evtmocks.NewMockInteractiveEventProducer(ctrl)
+			},
+		},
+		{
+			name: "发送失败，不能删",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveEventRepository, events.InteractiveEventProducer) {
+				repo := repomocks.NewMockInteractiveEventRepository(ctrl)
+				producer := evtmocks.NewMockInteractiveEventProducer(ctrl)
+				repo.EXPECT().Claim(gomock.Any(), 2, time.Second).Return(full, nil)
+				producer.EXPECT().ProduceInteractiveEvents(gomock.Any(), gomock.Any()).
+					Return(errors.New("kafka error"))
+				return repo, producer
+			},
+			wantErr: errors.New("kafka error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			j := NewOutboxRelayJob(repo, producer, 2, time.Second)
+			err := j.Relay(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"red-feed/interactive/repository"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// UvPersistJob 把 Redis 里面数出来的 UV 写进数据库。
+// 写的是人数不是增量，多个实例同时跑写重了也没关系
+type UvPersistJob struct {
+	repo      repository.UvRepository
+	l         logger.Logger
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewUvPersistJob(repo repository.UvRepository, l logger.Logger,
+	batchSize int, timeout time.Duration) *UvPersistJob {
+	return &UvPersistJob{
+		repo:      repo,
+		l:         l,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (j *UvPersistJob) Name() string {
+	return "interactive_uv_persist"
+}
+
+func (j *UvPersistJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Persist(ctx)
+}
+
+// Persist 一批一批地写，直到取出来的不满一批，或者 ctx 超时
+func (j *UvPersistJob) Persist(ctx context.Context) error {
+	total := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cnt, err := j.repo.PersistUv(ctx, j.batchSize)
+		if err != nil {
+			return err
+		}
+		total += cnt
+		if cnt < j.batchSize {
+			break
+		}
+	}
+	j.l.Debug("写入 UV 完成", logger.Int("cnt", total))
+	return nil
+}
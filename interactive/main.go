@@ -1,6 +1,11 @@
 package main
 
-import "github.com/spf13/viper"
+import (
+	"github.com/spf13/viper"
+	"os"
+	"os/signal"
+	"syscall"
+)
 
 func main() {
 	initViper()
@@ -11,10 +16,19 @@ func main() {
 			panic(err)
 		}
 	}
-	err := app.server.Serve()
-	if err != nil {
-		panic(err)
-	}
+	app.cron.Start()
+	go func() {
+		err := app.server.Serve()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	// k8s 滚动更新的时候先发 SIGTERM，过了宽限期才 SIGKILL
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	app.Shutdown()
 }
 
 func initViper() {
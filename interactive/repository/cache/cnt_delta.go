@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"red-feed/interactive/domain"
+	"strconv"
+	"strings"
+)
+
+var (
+	//go:embed lua/interactive_add_delta.lua
+	luaAddDelta string
+	//go:embed lua/interactive_claim_delta.lua
+	luaClaimDelta string
+	//go:embed lua/interactive_finish_delta.lua
+	luaFinishDelta string
+)
+
+const (
+	// keyDeltaPending 有计数变化等着写回数据库的资源
+	keyDeltaPending = "interactive:delta:pending"
+	// keyDeltaFlushing 已经领取了但是还没有写完的资源，进程崩溃之后靠它找回来
+	keyDeltaFlushing = "interactive:delta:flushing"
+	fieldFlushId     = "flush_id"
+)
+
+// CntDeltaCache 写回模式下点赞数、收藏数先攒在 Redis 里面，定时写回数据库。
+// 领取的时候把增量挪到另外一个 key 上，写回成功之前一直留着，写回失败或者崩溃了下一次接着写
+//
+//go:generate mockgen -source=./cnt_delta.go -package=cachemocks -destination=mocks/cnt_delta.mock.go CntDeltaCache
+type CntDeltaCache interface {
+	// Add 记下计数变化
+	Add(ctx context.Context, biz string, bizId int64, delta domain.CntDelta) error
+	// Pending 还没有写回数据库的计数变化，包括正在写回的
+	Pending(ctx context.Context, biz string, bizId int64) (domain.CntDelta, error)
+	// Claim 领取最多 limit 个资源的计数变化，上一次没有写完的优先
+	Claim(ctx context.Context, limit int) ([]domain.CntFlush, error)
+	// Finish 写回数据库之后删掉领取的增量
+	Finish(ctx context.Context, flush domain.CntFlush) error
+	// PendingCnt 还有多少个资源的计数变化没有写回
+	PendingCnt(ctx context.Context) (int64, error)
+}
+
+type RedisCntDeltaCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisCntDeltaCache(client redis.Cmdable) CntDeltaCache {
+	return &RedisCntDeltaCache{
+		client: client,
+	}
+}
+
+func (c *RedisCntDeltaCache) Add(ctx context.Context, biz string, bizId int64, delta domain.CntDelta) error {
+	if delta.IsZero() {
+		return nil
+	}
+	return c.client.Eval(ctx, luaAddDelta,
+		[]string{c.deltaKey(biz, bizId), keyDeltaPending},
+		c.member(biz, bizId),
+		fieldLikeCnt, delta.LikeCnt,
+		fieldCollectCnt, delta.CollectCnt).Err()
+}
+
+func (c *RedisCntDeltaCache) Pending(ctx context.Context, biz string, bizId int64) (domain.CntDelta, error) {
+	pipe := c.client.Pipeline()
+	cmds := []*redis.MapStringStringCmd{
+		pipe.HGetAll(ctx, c.deltaKey(biz, bizId)),
+		pipe.HGetAll(ctx, c.flushingKey(biz, bizId)),
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return domain.CntDelta{}, err
+	}
+	var res domain.CntDelta
+	for _, cmd := range cmds {
+		delta := c.toDelta(cmd.Val())
+		res.LikeCnt += delta.LikeCnt
+		res.CollectCnt += delta.CollectCnt
+	}
+	return res, nil
+}
+
+func (c *RedisCntDeltaCache) Claim(ctx context.Context, limit int) ([]domain.CntFlush, error) {
+	// 先把上一次没写完的捡回来
+	members, err := c.client.SRandMemberN(ctx, keyDeltaFlushing, int64(limit)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if rest := limit - len(members); rest > 0 {
+		pending, err := c.client.SRandMemberN(ctx, keyDeltaPending, int64(rest)).Result()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, pending...)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.Cmd, 0, len(members))
+	for _, member := range members {
+		biz, bizId, err := c.parseMember(member)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, pipe.Eval(ctx, luaClaimDelta,
+			[]string{c.deltaKey(biz, bizId), c.flushingKey(biz, bizId), keyDeltaPending, keyDeltaFlushing},
+			member, uuid.New().String()))
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]domain.CntFlush, 0, len(cmds))
+	for i, cmd := range cmds {
+		vals, err := cmd.StringSlice()
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) == 0 {
+			// 别的实例已经领走了
+			continue
+		}
+		fields := make(map[string]string, len(vals)/2)
+		for j := 0; j+1 < len(vals); j += 2 {
+			fields[vals[j]] = vals[j+1]
+		}
+		biz, bizId, _ := c.parseMember(members[i])
+		res = append(res, domain.CntFlush{
+			FlushId: fields[fieldFlushId],
+			Biz:     biz,
+			BizId:   bizId,
+			Delta:   c.toDelta(fields),
+		})
+	}
+	return res, nil
+}
+
+func (c *RedisCntDeltaCache) Finish(ctx context.Context, flush domain.CntFlush) error {
+	return c.client.Eval(ctx, luaFinishDelta,
+		[]string{c.flushingKey(flush.Biz, flush.BizId), keyDeltaFlushing},
+		c.member(flush.Biz, flush.BizId), flush.FlushId).Err()
+}
+
+func (c *RedisCntDeltaCache) PendingCnt(ctx context.Context) (int64, error) {
+	pipe := c.client.Pipeline()
+	pending := pipe.SCard(ctx, keyDeltaPending)
+	flushing := pipe.SCard(ctx, keyDeltaFlushing)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return pending.Val() + flushing.Val(), nil
+}
+
+func (c *RedisCntDeltaCache) toDelta(fields map[string]string) domain.CntDelta {
+	likeCnt, _ := strconv.ParseInt(fields[fieldLikeCnt], 10, 64)
+	collectCnt, _ := strconv.ParseInt(fields[fieldCollectCnt], 10, 64)
+	return domain.CntDelta{
+		LikeCnt:    likeCnt,
+		CollectCnt: collectCnt,
+	}
+}
+
+func (c *RedisCntDeltaCache) member(biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d", biz, bizId)
+}
+
+func (c *RedisCntDeltaCache) parseMember(member string) (string, int64, error) {
+	idx := strings.LastIndexByte(member, ':')
+	if idx < 0 {
+		return "", 0, errors.New("计数增量的资源格式不对：" + member)
+	}
+	bizId, err := strconv.ParseInt(member[idx+1:], 10, 64)
+	if err != nil {
+		return "", 0, err
+	}
+	return member[:idx], bizId, nil
+}
+
+func (c *RedisCntDeltaCache) deltaKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:delta:%s:%d", biz, bizId)
+}
+
+func (c *RedisCntDeltaCache) flushingKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:delta_flushing:%s:%d", biz, bizId)
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// EventDedupCache 用 SET NX 记住处理过的消息，重复消费的时候丢掉
+//
+//go:generate mockgen -source=./event.go -package=cachemocks -destination=mocks/event.mock.go EventDedupCache
+type EventDedupCache interface {
+	// MarkProcessed 标记这些事件处理过了，返回每个事件是不是第一次处理
+	MarkProcessed(ctx context.Context, topic string, eventIds []string) ([]bool, error)
+	// Unmark 处理失败的时候去掉标记，重新投递的时候还能处理
+	Unmark(ctx context.Context, topic string, eventIds []string) error
+}
+
+type RedisEventDedupCache struct {
+	client redis.Cmdable
+	// expiration 要比消息可能被重复投递的时间窗口长，rebalance 和重试一般都在几分钟之内
+	expiration time.Duration
+}
+
+func NewRedisEventDedupCache(client redis.Cmdable) EventDedupCache {
+	return &RedisEventDedupCache{
+		client:     client,
+		expiration: time.Hour * 24,
+	}
+}
+
+func (c *RedisEventDedupCache) MarkProcessed(ctx context.Context, topic string, eventIds []string) ([]bool, error) {
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.BoolCmd, 0, len(eventIds))
+	for _, id := range eventIds {
+		cmds = append(cmds, pipe.SetNX(ctx, c.key(topic, id), 1, c.expiration))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]bool, 0, len(cmds))
+	for _, cmd := range cmds {
+		res = append(res, cmd.Val())
+	}
+	return res, nil
+}
+
+func (c *RedisEventDedupCache) Unmark(ctx context.Context, topic string, eventIds []string) error {
+	keys := make([]string, 0, len(eventIds))
+	for _, id := range eventIds {
+		keys = append(keys, c.key(topic, id))
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *RedisEventDedupCache) key(topic string, id string) string {
+	return fmt.Sprintf("interactive:event:%s:%s", topic, id)
+}
@@ -7,12 +7,17 @@ import (
 	"github.com/redis/go-redis/v9"
 	"red-feed/interactive/domain"
 	"strconv"
+	"strings"
 	"time"
 )
 
 var (
 	//go:embed lua/interative_incr_cnt.lua
 	luaIncrCnt string
+	//go:embed lua/interactive_add_liked.lua
+	luaAddLiked string
+	//go:embed lua/interactive_set_cnt.lua
+	luaSetCnt string
 )
 
 var ErrKeyNotExist = redis.Nil
@@ -21,33 +26,63 @@ const (
 	fieldReadCnt    = "read_cnt"
 	fieldCollectCnt = "collect_cnt"
 	fieldLikeCnt    = "like_cnt"
+	fieldUvCnt      = "uv_cnt"
+	// fieldReactionCntPrefix 后面跟着表情，默认的表情就是 like_cnt
+	fieldReactionCntPrefix = "reaction_cnt:"
 )
 
+// RecentLikedLimit 每个用户最多缓存最近点赞的多少个资源
+const RecentLikedLimit = 1000
+
+// likedComplete 哨兵，存在的时候说明缓存里面就是用户全部的点赞
+const likedComplete = "*"
+
 //go:generate mockgen -source=./interactive.go -package=cachemocks -destination=mocks/interactive.mock.go InteractiveCache
 type InteractiveCache interface {
 
 	// IncrReadCntIfPresent 如果在缓存中有对应的数据，就 +1
 	IncrReadCntIfPresent(ctx context.Context,
 		biz string, bizId int64) error
+	// BatchIncrReadCntIfPresent 同一个资源先合并，再用一个 pipeline 更新缓存中有的数据
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64) error
 	IncrLikeCntIfPresent(ctx context.Context,
 		biz string, bizId int64) error
 	DecrLikeCntIfPresent(ctx context.Context,
 		biz string, bizId int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// IncrReactionCntIfPresent 默认以外的表情的数量，delta 可以是负数
+	IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error
+	// SetUvCntIfPresent UV 是 HyperLogLog 数出来的，只能整个覆盖
+	SetUvCntIfPresent(ctx context.Context, biz string, bizId int64, uvCnt int64) error
 	// Get 查询缓存中数据
 	Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error
+	Del(ctx context.Context, biz string, bizId int64) error
+
+	// GetLiked 返回 bizIds 里面缓存显示点赞了的。
+	// complete 为 false 的时候缓存不完整，不在结果里的还要再查数据库；缓存不存在返回 ErrKeyNotExist
+	GetLiked(ctx context.Context, biz string, uid int64, bizIds []int64) (liked []int64, complete bool, err error)
+	// SetLiked 用数据库里面用户最近的点赞覆盖缓存
+	SetLiked(ctx context.Context, biz string, uid int64, items []domain.LikedItem, complete bool) error
+	AddLikedIfPresent(ctx context.Context, biz string, uid int64, item domain.LikedItem) error
+	RemoveLiked(ctx context.Context, biz string, uid int64, bizId int64) error
 }
 
-func NewRedisInteractiveCache(cmd redis.Cmdable) InteractiveCache {
+func NewRedisInteractiveCache(cmd redis.Cmdable, bizs *domain.BizRegistry) InteractiveCache {
 	return &RedisInteractiveCache{
-		client: cmd,
+		client:          cmd,
+		bizs:            bizs,
+		likedExpiration: time.Minute * 30,
 	}
 }
 
 type RedisInteractiveCache struct {
 	client redis.Cmdable
+	// bizs 计数缓存的过期时间每个业务不一样
+	bizs *domain.BizRegistry
+	// likedExpiration 加载和更新之间有并发的时候缓存可能少了一个点赞，过期时间不要太长
+	likedExpiration time.Duration
 }
 
 func (c *RedisInteractiveCache) IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error {
@@ -56,6 +91,24 @@ func (c *RedisInteractiveCache) IncrReadCntIfPresent(ctx context.Context, biz st
 		fieldReadCnt, 1).Err()
 }
 
+func (c *RedisInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64) error {
+	// 让调用者保证两者是相等的
+	deltas := make(map[string]int64, len(bizs))
+	for i := 0; i < len(bizs); i++ {
+		deltas[c.key(bizs[i], bizIds[i])]++
+	}
+	if len(deltas) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for key, delta := range deltas {
+		pipe.Eval(ctx, luaIncrCnt, []string{key}, fieldReadCnt, delta)
+	}
+	// 缓存不存在的时候脚本返回 0，不会是 redis.Nil
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (c *RedisInteractiveCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	return c.client.Eval(ctx, luaIncrCnt,
 		[]string{c.key(biz, bizId)},
@@ -68,6 +121,13 @@ func (c *RedisInteractiveCache) DecrLikeCntIfPresent(ctx context.Context, biz st
 		fieldLikeCnt, -1).Err()
 }
 
+func (c *RedisInteractiveCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64,
+	reaction string, delta int64) error {
+	return c.client.Eval(ctx, luaIncrCnt,
+		[]string{c.key(biz, bizId)},
+		fieldReactionCntPrefix+reaction, delta).Err()
+}
+
 func (c *RedisInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	return c.client.Eval(ctx, luaIncrCnt,
 		[]string{c.key(biz, bizId)},
@@ -80,6 +140,11 @@ func (c *RedisInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz
 		fieldCollectCnt, -1).Err()
 }
 
+func (c *RedisInteractiveCache) SetUvCntIfPresent(ctx context.Context, biz string, bizId int64, uvCnt int64) error {
+	return c.client.Eval(ctx, luaSetCnt, []string{c.key(biz, bizId)},
+		fieldUvCnt, uvCnt).Err()
+}
+
 func (c *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	// 直接使用 HMGet，即便缓存中没有对应的 key，也不会返回 error
 	data, err := c.client.HGetAll(ctx, c.key(biz, bizId)).Result()
@@ -96,25 +161,111 @@ func (c *RedisInteractiveCache) Get(ctx context.Context, biz string, bizId int64
 	collectCnt, _ := strconv.ParseInt(data[fieldCollectCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(data[fieldLikeCnt], 10, 64)
 	readCnt, _ := strconv.ParseInt(data[fieldReadCnt], 10, 64)
+	uvCnt, _ := strconv.ParseInt(data[fieldUvCnt], 10, 64)
+	reactionCnts := make(map[string]int64)
+	for field, val := range data {
+		reaction, ok := strings.CutPrefix(field, fieldReactionCntPrefix)
+		if !ok {
+			continue
+		}
+		if cnt, _ := strconv.ParseInt(val, 10, 64); cnt > 0 {
+			reactionCnts[reaction] = cnt
+		}
+	}
 
 	return domain.Interactive{
-		BizId:      bizId,
-		CollectCnt: collectCnt,
-		LikeCnt:    likeCnt,
-		ReadCnt:    readCnt,
+		BizId:        bizId,
+		CollectCnt:   collectCnt,
+		LikeCnt:      likeCnt,
+		ReadCnt:      readCnt,
+		UvCnt:        uvCnt,
+		ReactionCnts: reactionCnts,
 	}, err
 }
 
 func (c *RedisInteractiveCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
 	key := c.key(biz, bizId)
-	err := c.client.HMSet(ctx, key,
+	vals := []any{
 		fieldLikeCnt, intr.LikeCnt,
 		fieldCollectCnt, intr.CollectCnt,
-		fieldReadCnt, intr.ReadCnt).Err()
+		fieldReadCnt, intr.ReadCnt,
+		fieldUvCnt, intr.UvCnt,
+	}
+	for reaction, cnt := range intr.ReactionCnts {
+		// 默认的表情已经在 like_cnt 里面了
+		if reaction != domain.ReactionLike {
+			vals = append(vals, fieldReactionCntPrefix+reaction, cnt)
+		}
+	}
+	err := c.client.HMSet(ctx, key, vals...).Err()
 	if err != nil {
 		return err
 	}
-	return c.client.Expire(ctx, key, time.Minute*15).Err()
+	return c.client.Expire(ctx, key, c.bizs.CacheTTL(biz)).Err()
+}
+
+func (c *RedisInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	return c.client.Del(ctx, c.key(biz, bizId)).Err()
+}
+
+func (c *RedisInteractiveCache) GetLiked(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, bool, error) {
+	key := c.likedKey(biz, uid)
+	members := make([]string, 0, len(bizIds)+1)
+	members = append(members, likedComplete)
+	for _, id := range bizIds {
+		members = append(members, strconv.FormatInt(id, 10))
+	}
+	pipe := c.client.Pipeline()
+	exists := pipe.Exists(ctx, key)
+	scores := pipe.ZMScore(ctx, key, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, false, err
+	}
+	if exists.Val() == 0 {
+		return nil, false, ErrKeyNotExist
+	}
+	// 不存在的成员分数是 0，点赞时间和哨兵的分数都大于 0
+	vals := scores.Val()
+	liked := make([]int64, 0, len(bizIds))
+	for i, id := range bizIds {
+		if vals[i+1] > 0 {
+			liked = append(liked, id)
+		}
+	}
+	return liked, vals[0] > 0, nil
+}
+
+func (c *RedisInteractiveCache) SetLiked(ctx context.Context, biz string, uid int64, items []domain.LikedItem, complete bool) error {
+	key := c.likedKey(biz, uid)
+	members := make([]redis.Z, 0, len(items)+1)
+	if complete {
+		members = append(members, redis.Z{Score: 1, Member: likedComplete})
+	}
+	for _, item := range items {
+		members = append(members, redis.Z{Score: float64(item.Utime.UnixMilli()), Member: item.BizId})
+	}
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, key)
+	if len(members) > 0 {
+		pipe.ZAdd(ctx, key, members...)
+		pipe.Expire(ctx, key, c.likedExpiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisInteractiveCache) AddLikedIfPresent(ctx context.Context, biz string, uid int64, item domain.LikedItem) error {
+	return c.client.Eval(ctx, luaAddLiked,
+		[]string{c.likedKey(biz, uid)},
+		item.BizId, item.Utime.UnixMilli(), RecentLikedLimit, likedComplete).Err()
+}
+
+func (c *RedisInteractiveCache) RemoveLiked(ctx context.Context, biz string, uid int64, bizId int64) error {
+	return c.client.ZRem(ctx, c.likedKey(biz, uid), bizId).Err()
+}
+
+func (c *RedisInteractiveCache) likedKey(biz string, uid int64) string {
+	return fmt.Sprintf("interactive:liked:%s:%d", biz, uid)
 }
 
 func (c *RedisInteractiveCache) key(biz string, bizId int64) string {
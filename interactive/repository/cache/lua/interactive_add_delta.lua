@@ -0,0 +1,8 @@
+-- KEYS[1] 资源的计数增量，KEYS[2] 等待写回数据库的资源集合
+-- ARGV[1] 集合里面的资源，后面是一对对的字段和增量
+local key = KEYS[1]
+for i = 2, #ARGV, 2 do
+    redis.call("HINCRBY", key, ARGV[i], tonumber(ARGV[i + 1]))
+end
+redis.call("SADD", KEYS[2], ARGV[1])
+return 1
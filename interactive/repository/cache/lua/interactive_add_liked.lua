@@ -0,0 +1,22 @@
+local key = KEYS[1]
+local member = ARGV[1]
+local score = tonumber(ARGV[2])
+-- 除了标记完整的哨兵之外最多保留多少个
+local limit = tonumber(ARGV[3])
+local sentinel = ARGV[4]
+local exists = redis.call("EXISTS", key)
+if exists == 1 then
+    redis.call("ZADD", key, score, member)
+    local size = redis.call("ZCARD", key)
+    if redis.call("ZSCORE", key, sentinel) then
+        size = size - 1
+    end
+    if size > limit then
+        -- 超出上限之后缓存就不完整了，去掉哨兵，只留最近的 limit 个
+        redis.call("ZREM", key, sentinel)
+        redis.call("ZREMRANGEBYRANK", key, 0, -(limit + 1))
+    end
+    return 1
+else
+    return 0
+end
@@ -0,0 +1,16 @@
+-- KEYS[1] 资源的计数增量，KEYS[2] 正在写回的增量，KEYS[3] 等待写回的集合，KEYS[4] 正在写回的集合
+-- ARGV[1] 集合里面的资源，ARGV[2] 新的 flush id
+-- 上一次领取了还没有完成的，接着用原来的 flush id 写，不领取新的增量
+if redis.call("EXISTS", KEYS[2]) == 1 then
+    redis.call("SADD", KEYS[4], ARGV[1])
+    return redis.call("HGETALL", KEYS[2])
+end
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    redis.call("SREM", KEYS[3], ARGV[1])
+    return {}
+end
+redis.call("RENAME", KEYS[1], KEYS[2])
+redis.call("HSET", KEYS[2], "flush_id", ARGV[2])
+redis.call("SREM", KEYS[3], ARGV[1])
+redis.call("SADD", KEYS[4], ARGV[1])
+return redis.call("HGETALL", KEYS[2])
@@ -0,0 +1,9 @@
+-- KEYS[1] 有新读者的集合，KEYS[2] 总的 UV，KEYS[3] 按天的 UV
+-- ARGV[1] 集合里面的资源，ARGV[2] 写进数据库的总数，ARGV[3] 写进数据库的当天人数
+-- 写数据库的时候又有新读者的，人数对不上，留着下一次再写
+if redis.call("PFCOUNT", KEYS[2]) == tonumber(ARGV[2]) and
+        redis.call("PFCOUNT", KEYS[3]) == tonumber(ARGV[3]) then
+    redis.call("SREM", KEYS[1], ARGV[1])
+    return 1
+end
+return 0
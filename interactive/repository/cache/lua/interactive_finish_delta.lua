@@ -0,0 +1,10 @@
+-- KEYS[1] 正在写回的增量，KEYS[2] 正在写回的集合
+-- ARGV[1] 集合里面的资源，ARGV[2] 写回数据库的 flush id
+-- flush id 对不上说明已经被别的实例处理完，又领取了新的，不能删
+if redis.call("HGET", KEYS[1], "flush_id") == ARGV[2] then
+    redis.call("DEL", KEYS[1])
+end
+if redis.call("EXISTS", KEYS[1]) == 0 then
+    redis.call("SREM", KEYS[2], ARGV[1])
+end
+return 1
@@ -0,0 +1,10 @@
+local key = KEYS[1]
+local cntKey = ARGV[1]
+local val = ARGV[2]
+local exists = redis.call("EXISTS", key)
+if exists == 1 then
+    redis.call("HSET", key, cntKey, val)
+    return 1
+else
+    return 0
+end
@@ -0,0 +1,6 @@
+-- KEYS[1] ip 的阅读次数
+-- 窗口已经过期的不用减，直接 DECR 会留下一个不会过期的负数
+if redis.call("EXISTS", KEYS[1]) == 1 then
+    return redis.call("DECR", KEYS[1])
+end
+return 0
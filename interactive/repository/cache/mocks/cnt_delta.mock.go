@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./cnt_delta.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./cnt_delta.go -package=cachemocks -destination=mocks/cnt_delta.mock.go CntDeltaCache
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCntDeltaCache is a mock of CntDeltaCache interface.
+type MockCntDeltaCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockCntDeltaCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockCntDeltaCacheMockRecorder is the mock recorder for MockCntDeltaCache.
+type MockCntDeltaCacheMockRecorder struct {
+	mock *MockCntDeltaCache
+}
+
+// NewMockCntDeltaCache creates a new mock instance.
+func NewMockCntDeltaCache(ctrl *gomock.Controller) *MockCntDeltaCache {
+	mock := &MockCntDeltaCache{ctrl: ctrl}
+	mock.recorder = &MockCntDeltaCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCntDeltaCache) EXPECT() *MockCntDeltaCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockCntDeltaCache) Add(ctx context.Context, biz string, bizId int64, delta domain.CntDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockCntDeltaCacheMockRecorder) Add(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockCntDeltaCache)(nil).Add), ctx, biz, bizId, delta)
+}
+
+// Claim mocks base method.
+func (m *MockCntDeltaCache) Claim(ctx context.Context, limit int) ([]domain.CntFlush, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit)
+	ret0, _ := ret[0].([]domain.CntFlush)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockCntDeltaCacheMockRecorder) Claim(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockCntDeltaCache)(nil).Claim), ctx, limit)
+}
+
+// Finish mocks base method.
+func (m *MockCntDeltaCache) Finish(ctx context.Context, flush domain.CntFlush) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, flush)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockCntDeltaCacheMockRecorder) Finish(ctx, flush any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockCntDeltaCache)(nil).Finish), ctx, flush)
+}
+
+// Pending mocks base method.
+func (m *MockCntDeltaCache) Pending(ctx context.Context, biz string, bizId int64) (domain.CntDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.CntDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockCntDeltaCacheMockRecorder) Pending(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockCntDeltaCache)(nil).Pending), ctx, biz, bizId)
+}
+
+// PendingCnt mocks base method.
+func (m *MockCntDeltaCache) PendingCnt(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingCnt", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingCnt indicates an expected call of PendingCnt.
+func (mr *MockCntDeltaCacheMockRecorder) PendingCnt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingCnt", reflect.TypeOf((*MockCntDeltaCache)(nil).PendingCnt), ctx)
+}
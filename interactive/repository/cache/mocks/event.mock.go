@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./event.go -package=cachemocks -destination=mocks/event.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventDedupCache is a mock of EventDedupCache interface.
+type MockEventDedupCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventDedupCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockEventDedupCacheMockRecorder is the mock recorder for MockEventDedupCache.
+type MockEventDedupCacheMockRecorder struct {
+	mock *MockEventDedupCache
+}
+
+// NewMockEventDedupCache creates a new mock instance.
+func NewMockEventDedupCache(ctrl *gomock.Controller) *MockEventDedupCache {
+	mock := &MockEventDedupCache{ctrl: ctrl}
+	mock.recorder = &MockEventDedupCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventDedupCache) EXPECT() *MockEventDedupCacheMockRecorder {
+	return m.recorder
+}
+
+// MarkProcessed mocks base method.
+func (m *MockEventDedupCache) MarkProcessed(ctx context.Context, topic string, eventIds []string) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkProcessed", ctx, topic, eventIds)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkProcessed indicates an expected call of MarkProcessed.
+func (mr *MockEventDedupCacheMockRecorder) MarkProcessed(ctx, topic, eventIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkProcessed", reflect.TypeOf((*MockEventDedupCache)(nil).MarkProcessed), ctx, topic, eventIds)
+}
+
+// Unmark mocks base method.
+func (m *MockEventDedupCache) Unmark(ctx context.Context, topic string, eventIds []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unmark", ctx, topic, eventIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unmark indicates an expected call of Unmark.
+func (mr *MockEventDedupCacheMockRecorder) Unmark(ctx, topic, eventIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmark", reflect.TypeOf((*MockEventDedupCache)(nil).Unmark), ctx, topic, eventIds)
+}
@@ -0,0 +1,255 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive.go -package=cachemocks -destination=mocks/interactive.mock.go InteractiveCache
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveCache is a mock of InteractiveCache interface.
+type MockInteractiveCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractiveCacheMockRecorder is the mock recorder for MockInteractiveCache.
+type MockInteractiveCacheMockRecorder struct {
+	mock *MockInteractiveCache
+}
+
+// NewMockInteractiveCache creates a new mock instance.
+func NewMockInteractiveCache(ctrl *gomock.Controller) *MockInteractiveCache {
+	mock := &MockInteractiveCache{ctrl: ctrl}
+	mock.recorder = &MockInteractiveCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
+	return m.recorder
+}
+
+// AddLikedIfPresent mocks base method.
+func (m *MockInteractiveCache) AddLikedIfPresent(ctx context.Context, biz string, uid int64, item domain.LikedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLikedIfPresent", ctx, biz, uid, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddLikedIfPresent indicates an expected call of AddLikedIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) AddLikedIfPresent(ctx, biz, uid, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLikedIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).AddLikedIfPresent), ctx, biz, uid, item)
+}
+
+// BatchIncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCntIfPresent", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCntIfPresent indicates an expected call of BatchIncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchIncrReadCntIfPresent(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchIncrReadCntIfPresent), ctx, bizs, bizIds)
+}
+
+// DecrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCollectCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCollectCntIfPresent indicates an expected call of DecrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrCollectCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrCollectCntIfPresent), ctx, biz, bizId)
+}
+
+// DecrLikeCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLikeCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLikeCntIfPresent indicates an expected call of DecrLikeCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrLikeCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrLikeCntIfPresent), ctx, biz, bizId)
+}
+
+// Del mocks base method.
+func (m *MockInteractiveCache) Del(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockInteractiveCacheMockRecorder) Del(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockInteractiveCache)(nil).Del), ctx, biz, bizId)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveCache) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveCacheMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveCache)(nil).Get), ctx, biz, bizId)
+}
+
+// GetLiked mocks base method.
+func (m *MockInteractiveCache) GetLiked(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiked", ctx, biz, uid, bizIds)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLiked indicates an expected call of GetLiked.
+func (mr *MockInteractiveCacheMockRecorder) GetLiked(ctx, biz, uid, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiked", reflect.TypeOf((*MockInteractiveCache)(nil).GetLiked), ctx, biz, uid, bizIds)
+}
+
+// IncrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCollectCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCollectCntIfPresent indicates an expected call of IncrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCollectCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCollectCntIfPresent), ctx, biz, bizId)
+}
+
+// IncrLikeCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLikeCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLikeCntIfPresent indicates an expected call of IncrLikeCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrLikeCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLikeCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrLikeCntIfPresent), ctx, biz, bizId)
+}
+
+// IncrReactionCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReactionCntIfPresent", ctx, biz, bizId, reaction, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReactionCntIfPresent indicates an expected call of IncrReactionCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrReactionCntIfPresent(ctx, biz, bizId, reaction, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReactionCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReactionCntIfPresent), ctx, biz, bizId, reaction, delta)
+}
+
+// IncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCntIfPresent indicates an expected call of IncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrReadCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReadCntIfPresent), ctx, biz, bizId)
+}
+
+// RemoveLiked mocks base method.
+func (m *MockInteractiveCache) RemoveLiked(ctx context.Context, biz string, uid, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLiked", ctx, biz, uid, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLiked indicates an expected call of RemoveLiked.
+func (mr *MockInteractiveCacheMockRecorder) RemoveLiked(ctx, biz, uid, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLiked", reflect.TypeOf((*MockInteractiveCache)(nil).RemoveLiked), ctx, biz, uid, bizId)
+}
+
+// Set mocks base method.
+func (m *MockInteractiveCache) Set(ctx context.Context, biz string, bizId int64, intr domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, biz, bizId, intr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockInteractiveCacheMockRecorder) Set(ctx, biz, bizId, intr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockInteractiveCache)(nil).Set), ctx, biz, bizId, intr)
+}
+
+// SetLiked mocks base method.
+func (m *MockInteractiveCache) SetLiked(ctx context.Context, biz string, uid int64, items []domain.LikedItem, complete bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLiked", ctx, biz, uid, items, complete)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLiked indicates an expected call of SetLiked.
+func (mr *MockInteractiveCacheMockRecorder) SetLiked(ctx, biz, uid, items, complete any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLiked", reflect.TypeOf((*MockInteractiveCache)(nil).SetLiked), ctx, biz, uid, items, complete)
+}
+
+// SetUvCntIfPresent mocks base method.
+func (m *MockInteractiveCache) SetUvCntIfPresent(ctx context.Context, biz string, bizId, uvCnt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUvCntIfPresent", ctx, biz, bizId, uvCnt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUvCntIfPresent indicates an expected call of SetUvCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) SetUvCntIfPresent(ctx, biz, bizId, uvCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUvCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).SetUvCntIfPresent), ctx, biz, bizId, uvCnt)
+}
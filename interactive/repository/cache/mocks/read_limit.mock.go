@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./read_limit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./read_limit.go -package=cachemocks -destination=mocks/read_limit.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadLimitCache is a mock of ReadLimitCache interface.
+type MockReadLimitCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadLimitCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockReadLimitCacheMockRecorder is the mock recorder for MockReadLimitCache.
+type MockReadLimitCacheMockRecorder struct {
+	mock *MockReadLimitCache
+}
+
+// NewMockReadLimitCache creates a new mock instance.
+func NewMockReadLimitCache(ctrl *gomock.Controller) *MockReadLimitCache {
+	mock := &MockReadLimitCache{ctrl: ctrl}
+	mock.recorder = &MockReadLimitCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadLimitCache) EXPECT() *MockReadLimitCacheMockRecorder {
+	return m.recorder
+}
+
+// MarkViews mocks base method.
+func (m *MockReadLimitCache) MarkViews(ctx context.Context, biz string, views []domain.ReadView, viewWindow, ipWindow time.Duration) ([]domain.ReadViewResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkViews", ctx, biz, views, viewWindow, ipWindow)
+	ret0, _ := ret[0].([]domain.ReadViewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkViews indicates an expected call of MarkViews.
+func (mr *MockReadLimitCacheMockRecorder) MarkViews(ctx, biz, views, viewWindow, ipWindow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkViews", reflect.TypeOf((*MockReadLimitCache)(nil).MarkViews), ctx, biz, views, viewWindow, ipWindow)
+}
+
+// UnmarkViews mocks base method.
+func (m *MockReadLimitCache) UnmarkViews(ctx context.Context, biz string, views []domain.ReadView, results []domain.ReadViewResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnmarkViews", ctx, biz, views, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnmarkViews indicates an expected call of UnmarkViews.
+func (mr *MockReadLimitCacheMockRecorder) UnmarkViews(ctx, biz, views, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmarkViews", reflect.TypeOf((*MockReadLimitCache)(nil).UnmarkViews), ctx, biz, views, results)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./uv.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./uv.go -package=cachemocks -destination=mocks/uv.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUvCache is a mock of UvCache interface.
+type MockUvCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockUvCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockUvCacheMockRecorder is the mock recorder for MockUvCache.
+type MockUvCacheMockRecorder struct {
+	mock *MockUvCache
+}
+
+// NewMockUvCache creates a new mock instance.
+func NewMockUvCache(ctrl *gomock.Controller) *MockUvCache {
+	mock := &MockUvCache{ctrl: ctrl}
+	mock.recorder = &MockUvCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUvCache) EXPECT() *MockUvCacheMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockUvCache) Add(ctx context.Context, visits []domain.Visit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, visits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockUvCacheMockRecorder) Add(ctx, visits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockUvCache)(nil).Add), ctx, visits)
+}
+
+// ClearDirty mocks base method.
+func (m *MockUvCache) ClearDirty(ctx context.Context, cnts []domain.UvCnt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearDirty", ctx, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearDirty indicates an expected call of ClearDirty.
+func (mr *MockUvCacheMockRecorder) ClearDirty(ctx, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearDirty", reflect.TypeOf((*MockUvCache)(nil).ClearDirty), ctx, cnts)
+}
+
+// GetDirty mocks base method.
+func (m *MockUvCache) GetDirty(ctx context.Context, limit int) ([]domain.UvCnt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirty", ctx, limit)
+	ret0, _ := ret[0].([]domain.UvCnt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirty indicates an expected call of GetDirty.
+func (mr *MockUvCacheMockRecorder) GetDirty(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirty", reflect.TypeOf((*MockUvCache)(nil).GetDirty), ctx, limit)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"red-feed/interactive/domain"
+	"time"
+)
+
+var (
+	//go:embed lua/interactive_unmark_read_ip.lua
+	luaUnmarkReadIp string
+)
+
+//go:generate mockgen -source=./read_limit.go -package=cachemocks -destination=mocks/read_limit.mock.go ReadLimitCache
+type ReadLimitCache interface {
+	// MarkViews 记录一批阅读，viewWindow 内同一个读者重复看同一个资源只有第一次 First 为 true，
+	// ipWindow 内统计同一个 ip 的阅读次数
+	MarkViews(ctx context.Context, biz string, views []domain.ReadView,
+		viewWindow time.Duration, ipWindow time.Duration) ([]domain.ReadViewResult, error)
+	// UnmarkViews 撤销 MarkViews 留下的标记，results 是 MarkViews 返回的结果。
+	// 只删掉这一次设置的重复阅读标记，ip 的阅读次数减回去
+	UnmarkViews(ctx context.Context, biz string, views []domain.ReadView, results []domain.ReadViewResult) error
+}
+
+type RedisReadLimitCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisReadLimitCache(client redis.Cmdable) ReadLimitCache {
+	return &RedisReadLimitCache{
+		client: client,
+	}
+}
+
+func (c *RedisReadLimitCache) MarkViews(ctx context.Context, biz string, views []domain.ReadView,
+	viewWindow time.Duration, ipWindow time.Duration) ([]domain.ReadViewResult, error) {
+	pipe := c.client.Pipeline()
+	firsts := make([]*redis.BoolCmd, len(views))
+	ipCnts := make([]*redis.IntCmd, len(views))
+	for i, v := range views {
+		if v.Reader != "" {
+			firsts[i] = pipe.SetNX(ctx, c.viewKey(biz, v.BizId, v.Reader), 1, viewWindow)
+		}
+		if v.Ip != "" {
+			key := c.ipKey(v.Ip)
+			ipCnts[i] = pipe.Incr(ctx, key)
+			// 固定窗口，第一次阅读的时候开始计时
+			pipe.ExpireNX(ctx, key, ipWindow)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	res := make([]domain.ReadViewResult, len(views))
+	for i := range views {
+		// 识别不了读者的都算第一次
+		res[i].First = firsts[i] == nil || firsts[i].Val()
+		if ipCnts[i] != nil {
+			res[i].IpCnt = ipCnts[i].Val()
+		}
+	}
+	return res, nil
+}
+
+func (c *RedisReadLimitCache) UnmarkViews(ctx context.Context, biz string,
+	views []domain.ReadView, results []domain.ReadViewResult) error {
+	pipe := c.client.Pipeline()
+	for i, v := range views {
+		// 不是第一次的标记是之前的阅读留下的，不能删
+		if v.Reader != "" && results[i].First {
+			pipe.Del(ctx, c.viewKey(biz, v.BizId, v.Reader))
+		}
+		if v.Ip != "" {
+			pipe.Eval(ctx, luaUnmarkReadIp, []string{c.ipKey(v.Ip)})
+		}
+	}
+	if pipe.Len() == 0 {
+		return nil
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisReadLimitCache) viewKey(biz string, bizId int64, reader string) string {
+	return fmt.Sprintf("interactive:read_view:%s:%d:%s", biz, bizId, reader)
+}
+
+func (c *RedisReadLimitCache) ipKey(ip string) string {
+	return fmt.Sprintf("interactive:read_ip:%s", ip)
+}
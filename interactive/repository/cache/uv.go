@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"red-feed/interactive/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	//go:embed lua/interactive_clear_uv_dirty.lua
+	luaClearUvDirty string
+)
+
+const (
+	// keyUvDirty 有新读者的资源，等着把人数写进数据库
+	keyUvDirty   = "interactive:uv:dirty"
+	uvDateLayout = "20060102"
+	// dailyUvExpiration 按天统计的过了第二天就不会再变了，写进数据库之后留着也没用
+	dailyUvExpiration = time.Hour * 48
+)
+
+// UvCache 用 HyperLogLog 统计每个资源有多少人读过，每个资源一个总的，再按天一个。
+// 每个 HyperLogLog 最多占 12KB，误差在 1% 左右
+//
+//go:generate mockgen -source=./uv.go -package=cachemocks -destination=mocks/uv.mock.go UvCache
+type UvCache interface {
+	// Add 记下读者，同时把资源标记成要写数据库
+	Add(ctx context.Context, visits []domain.Visit) error
+	// GetDirty 查最多 limit 个有新读者的资源和它们现在的人数，不会移出标记
+	GetDirty(ctx context.Context, limit int) ([]domain.UvCnt, error)
+	// ClearDirty 写进数据库之后移出标记，期间人数又变了的留着下一次再写
+	ClearDirty(ctx context.Context, cnts []domain.UvCnt) error
+}
+
+type RedisUvCache struct {
+	client redis.Cmdable
+}
+
+func NewRedisUvCache(client redis.Cmdable) UvCache {
+	return &RedisUvCache{
+		client: client,
+	}
+}
+
+func (c *RedisUvCache) Add(ctx context.Context, visits []domain.Visit) error {
+	if len(visits) == 0 {
+		return nil
+	}
+	type key struct {
+		biz   string
+		bizId int64
+		date  string
+	}
+	// 一批里面同一个资源同一天的合并成一次 PFADD
+	visitors := make(map[key][]any, len(visits))
+	for _, v := range visits {
+		k := key{biz: v.Biz, bizId: v.BizId, date: v.Time.Format(uvDateLayout)}
+		visitors[k] = append(visitors[k], v.Visitor)
+	}
+	pipe := c.client.Pipeline()
+	for k, vs := range visitors {
+		pipe.PFAdd(ctx, c.totalKey(k.biz, k.bizId), vs...)
+		dailyKey := c.dailyKey(k.biz, k.bizId, k.date)
+		pipe.PFAdd(ctx, dailyKey, vs...)
+		pipe.Expire(ctx, dailyKey, dailyUvExpiration)
+		pipe.SAdd(ctx, keyUvDirty, c.member(k.biz, k.bizId, k.date))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisUvCache) GetDirty(ctx context.Context, limit int) ([]domain.UvCnt, error) {
+	// 写进数据库之前不能移出去，不然写失败或者进程挂了就丢了
+	members, err := c.client.SRandMemberN(ctx, keyUvDirty, int64(limit)).Result()
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	res := make([]domain.UvCnt, 0, len(members))
+	invalid := make([]any, 0)
+	pipe := c.client.Pipeline()
+	totals := make([]*redis.IntCmd, 0, len(members))
+	dailies := make([]*redis.IntCmd, 0, len(members))
+	for _, member := range members {
+		biz, bizId, date, err := c.parseMember(member)
+		if err != nil {
+			invalid = append(invalid, member)
+			continue
+		}
+		res = append(res, domain.UvCnt{Biz: biz, BizId: bizId, Date: date})
+		totals = append(totals, pipe.PFCount(ctx, c.totalKey(biz, bizId)))
+		dailies = append(dailies, pipe.PFCount(ctx, c.dailyKey(biz, bizId, date)))
+	}
+	if len(invalid) > 0 {
+		// 格式不对的永远写不进去，留着只会每次都查出来
+		pipe.SRem(ctx, keyUvDirty, invalid...)
+	}
+	if _, err = pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	for i := range res {
+		res[i].UvCnt = totals[i].Val()
+		res[i].DailyUvCnt = dailies[i].Val()
+	}
+	return res, nil
+}
+
+func (c *RedisUvCache) ClearDirty(ctx context.Context, cnts []domain.UvCnt) error {
+	if len(cnts) == 0 {
+		return nil
+	}
+	pipe := c.client.Pipeline()
+	for _, cnt := range cnts {
+		pipe.Eval(ctx, luaClearUvDirty,
+			[]string{keyUvDirty, c.totalKey(cnt.Biz, cnt.BizId), c.dailyKey(cnt.Biz, cnt.BizId, cnt.Date)},
+			c.member(cnt.Biz, cnt.BizId, cnt.Date), cnt.UvCnt, cnt.DailyUvCnt)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisUvCache) member(biz string, bizId int64, date string) string {
+	return fmt.Sprintf("%s:%d:%s", biz, bizId, date)
+}
+
+func (c *RedisUvCache) parseMember(member string) (string, int64, string, error) {
+	dateIdx := strings.LastIndexByte(member, ':')
+	if dateIdx < 0 {
+		return "", 0, "", errors.New("UV 的资源格式不对：" + member)
+	}
+	idIdx := strings.LastIndexByte(member[:dateIdx], ':')
+	if idIdx < 0 {
+		return "", 0, "", errors.New("UV 的资源格式不对：" + member)
+	}
+	bizId, err := strconv.ParseInt(member[idIdx+1:dateIdx], 10, 64)
+	if err != nil {
+		return "", 0, "", err
+	}
+	return member[:idIdx], bizId, member[dateIdx+1:], nil
+}
+
+func (c *RedisUvCache) totalKey(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:uv:%s:%d", biz, bizId)
+}
+
+func (c *RedisUvCache) dailyKey(biz string, bizId int64, date string) string {
+	return fmt.Sprintf("interactive:uv:%s:%d:%s", biz, bizId, date)
+}
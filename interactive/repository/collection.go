@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+var ErrCollectionNotFound = dao.ErrDataNotFound
+
+//go:generate mockgen -source=./collection.go -package=repomocks -destination=mocks/collection.mock.go CollectionRepository
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete 删除收藏夹，里面资源的收藏数缓存也跟着减
+	Delete(ctx context.Context, id int64, uid int64) error
+	GetById(ctx context.Context, id int64) (domain.Collection, error)
+	FindDefault(ctx context.Context, uid int64, name string) (domain.Collection, error)
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error)
+	ListItems(ctx context.Context, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+	MoveItems(ctx context.Context, uid int64, from int64, to int64, biz string, bizIds []int64) (int64, error)
+}
+
+// CollectionRepositoryImpl 收藏夹读得不多，不加缓存。
+// cache 是资源的计数缓存，删除收藏夹会改收藏数
+type CollectionRepositoryImpl struct {
+	dao   dao.CollectionDAO
+	cache cache.InteractiveCache
+	l     logger.Logger
+}
+
+func NewCollectionRepository(dao dao.CollectionDAO, cache cache.InteractiveCache, l logger.Logger) CollectionRepository {
+	return &CollectionRepositoryImpl{
+		dao:   dao,
+		cache: cache,
+		l:     l,
+	}
+}
+
+func (r *CollectionRepositoryImpl) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	return r.dao.Insert(ctx, r.toEntity(c))
+}
+
+func (r *CollectionRepositoryImpl) Update(ctx context.Context, c domain.Collection) error {
+	return r.dao.Update(ctx, r.toEntity(c))
+}
+
+func (r *CollectionRepositoryImpl) Delete(ctx context.Context, id int64, uid int64) error {
+	items, err := r.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		cErr := r.cache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if cErr != nil {
+			r.l.Error("DecrCollectCntIfPresent error", logger.Error(cErr),
+				logger.String("biz", item.Biz), logger.Int64("bizId", item.BizId))
+		}
+	}
+	return nil
+}
+
+func (r *CollectionRepositoryImpl) GetById(ctx context.Context, id int64) (domain.Collection, error) {
+	c, err := r.dao.GetById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *CollectionRepositoryImpl) FindDefault(ctx context.Context, uid int64, name string) (domain.Collection, error) {
+	c, err := r.dao.FindDefault(ctx, uid, name)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return r.toDomain(c), nil
+}
+
+func (r *CollectionRepositoryImpl) FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error) {
+	cs, err := r.dao.FindByUid(ctx, uid, onlyPublic)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Collection, domain.Collection](cs, func(idx int, src dao.Collection) domain.Collection {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CollectionRepositoryImpl) ListItems(ctx context.Context, cid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	items, err := r.dao.ListItems(ctx, cid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items,
+		func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+			return domain.CollectionItem{
+				Cid:   src.Cid,
+				Biz:   src.Biz,
+				BizId: src.BizId,
+				Ctime: time.UnixMilli(src.Utime),
+			}
+		}), nil
+}
+
+func (r *CollectionRepositoryImpl) MoveItems(ctx context.Context, uid int64, from int64, to int64,
+	biz string, bizIds []int64) (int64, error) {
+	return r.dao.MoveItems(ctx, uid, from, to, biz, bizIds)
+}
+
+func (r *CollectionRepositoryImpl) toEntity(c domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:          c.Id,
+		Name:        c.Name,
+		Description: c.Description,
+		Uid:         c.Uid,
+		IsDefault:   c.IsDefault,
+		Public:      c.Public,
+	}
+}
+
+func (r *CollectionRepositoryImpl) toDomain(c dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:          c.Id,
+		Uid:         c.Uid,
+		Name:        c.Name,
+		Description: c.Description,
+		Public:      c.Public,
+		IsDefault:   c.IsDefault,
+		Ctime:       time.UnixMilli(c.Ctime),
+		Utime:       time.UnixMilli(c.Utime),
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestCollectionRepositoryImpl_Delete(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.CollectionDAO, cache.InteractiveCache)
+		wantErr error
+	}{
+		{
+			name: "取消掉的收藏，缓存的收藏数也减一",
+			mock: func(ctrl *gomock.Controller) (dao.CollectionDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockCollectionDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().Delete(gomock.Any(), int64(3), int64(2)).Return([]dao.UserCollectionBiz{
+					{Biz: "article", BizId: 10},
+					{Biz: "article", BizId: 11},
+				}, nil)
+				c.EXPECT().DecrCollectCntIfPresent(gomock.Any(), "article", int64(10)).Return(nil)
+				c.EXPECT().DecrCollectCntIfPresent(gomock.Any(), "article", int64(11)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "缓存失败也算成功",
+			mock: func(ctrl *gomock.Controller) (dao.CollectionDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockCollectionDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().Delete(gomock.Any(), int64(3), int64(2)).Return([]dao.UserCollectionBiz{
+					{Biz: "article", BizId: 10},
+					{Biz: "article", BizId: 11},
+				}, nil)
+				c.EXPECT().DecrCollectCntIfPresent(gomock.Any(), "article", int64(10)).
+					Return(errors.New("redis error"))
+				c.EXPECT().DecrCollectCntIfPresent(gomock.Any(), "article", int64(11)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "数据库错误，不动缓存",
+			mock: func(ctrl *gomock.Controller) (dao.CollectionDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockCollectionDAO(ctrl)
+				d.EXPECT().Delete(gomock.Any(), int64(3), int64(2)).Return(nil, dao.ErrDataNotFound)
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewCollectionRepository(d, c, &logger.NopLogger{})
+			err := repo.Delete(context.Background(), 3, 2)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"time"
+)
+
+//go:generate mockgen -source=./collection.go -package=daomocks -destination=mocks/collection.mock.go CollectionDAO
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// Update 只能更新自己的收藏夹，没有更新到返回 ErrDataNotFound
+	Update(ctx context.Context, c Collection) error
+	// Delete 删除收藏夹，里面的收藏一起取消掉，返回取消掉的收藏
+	Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error)
+	GetById(ctx context.Context, id int64) (Collection, error)
+	// FindDefault 找到用户的默认收藏夹，没有就创建一个，并发创建的时候只有一个能创建成功
+	FindDefault(ctx context.Context, uid int64, name string) (Collection, error)
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error)
+	// ListItems 收藏夹里面的收藏，按照收藏时间倒序
+	ListItems(ctx context.Context, cid int64, offset int, limit int) ([]UserCollectionBiz, error)
+	// MoveItems 把收藏从一个收藏夹挪到另一个，返回挪动的数量
+	MoveItems(ctx context.Context, uid int64, from int64, to int64, biz string, bizIds []int64) (int64, error)
+}
+
+type GORMCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GORMCollectionDAO{
+		db: db,
+	}
+}
+
+func (d *GORMCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := d.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (d *GORMCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := d.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? AND uid = ?", c.Id, c.Uid).
+		Updates(map[string]any{
+			"name":        c.Name,
+			"description": c.Description,
+			"public":      c.Public,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrDataNotFound
+	}
+	return nil
+}
+
+func (d *GORMCollectionDAO) Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	now := time.Now().UnixMilli()
+	var items []UserCollectionBiz
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrDataNotFound
+		}
+		err := tx.Where("cid = ? AND status = ?", id, 1).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		err = tx.Model(&UserCollectionBiz{}).
+			Where("cid = ? AND status = ?", id, 1).
+			Updates(map[string]any{
+				"utime":  now,
+				"status": 0,
+			}).Error
+		if err != nil {
+			return err
+		}
+		// 收藏数跟着减
+		for _, item := range items {
+			err = tx.Model(&Interactive{}).
+				Where("biz = ? AND biz_id = ?", item.Biz, item.BizId).
+				Updates(map[string]any{
+					"utime":       now,
+					"collect_cnt": gorm.Expr("collect_cnt - 1"),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (d *GORMCollectionDAO) GetById(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) FindDefault(ctx context.Context, uid int64, name string) (Collection, error) {
+	res, err := d.findDefault(ctx, uid)
+	if err != gorm.ErrRecordNotFound {
+		return res, err
+	}
+	now := time.Now().UnixMilli()
+	res = Collection{
+		Name:       name,
+		Uid:        uid,
+		IsDefault:  true,
+		DefaultUid: sql.NullInt64{Int64: uid, Valid: true},
+		Ctime:      now,
+		Utime:      now,
+	}
+	err = d.db.WithContext(ctx).Create(&res).Error
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok {
+		const uniqueConflictsErrNo uint16 = 1062
+		if mysqlErr.Number == uniqueConflictsErrNo {
+			// 别的请求已经创建好了，用它创建的
+			return d.findDefault(ctx, uid)
+		}
+	}
+	return res, err
+}
+
+func (d *GORMCollectionDAO) findDefault(ctx context.Context, uid int64) (Collection, error) {
+	var res Collection
+	err := d.db.WithContext(ctx).
+		Where("uid = ? AND is_default = ?", uid, true).
+		First(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error) {
+	var res []Collection
+	query := d.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		query = query.Where("public = ?", true)
+	}
+	// 默认收藏夹排在最前面
+	err := query.Order("is_default DESC, id ASC").Find(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) ListItems(ctx context.Context, cid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := d.db.WithContext(ctx).
+		Where("cid = ? AND status = ?", cid, 1).
+		Order("utime DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMCollectionDAO) MoveItems(ctx context.Context, uid int64, from int64, to int64,
+	biz string, bizIds []int64) (int64, error) {
+	res := d.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Where("uid = ? AND cid = ? AND biz = ? AND biz_id IN ? AND status = ?", uid, from, biz, bizIds, 1).
+		Updates(map[string]any{
+			"cid":   to,
+			"utime": time.Now().UnixMilli(),
+		})
+	return res.RowsAffected, res.Error
+}
+
+// Collection 收藏夹
+type Collection struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	Name        string `gorm:"type:varchar(128)"`
+	Description string `gorm:"type:varchar(1024)"`
+	Uid         int64  `gorm:"index:uid_default,priority:1"`
+	// IsDefault 每个用户只有一个默认收藏夹
+	IsDefault bool `gorm:"index:uid_default,priority:2"`
+	// DefaultUid 默认收藏夹才有，等于 Uid，唯一索引保证并发创建的时候只有一个默认收藏夹。
+	// 其它收藏夹是 NULL，不受唯一索引的限制
+	DefaultUid sql.NullInt64 `gorm:"unique"`
+	Public     bool
+	Ctime      int64
+	Utime      int64
+}
@@ -0,0 +1,257 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGORMCollectionDAO_Delete(t *testing.T) {
+	testcases := []struct {
+		name      string
+		mock      func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantItems []UserCollectionBiz
+		wantErr   error
+	}{
+		{
+			name: "删除收藏夹，里面的收藏取消，收藏数减一",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` WHERE id = \\? AND uid = \\?").
+					WithArgs(int64(3), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collection_bizs` WHERE cid = \\? AND status = \\?").
+					WithArgs(int64(3), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id", "uid", "cid", "status"}).
+						AddRow(1, "article", 10, 2, 3, 1).
+						AddRow(2, "article", 11, 2, 3, 1))
+				mock.ExpectExec("UPDATE `user_collection_bizs` SET .* WHERE cid = \\? AND status = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=collect_cnt - 1.*").
+					WithArgs(sqlmock.AnyArg(), "article", int64(10)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=collect_cnt - 1.*").
+					WithArgs(sqlmock.AnyArg(), "article", int64(11)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantItems: []UserCollectionBiz{
+				{Id: 1, Biz: "article", BizId: 10, Uid: 2, Cid: 3, Status: 1},
+				{Id: 2, Biz: "article", BizId: 11, Uid: 2, Cid: 3, Status: 1},
+			},
+		},
+		{
+			name: "空的收藏夹",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collection_bizs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantItems: []UserCollectionBiz{},
+		},
+		{
+			name: "不是自己的收藏夹",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			wantErr: ErrDataNotFound,
+		},
+		{
+			name: "减收藏数失败，整个回滚",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `collections` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_collection_bizs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id"}).
+						AddRow(1, "article", 10))
+				mock.ExpectExec("UPDATE `user_collection_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` .*").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewCollectionDAO(openMockDB(t, mockDB))
+			items, err := d.Delete(context.Background(), 3, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, items)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMCollectionDAO_FindDefault(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "已经有默认收藏夹",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `collections` WHERE uid = \\? AND is_default = \\?").
+					WithArgs(int64(2), true, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "is_default"}).AddRow(5, 2, true))
+				return mockDB, mock
+			},
+			wantId: 5,
+		},
+		{
+			name: "没有就创建一个，带上唯一的默认标记",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `collections` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO `collections` .*").
+					WithArgs("默认收藏夹", "", int64(2), true, int64(2), false, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(6, 1))
+				return mockDB, mock
+			},
+			wantId: 6,
+		},
+		{
+			name: "并发创建冲突了，用别人创建的",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `collections` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO `collections` .*").
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectQuery("SELECT \\* FROM `collections` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "is_default"}).AddRow(7, 2, true))
+				return mockDB, mock
+			},
+			wantId: 7,
+		},
+		{
+			name: "创建失败",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `collections` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("INSERT INTO `collections` .*").
+					WillReturnError(errors.New("db error"))
+				return mockDB, mock
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewCollectionDAO(openMockDB(t, mockDB))
+			c, err := d.FindDefault(context.Background(), 2, "默认收藏夹")
+			assert.Equal(t, tc.wantErr, err)
+			if err == nil {
+				assert.Equal(t, tc.wantId, c.Id)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteCollectInfo(t *testing.T) {
+	testcases := []struct {
+		name        string
+		cid         int64
+		mock        func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantChanged bool
+	}{
+		{
+			name: "指定了收藏夹，只取消这个收藏夹里面的",
+			cid:  3,
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_collection_bizs` SET .* WHERE "+
+					"\\(biz=\\? AND biz_id = \\? AND uid = \\? AND status = \\?\\) AND cid = \\?").
+					WithArgs(0, sqlmock.AnyArg(), "article", int64(1), int64(2), 1, int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=collect_cnt-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantChanged: true,
+		},
+		{
+			name: "没指定收藏夹，不管在哪个收藏夹都取消",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_collection_bizs` SET .* WHERE "+
+					"biz=\\? AND biz_id = \\? AND uid = \\? AND status = \\?$").
+					WithArgs(0, sqlmock.AnyArg(), "article", int64(1), int64(2), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `collect_cnt`=collect_cnt-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantChanged: true,
+		},
+		{
+			name: "没有收藏，收藏数不变",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_collection_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewInteractiveDAO(openMockDB(t, mockDB))
+			changed, err := d.DeleteCollectInfo(context.Background(), "article", 1, 2, tc.cid)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantChanged, changed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -8,5 +8,11 @@ func InitTable(db *gorm.DB) error {
 		&UserLikeBiz{},
 		&Collection{},
 		&UserCollectionBiz{},
+		&InteractiveFlush{},
+		&InteractiveOutbox{},
+		&InteractiveDailyUv{},
+		&InteractiveHourlyStats{},
+		&UserReactionBiz{},
+		&InteractiveReaction{},
 	)
 }
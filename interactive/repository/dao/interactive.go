@@ -2,27 +2,79 @@ package dao
 
 import (
 	"context"
+	"fmt"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"red-feed/interactive/domain"
+	"sort"
 	"time"
 )
 
 var ErrDataNotFound = gorm.ErrRecordNotFound
 
+//go:generate mockgen -source=./interactive.go -package=daomocks -destination=mocks/interactive.mock.go InteractiveDAO
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
+	// BatchIncrInvalidReadCnt 重复阅读和疑似刷量的阅读不算进阅读数，单独记下来给分析用
+	BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
 
+	// 点赞、收藏是幂等的，返回的 bool 是点赞、收藏的状态有没有变化，没有变化的不改计数
 	GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (UserLikeBiz, error)
-	InsertLikeInfo(ctx context.Context, biz string, bizId, uId int64) error
-	DeleteLikeInfo(ctx context.Context, biz string, bizId, uId int64) error
+	InsertLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error)
+	DeleteLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error)
 
-	InsertCollectInfo(ctx context.Context, biz string, bizId int64, uId, cId int64) error
+	InsertCollectInfo(ctx context.Context, biz string, bizId int64, uId, cId int64) (bool, error)
 	GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (UserCollectionBiz, error)
-	DeleteCollectInfo(ctx context.Context, biz string, bizId int64, uId, cId int64) error
+	DeleteCollectInfo(ctx context.Context, biz string, bizId int64, uId, cId int64) (bool, error)
+
+	// 写回模式下只写点赞、收藏记录，计数攒在 Redis 里面，再用 FlushCnt 写回来
+	InsertLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error)
+	DeleteLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error)
+	InsertCollectRelation(ctx context.Context, biz string, bizId int64, uId, cId int64) (bool, error)
+	DeleteCollectRelation(ctx context.Context, biz string, bizId int64, uId, cId int64) (bool, error)
+	// FlushCnt 把攒下来的计数变化加到计数上，同一个 FlushId 只会生效一次，返回这一次有没有生效
+	FlushCnt(ctx context.Context, flushId string, biz string, bizId int64, likeDelta, collectDelta int64) (bool, error)
+	// DeleteFlushBefore 删除 ctime 早于 before 的写回记录，一次最多删 limit 条，返回删了多少条
+	DeleteFlushBefore(ctx context.Context, before int64, limit int) (int64, error)
+
+	// 默认的表情就是点赞，记在 UserLikeBiz 里面，别的表情记在 UserReactionBiz 里面，两个只能有一个
+	GetReaction(ctx context.Context, biz string, bizId, uid int64) (UserReactionBiz, error)
+	// SetReaction 在一个事务里面锁住用户的点赞和表情记录，换成点赞的时候去掉别的表情，
+	// 换成别的表情的时候取消点赞。likeCnt 为 false 的时候不改点赞数，写回模式自己攒着
+	SetReaction(ctx context.Context, biz string, bizId, uid int64, reaction string, likeCnt bool) (ReactionChange, error)
+	// DeleteReaction 点赞和别的表情都取消，likeCnt 同 SetReaction
+	DeleteReaction(ctx context.Context, biz string, bizId, uid int64, likeCnt bool) (ReactionChange, error)
+	// GetReactionCnts 数量是 0 的不在结果里
+	GetReactionCnts(ctx context.Context, biz string, bizId int64) ([]InteractiveReaction, error)
 
 	Get(ctx context.Context, biz string, bizId int64) (Interactive, error)
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error)
+
+	// GetLikedBizIds 返回 bizIds 里面用户点赞了的，一次 IN 查询
+	GetLikedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error)
+	// GetCollectedBizIds 返回 bizIds 里面用户收藏了的，一次 IN 查询
+	GetCollectedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error)
+	// ListRecentLiked 用户最近点赞的记录，按照点赞时间倒序
+	ListRecentLiked(ctx context.Context, biz string, uid int64, limit int) ([]UserLikeBiz, error)
+	// ListLikers 资源最近的点赞，cursorUtime 和 cursorId 是上一页最后一条，都是 0 的时候从头开始
+	ListLikers(ctx context.Context, biz string, bizId int64, cursorUtime, cursorId int64, limit int) ([]UserLikeBiz, error)
+	// ListLikedByUser 用户最近的点赞，翻页方式同 ListLikers
+	ListLikedByUser(ctx context.Context, biz string, uid int64, cursorUtime, cursorId int64, limit int) ([]UserLikeBiz, error)
+
+	// DeleteBiz 删除一个资源的计数和所有的点赞、收藏记录
+	DeleteBiz(ctx context.Context, biz string, bizId int64) error
+
+	// ListInteractives 按照 id 遍历计数，从 id 大于 startId 的开始
+	ListInteractives(ctx context.Context, startId int64, limit int) ([]Interactive, error)
+	// CountLikes 按照点赞记录数一遍点赞数，没有点赞的不在结果里
+	CountLikes(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	// CountCollects 按照收藏记录数一遍收藏数，没有收藏的不在结果里
+	CountCollects(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	// SaveUvCnt 写入 UV 总数和当天的 UV，HyperLogLog 数出来的只会变大，比数据库里面小的不写
+	SaveUvCnt(ctx context.Context, intrs []Interactive, dailies []InteractiveDailyUv) error
+	// RepairCnt 计数还是 oldLikeCnt 和 oldCollectCnt 的时候才修复，中间有人点赞、收藏了就等下一次
+	RepairCnt(ctx context.Context, id int64, oldLikeCnt, oldCollectCnt, likeCnt, collectCnt int64) (bool, error)
 }
 
 type GORMInteractiveDAO struct {
@@ -35,14 +87,144 @@ func NewInteractiveDAO(db *gorm.DB) InteractiveDAO {
 	}
 }
 
+func (d *GORMInteractiveDAO) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&UserLikeBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&UserReactionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&InteractiveReaction{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz = ? AND biz_id = ?", biz, bizId).Delete(&Interactive{}).Error
+	})
+}
+
 func (d *GORMInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]Interactive, error) {
 	var res []Interactive
-	err := d.db.WithContext(ctx).Where("biz = ? AND id IN ?", biz, ids).Find(&res).Error
+	err := d.db.WithContext(ctx).Where("biz = ? AND biz_id IN ?", biz, ids).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetLikedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error) {
+	var res []int64
+	err := d.db.WithContext(ctx).Model(&UserLikeBiz{}).
+		Where("biz = ? AND uid = ? AND biz_id IN ? AND status = ?", biz, uid, bizIds, 1).
+		Pluck("biz_id", &res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetCollectedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error) {
+	var res []int64
+	err := d.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Where("biz = ? AND uid = ? AND biz_id IN ? AND status = ?", biz, uid, bizIds, 1).
+		Pluck("biz_id", &res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) ListRecentLiked(ctx context.Context, biz string, uid int64, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := d.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND status = ?", uid, biz, 1).
+		Order("utime DESC").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) ListLikers(ctx context.Context, biz string, bizId int64,
+	cursorUtime, cursorId int64, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	query := d.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND status = ?", biz, bizId, 1)
+	err := d.page(query, cursorUtime, cursorId, limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) ListLikedByUser(ctx context.Context, biz string, uid int64,
+	cursorUtime, cursorId int64, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	query := d.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND status = ?", uid, biz, 1)
+	err := d.page(query, cursorUtime, cursorId, limit).Find(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) ListInteractives(ctx context.Context, startId int64, limit int) ([]Interactive, error) {
+	var res []Interactive
+	err := d.db.WithContext(ctx).
+		Where("id > ?", startId).
+		Order("id").
+		Limit(limit).
+		Find(&res).Error
 	return res, err
 }
 
+func (d *GORMInteractiveDAO) CountLikes(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	return d.countActive(d.db.WithContext(ctx).Model(&UserLikeBiz{}), biz, bizIds)
+}
+
+func (d *GORMInteractiveDAO) CountCollects(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	return d.countActive(d.db.WithContext(ctx).Model(&UserCollectionBiz{}), biz, bizIds)
+}
+
+// countActive 只数 status = 1 的记录，取消了的不算
+func (d *GORMInteractiveDAO) countActive(query *gorm.DB, biz string, bizIds []int64) (map[int64]int64, error) {
+	var rows []struct {
+		BizId int64
+		Cnt   int64
+	}
+	err := query.Select("biz_id, COUNT(*) AS cnt").
+		Where("biz = ? AND biz_id IN ? AND status = ?", biz, bizIds, 1).
+		Group("biz_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]int64, len(rows))
+	for _, row := range rows {
+		res[row.BizId] = row.Cnt
+	}
+	return res, nil
+}
+
+func (d *GORMInteractiveDAO) RepairCnt(ctx context.Context, id int64,
+	oldLikeCnt, oldCollectCnt, likeCnt, collectCnt int64) (bool, error) {
+	res := d.db.WithContext(ctx).Model(&Interactive{}).
+		Where("id = ? AND like_cnt = ? AND collect_cnt = ?", id, oldLikeCnt, oldCollectCnt).
+		Updates(map[string]any{
+			"like_cnt":    likeCnt,
+			"collect_cnt": collectCnt,
+			"utime":       time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+// page 按照 utime、id 倒序翻页，不用 offset，翻得再深也能走索引
+func (d *GORMInteractiveDAO) page(query *gorm.DB, cursorUtime, cursorId int64, limit int) *gorm.DB {
+	if cursorUtime > 0 || cursorId > 0 {
+		query = query.Where("utime < ? OR (utime = ? AND id < ?)", cursorUtime, cursorUtime, cursorId)
+	}
+	return query.Order("utime DESC, id DESC").Limit(limit)
+}
+
+// Get 还没有人读过、点赞、收藏过的没有记录，计数都是 0
 func (d *GORMInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (Interactive, error) {
-	return Interactive{}, nil
+	var res Interactive
+	err := d.db.WithContext(ctx).Where("biz = ? AND biz_id = ?", biz, bizId).First(&res).Error
+	if err == ErrDataNotFound {
+		return Interactive{Biz: biz, BizId: bizId}, nil
+	}
+	return res, err
 }
 
 func (d *GORMInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (UserLikeBiz, error) {
@@ -57,21 +239,15 @@ func (d *GORMInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string,
 	return res, err
 }
 
-func (d *GORMInteractiveDAO) DeleteCollectInfo(ctx context.Context, biz string, bizId int64, uId int64, cId int64) error {
-	now := time.Now().UnixMilli()
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+func (d *GORMInteractiveDAO) DeleteCollectInfo(ctx context.Context, biz string, bizId int64, uId int64, cId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventUncollected, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
 		// 删除收藏记录
-		err := tx.Model(&UserCollectionBiz{}).
-			Where("biz=? AND biz_id = ? AND uid = ? AND cid = ?", biz, bizId, uId, cId).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": 0,
-			}).Error
-		if err != nil {
-			return err
+		changed, err := d.deleteCollectRelation(tx, biz, bizId, uId, cId, now)
+		if err != nil || !changed {
+			return changed, err
 		}
 		// 减收藏数量
-		return tx.Model(&Interactive{}).
+		return true, tx.Model(&Interactive{}).
 			Where("biz=? AND biz_id = ?", biz, bizId).
 			Updates(map[string]any{
 				"utime":       now,
@@ -80,14 +256,37 @@ func (d *GORMInteractiveDAO) DeleteCollectInfo(ctx context.Context, biz string,
 	})
 }
 
-func (d *GORMInteractiveDAO) InsertCollectInfo(ctx context.Context, biz string, bizId int64, uId int64, cId int64) error {
-	now := time.Now().UnixMilli()
-	return d.db.Transaction(func(tx *gorm.DB) error {
-		// 开启事务，同时操作 Interactive表 和 UserCollectBiz 表
-		err := tx.WithContext(ctx).Clauses(clause.OnConflict{
+func (d *GORMInteractiveDAO) DeleteCollectRelation(ctx context.Context, biz string, bizId int64, uId int64, cId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventUncollected, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		return d.deleteCollectRelation(tx, biz, bizId, uId, cId, now)
+	})
+}
+
+// deleteCollectRelation 只有收藏着的才算取消了收藏，cId 是 0 的时候不管在哪个收藏夹里面都取消
+func (d *GORMInteractiveDAO) deleteCollectRelation(tx *gorm.DB, biz string, bizId int64, uId int64, cId int64, now int64) (bool, error) {
+	query := tx.Model(&UserCollectionBiz{}).
+		Where("biz=? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uId, 1)
+	if cId > 0 {
+		query = query.Where("cid = ?", cId)
+	}
+	res := query.Updates(map[string]any{
+		"utime":  now,
+		"status": 0,
+	})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (d *GORMInteractiveDAO) InsertCollectInfo(ctx context.Context, biz string, bizId int64, uId int64, cId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventCollected, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		// 同时操作 Interactive表 和 UserCollectBiz 表
+		changed, err := d.insertCollectRelation(tx, biz, bizId, uId, cId, now)
+		if err != nil || !changed {
+			return changed, err
+		}
+		return true, tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]any{
 				"collect_cnt": gorm.Expr("collect_cnt + 1"),
-				"utime":       time.Now().UnixMilli(),
+				"utime":       now,
 			}),
 		}).Create(&Interactive{
 			Biz:        biz,
@@ -96,85 +295,201 @@ func (d *GORMInteractiveDAO) InsertCollectInfo(ctx context.Context, biz string,
 			Ctime:      now,
 			Utime:      now,
 		}).Error
-		if err != nil {
-			return err
+	})
+}
+
+func (d *GORMInteractiveDAO) InsertCollectRelation(ctx context.Context, biz string, bizId int64, uId int64, cId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventCollected, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		return d.insertCollectRelation(tx, biz, bizId, uId, cId, now)
+	})
+}
+
+// insertCollectRelation 返回是不是从没有收藏变成了收藏
+func (d *GORMInteractiveDAO) insertCollectRelation(tx *gorm.DB, biz string, bizId int64, uId int64, cId int64, now int64) (bool, error) {
+	// 以前取消过收藏的，恢复回来
+	res := tx.Model(&UserCollectionBiz{}).
+		Where("biz=? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uId, 0).
+		Updates(map[string]any{
+			"cid":    cId,
+			"utime":  now,
+			"status": 1,
+		})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.RowsAffected > 0, res.Error
+	}
+	// 同一个资源只能在一个收藏夹里面，再收藏一次就是换个收藏夹。
+	// MySQL 插入的时候影响行数是 1，更新是 2 或者 0
+	res = tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"cid":   cId,
+			"utime": now,
+		}),
+	}).Create(&UserCollectionBiz{
+		Biz:    biz,
+		BizId:  bizId,
+		Uid:    uId,
+		Cid:    cId,
+		Status: 1,
+		Ctime:  now,
+		Utime:  now,
+	})
+	return res.RowsAffected == 1, res.Error
+}
+
+func (d *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventLiked, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		// 同时操作 Interactive表 和 UserLikeBiz 表
+		changed, err := d.insertLikeRelation(tx, biz, bizId, uId, now)
+		if err != nil || !changed {
+			return changed, err
 		}
-		return tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"utime":  now,
-				"status": 1,
-			}),
-		}).Create(&UserCollectionBiz{
-			Biz:    biz,
-			BizId:  bizId,
-			Uid:    uId,
-			Cid:    cId,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
+		return true, d.incrLikeCnt(tx, biz, bizId, now)
+	})
+}
+
+func (d *GORMInteractiveDAO) incrLikeCnt(tx *gorm.DB, biz string, bizId int64, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"like_cnt": gorm.Expr("like_cnt + 1"),
+			"utime":    now,
+		}),
+	}).Create(&Interactive{
+		Biz:     biz,
+		BizId:   bizId,
+		LikeCnt: 1,
+		Ctime:   now,
+		Utime:   now,
+	}).Error
+}
+
+func (d *GORMInteractiveDAO) InsertLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventLiked, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		return d.insertLikeRelation(tx, biz, bizId, uId, now)
+	})
+}
+
+// insertLikeRelation 返回是不是从没有点赞变成了点赞，已经点赞了再点一次不算
+func (d *GORMInteractiveDAO) insertLikeRelation(tx *gorm.DB, biz string, bizId, uId int64, now int64) (bool, error) {
+	// 以前取消过点赞的，恢复回来
+	res := tx.Model(&UserLikeBiz{}).
+		Where("biz=? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uId, 0).
+		Updates(map[string]any{
+			"utime":  now,
+			"status": 1,
+		})
+	if res.Error != nil || res.RowsAffected > 0 {
+		return res.RowsAffected > 0, res.Error
+	}
+	// 没有点赞过的插入，已经点赞了的什么都不做
+	res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserLikeBiz{
+		Biz:    biz,
+		BizId:  bizId,
+		Uid:    uId,
+		Status: 1,
+		Ctime:  now,
+		Utime:  now,
+	})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (d *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventUnliked, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		// 两个操作
+		// 一个是软删除点赞记录
+		// 一个是减点赞数量，没有点赞的不减
+		changed, err := d.deleteLikeRelation(tx, biz, bizId, uId, now)
+		if err != nil || !changed {
+			return changed, err
+		}
+		return true, d.decrLikeCnt(tx, biz, bizId, now)
+	})
+}
+
+func (d *GORMInteractiveDAO) decrLikeCnt(tx *gorm.DB, biz string, bizId int64, now int64) error {
+	return tx.Model(&Interactive{}).
+		// 这边命中了索引，然后没找到，所以不会加锁
+		Where("biz=? AND biz_id = ?", biz, bizId).
+		Updates(map[string]any{
+			"utime":    now,
+			"like_cnt": gorm.Expr("like_cnt-1"),
 		}).Error
+}
+
+func (d *GORMInteractiveDAO) DeleteLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	return d.changeRelation(ctx, domain.EventUnliked, biz, bizId, uId, func(tx *gorm.DB, now int64) (bool, error) {
+		return d.deleteLikeRelation(tx, biz, bizId, uId, now)
 	})
 }
 
-func (d *GORMInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uId int64) error {
+// changeRelation 在一个事务里面修改点赞、收藏记录，状态真的变了的话再把事件写进 outbox 表，
+// 事务提交了事件就一定会发出去
+func (d *GORMInteractiveDAO) changeRelation(ctx context.Context, typ domain.InteractiveEventType,
+	biz string, bizId, uId int64, fn func(tx *gorm.DB, now int64) (bool, error)) (bool, error) {
 	now := time.Now().UnixMilli()
-	return d.db.Transaction(func(tx *gorm.DB) error {
-		// 开启事务，同时操作 Interactive表 和 UserLikeBiz 表
-		err := tx.WithContext(ctx).Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]any{
-				"like_cnt": gorm.Expr("like_cnt + 1"),
-				"utime":    time.Now().UnixMilli(),
-			}),
-		}).Create(&Interactive{
+	changed := false
+	// 控制事务超时
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = fn(tx, now)
+		if err != nil || !changed {
+			return err
+		}
+		return insertOutbox(tx, typ, biz, bizId, uId, now)
+	})
+	return changed && err == nil, err
+}
+
+// deleteLikeRelation 只有点赞着的才算取消了点赞
+func (d *GORMInteractiveDAO) deleteLikeRelation(tx *gorm.DB, biz string, bizId, uId int64, now int64) (bool, error) {
+	res := tx.Model(&UserLikeBiz{}).
+		Where("biz=? AND biz_id = ? AND uid = ? AND status = ?", biz, bizId, uId, 1).
+		Updates(map[string]any{
+			"utime":  now,
+			"status": 0,
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (d *GORMInteractiveDAO) FlushCnt(ctx context.Context, flushId string, biz string, bizId int64,
+	likeDelta, collectDelta int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	applied := false
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先占住 flush id，已经有了说明上一次写回成功了，只是没来得及清理 Redis
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&InteractiveFlush{
+			FlushId: flushId,
 			Biz:     biz,
 			BizId:   bizId,
-			ReadCnt: 1,
 			Ctime:   now,
-			Utime:   now,
-		}).Error
-		if err != nil {
-			return err
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
 		}
+		applied = true
 		return tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]any{
-				"utime":  now,
-				"status": 1,
+				"like_cnt":    gorm.Expr("like_cnt + ?", likeDelta),
+				"collect_cnt": gorm.Expr("collect_cnt + ?", collectDelta),
+				"utime":       now,
 			}),
-		}).Create(&UserLikeBiz{
-			Biz:    biz,
-			BizId:  bizId,
-			Uid:    uId,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
+		}).Create(&Interactive{
+			Biz:        biz,
+			BizId:      bizId,
+			LikeCnt:    likeDelta,
+			CollectCnt: collectDelta,
+			Ctime:      now,
+			Utime:      now,
 		}).Error
 	})
+	return applied && err == nil, err
 }
 
-func (d *GORMInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uId int64) error {
-	now := time.Now().UnixMilli()
-	// 控制事务超时
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 两个操作
-		// 一个是软删除点赞记录
-		// 一个是减点赞数量
-		err := tx.Model(&UserLikeBiz{}).
-			Where("biz=? AND biz_id = ? AND uid = ?", biz, bizId, uId).
-			Updates(map[string]any{
-				"utime":  now,
-				"status": 0,
-			}).Error
-		if err != nil {
-			return err
-		}
-		return tx.Model(&Interactive{}).
-			// 这边命中了索引，然后没找到，所以不会加锁
-			Where("biz=? AND biz_id = ?", biz, bizId).
-			Updates(map[string]any{
-				"utime":    now,
-				"like_cnt": gorm.Expr("like_cnt-1"),
-			}).Error
-	})
+func (d *GORMInteractiveDAO) DeleteFlushBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("ctime < ?", before).
+		Limit(limit).
+		Delete(&InteractiveFlush{})
+	return res.RowsAffected, res.Error
 }
 
 func (d *GORMInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
@@ -200,38 +515,129 @@ func (d *GORMInteractiveDAO) incrReadCnt(tx *gorm.DB, biz string, bizId int64) e
 }
 
 func (d *GORMInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	return d.batchIncr(ctx, "read_cnt", bizs, bizIds, func(intr *Interactive, delta int64) {
+		intr.ReadCnt = delta
+	})
+}
+
+func (d *GORMInteractiveDAO) BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	return d.batchIncr(ctx, "invalid_read_cnt", bizs, bizIds, func(intr *Interactive, delta int64) {
+		intr.InvalidReadCnt = delta
+	})
+}
+
+// batchIncr 同一个资源先合并成一个增量，再用一条 INSERT ... ON DUPLICATE KEY UPDATE 写进去。
+// 热门帖子一批里面出现很多次，逐条更新会反复争抢同一行的锁
+func (d *GORMInteractiveDAO) batchIncr(ctx context.Context, column string, bizs []string, bizIds []int64,
+	set func(intr *Interactive, delta int64)) error {
+	type key struct {
+		biz   string
+		bizId int64
+	}
+	// 让调用者保证两者是相等的
+	deltas := make(map[key]int64, len(bizs))
+	for i := 0; i < len(bizs); i++ {
+		deltas[key{biz: bizs[i], bizId: bizIds[i]}]++
+	}
+	if len(deltas) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	rows := make([]Interactive, 0, len(deltas))
+	for k, delta := range deltas {
+		row := Interactive{
+			Biz:   k.biz,
+			BizId: k.bizId,
+			Ctime: now,
+			Utime: now,
+		}
+		set(&row, delta)
+		rows = append(rows, row)
+	}
+	// 按照唯一索引的顺序加锁，几个消费者同时写的时候不会互相死锁
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].BizId != rows[j].BizId {
+			return rows[i].BizId < rows[j].BizId
+		}
+		return rows[i].Biz < rows[j].Biz
+	})
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			column:  gorm.Expr(fmt.Sprintf("%s + VALUES(%s)", column, column)),
+			"utime": now,
+		}),
+	}).Create(&rows).Error
+}
+
+func (d *GORMInteractiveDAO) SaveUvCnt(ctx context.Context, intrs []Interactive, dailies []InteractiveDailyUv) error {
+	if len(intrs) == 0 && len(dailies) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	// 按照唯一索引的顺序加锁，和 batchIncr 一样
+	sort.Slice(intrs, func(i, j int) bool {
+		if intrs[i].BizId != intrs[j].BizId {
+			return intrs[i].BizId < intrs[j].BizId
+		}
+		return intrs[i].Biz < intrs[j].Biz
+	})
+	for i := range intrs {
+		intrs[i].Ctime = now
+		intrs[i].Utime = now
+	}
+	for i := range dailies {
+		dailies[i].Ctime = now
+		dailies[i].Utime = now
+	}
 	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 让调用者保证两者是相等的
-		for i := 0; i < len(bizs); i++ {
-			err := d.incrReadCnt(tx, bizs[i], bizIds[i])
+		if len(intrs) > 0 {
+			err := tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]any{
+					"uv_cnt": gorm.Expr("GREATEST(uv_cnt, VALUES(uv_cnt))"),
+					"utime":  now,
+				}),
+			}).Create(&intrs).Error
 			if err != nil {
 				return err
 			}
 		}
-		return nil
+		if len(dailies) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"uv_cnt": gorm.Expr("GREATEST(uv_cnt, VALUES(uv_cnt))"),
+				"utime":  now,
+			}),
+		}).Create(&dailies).Error
 	})
 }
 
 // Interactive 互动信息表
 type Interactive struct {
-	Id         int64  `gorm:"primaryKey,autoIncrement"`
-	BizId      int64  `gorm:"uniqueIndex:biz_id_type"`
-	Biz        string `gorm:"uniqueIndex:biz_id_type;type:varchar(128)"`
-	ReadCnt    int64
-	LikeCnt    int64
-	CollectCnt int64
-	Ctime      int64
-	Utime      int64
+	Id      int64  `gorm:"primaryKey,autoIncrement"`
+	BizId   int64  `gorm:"uniqueIndex:biz_id_type"`
+	Biz     string `gorm:"uniqueIndex:biz_id_type;type:varchar(128)"`
+	ReadCnt int64
+	// InvalidReadCnt 没有算进 ReadCnt 的阅读
+	InvalidReadCnt int64
+	LikeCnt        int64
+	CollectCnt     int64
+	// UvCnt 读过的人数，定时从 Redis 的 HyperLogLog 写过来
+	UvCnt int64
+	Ctime int64
+	Utime int64
 }
 
 // UserLikeBiz 用户点赞
 type UserLikeBiz struct {
-	Id     int64  `gorm:"primaryKey,autoIncrement"`
-	Biz    string `gorm:"uniqueIndex:uid_biz_id_type;type:varchar(128)"`
-	BizId  int64  `gorm:"uniqueIndex:uid_biz_id_type"`
-	Uid    int64  `gorm:"uniqueIndex:uid_biz_id_type"`
-	Ctime  int64
-	Utime  int64
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Biz   string `gorm:"uniqueIndex:uid_biz_id_type;type:varchar(128);index:uid_biz_utime,priority:2;index:biz_id_utime,priority:1"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_id_type;index:biz_id_utime,priority:2"`
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_id_type;index:uid_biz_utime,priority:1"`
+	Ctime int64
+	// Utime 用户最近点赞的列表和资源的点赞列表都按照它排序
+	Utime  int64 `gorm:"index:uid_biz_utime,priority:3;index:biz_id_utime,priority:3"`
 	Status uint8 // 1 点赞 0 取消点赞
 }
 
@@ -247,11 +653,24 @@ type UserCollectionBiz struct {
 	Status uint8 // 1 收藏 0 取消收藏
 }
 
-// Collection 收藏夹
-type Collection struct {
+// InteractiveFlush 写回模式下写回过的计数变化，保证同一次写回重试的时候不会重复加。
+// 只是用来去重的，可以定期清理掉比较早的
+type InteractiveFlush struct {
+	Id      int64  `gorm:"primaryKey,autoIncrement"`
+	FlushId string `gorm:"type:varchar(64);uniqueIndex"`
+	Biz     string `gorm:"type:varchar(128)"`
+	BizId   int64
+	Ctime   int64 `gorm:"index"`
+}
+
+// InteractiveDailyUv 每个资源每天有多少人读过
+type InteractiveDailyUv struct {
 	Id    int64  `gorm:"primaryKey,autoIncrement"`
-	Name  string `gorm:"type=varchar(1024)"`
-	Uid   int64  `gorm:""`
+	BizId int64  `gorm:"uniqueIndex:biz_id_type_date"`
+	Biz   string `gorm:"uniqueIndex:biz_id_type_date;type:varchar(128)"`
+	// Date 格式是 20060102
+	Date  string `gorm:"uniqueIndex:biz_id_type_date;type:varchar(8)"`
+	UvCnt int64
 	Ctime int64
 	Utime int64
 }
@@ -0,0 +1,229 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMySQL "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMInteractiveDAO_BatchIncrReadCnt(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		bizs    []string
+		bizIds  []int64
+		wantErr error
+	}{
+		{
+			name: "同一个资源合并成一行，一条语句写完",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				// 两个资源，只有两行 VALUES
+				mock.ExpectExec("INSERT INTO `interactives` .* VALUES \\(.*\\),\\(.*\\) " +
+					"ON DUPLICATE KEY UPDATE `read_cnt`=read_cnt \\+ VALUES\\(read_cnt\\)").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				return mockDB, mock
+			},
+			bizs:   []string{"article", "article", "article"},
+			bizIds: []int64{2, 1, 2},
+		},
+		{
+			name: "没有数据不用写",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				return mockDB, mock
+			},
+		},
+		{
+			name: "数据库错误",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("INSERT INTO `interactives` .*").
+					WillReturnError(errors.New("db error"))
+				return mockDB, mock
+			},
+			bizs:    []string{"article"},
+			bizIds:  []int64{1},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewInteractiveDAO(openMockDB(t, mockDB))
+			err := d.BatchIncrReadCnt(context.Background(), tc.bizs, tc.bizIds)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_InsertLikeInfo(t *testing.T) {
+	testcases := []struct {
+		name        string
+		mock        func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantChanged bool
+		wantErr     error
+	}{
+		{
+			name: "第一次点赞，点赞数加一",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .* ON DUPLICATE KEY UPDATE `id`=`id`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE `like_cnt`=like_cnt \\+ 1").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				// 事件和点赞记录在同一个事务里面
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WithArgs(sqlmock.AnyArg(), "liked", "article", int64(1), int64(2), sqlmock.AnyArg(), "", int64(0)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantChanged: true,
+		},
+		{
+			name: "取消过点赞的，恢复之后点赞数加一",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .*").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantChanged: true,
+		},
+		{
+			name: "已经点赞了，点赞数不变，也不发事件",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				// 没有变化，不发事件
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+		},
+		{
+			name: "数据库错误",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewInteractiveDAO(openMockDB(t, mockDB))
+			changed, err := d.InsertLikeInfo(context.Background(), "article", 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantChanged, changed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMInteractiveDAO_DeleteLikeInfo(t *testing.T) {
+	testcases := []struct {
+		name        string
+		mock        func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantChanged bool
+	}{
+		{
+			name: "取消点赞，点赞数减一",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET `like_cnt`=like_cnt-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WithArgs(sqlmock.AnyArg(), "unliked", "article", int64(1), int64(2), sqlmock.AnyArg(), "", int64(0)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			wantChanged: true,
+		},
+		{
+			name: "已经取消了，点赞数不变，也不发事件",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewInteractiveDAO(openMockDB(t, mockDB))
+			changed, err := d.DeleteLikeInfo(context.Background(), "article", 1, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantChanged, changed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func openMockDB(t *testing.T, mockDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(gormMySQL.New(gormMySQL.Config{
+		Conn:                      mockDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestGORMInteractiveDAO_DeleteFlushBefore(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 一次只删一批，不会一条语句锁住整张表
+	mock.ExpectExec("DELETE FROM `interactive_flushes` WHERE ctime < \\? LIMIT \\?").
+		WithArgs(int64(1000), 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	d := NewInteractiveDAO(openMockDB(t, mockDB))
+	cnt, err := d.DeleteFlushBefore(context.Background(), 1000, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cnt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,161 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./collection.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./collection.go -package=daomocks -destination=mocks/collection.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	dao "red-feed/interactive/repository/dao"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollectionDAO is a mock of CollectionDAO interface.
+type MockCollectionDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockCollectionDAOMockRecorder is the mock recorder for MockCollectionDAO.
+type MockCollectionDAOMockRecorder struct {
+	mock *MockCollectionDAO
+}
+
+// NewMockCollectionDAO creates a new mock instance.
+func NewMockCollectionDAO(ctrl *gomock.Controller) *MockCollectionDAO {
+	mock := &MockCollectionDAO{ctrl: ctrl}
+	mock.recorder = &MockCollectionDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionDAO) EXPECT() *MockCollectionDAOMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCollectionDAO) Delete(ctx context.Context, id, uid int64) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, uid)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCollectionDAOMockRecorder) Delete(ctx, id, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCollectionDAO)(nil).Delete), ctx, id, uid)
+}
+
+// FindByUid mocks base method.
+func (m *MockCollectionDAO) FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]dao.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUid", ctx, uid, onlyPublic)
+	ret0, _ := ret[0].([]dao.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUid indicates an expected call of FindByUid.
+func (mr *MockCollectionDAOMockRecorder) FindByUid(ctx, uid, onlyPublic any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUid", reflect.TypeOf((*MockCollectionDAO)(nil).FindByUid), ctx, uid, onlyPublic)
+}
+
+// FindDefault mocks base method.
+func (m *MockCollectionDAO) FindDefault(ctx context.Context, uid int64, name string) (dao.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDefault", ctx, uid, name)
+	ret0, _ := ret[0].(dao.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDefault indicates an expected call of FindDefault.
+func (mr *MockCollectionDAOMockRecorder) FindDefault(ctx, uid, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDefault", reflect.TypeOf((*MockCollectionDAO)(nil).FindDefault), ctx, uid, name)
+}
+
+// GetById mocks base method.
+func (m *MockCollectionDAO) GetById(ctx context.Context, id int64) (dao.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", ctx, id)
+	ret0, _ := ret[0].(dao.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockCollectionDAOMockRecorder) GetById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockCollectionDAO)(nil).GetById), ctx, id)
+}
+
+// Insert mocks base method.
+func (m *MockCollectionDAO) Insert(ctx context.Context, c dao.Collection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCollectionDAOMockRecorder) Insert(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCollectionDAO)(nil).Insert), ctx, c)
+}
+
+// ListItems mocks base method.
+func (m *MockCollectionDAO) ListItems(ctx context.Context, cid int64, offset, limit int) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, cid, offset, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockCollectionDAOMockRecorder) ListItems(ctx, cid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockCollectionDAO)(nil).ListItems), ctx, cid, offset, limit)
+}
+
+// MoveItems mocks base method.
+func (m *MockCollectionDAO) MoveItems(ctx context.Context, uid, from, to int64, biz string, bizIds []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItems", ctx, uid, from, to, biz, bizIds)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItems indicates an expected call of MoveItems.
+func (mr *MockCollectionDAOMockRecorder) MoveItems(ctx, uid, from, to, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItems", reflect.TypeOf((*MockCollectionDAO)(nil).MoveItems), ctx, uid, from, to, biz, bizIds)
+}
+
+// Update mocks base method.
+func (m *MockCollectionDAO) Update(ctx context.Context, c dao.Collection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCollectionDAOMockRecorder) Update(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCollectionDAO)(nil).Update), ctx, c)
+}
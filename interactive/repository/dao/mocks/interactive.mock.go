@@ -0,0 +1,517 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive.go -package=daomocks -destination=mocks/interactive.mock.go
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	dao "red-feed/interactive/repository/dao"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveDAO is a mock of InteractiveDAO interface.
+type MockInteractiveDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractiveDAOMockRecorder is the mock recorder for MockInteractiveDAO.
+type MockInteractiveDAOMockRecorder struct {
+	mock *MockInteractiveDAO
+}
+
+// NewMockInteractiveDAO creates a new mock instance.
+func NewMockInteractiveDAO(ctrl *gomock.Controller) *MockInteractiveDAO {
+	mock := &MockInteractiveDAO{ctrl: ctrl}
+	mock.recorder = &MockInteractiveDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrInvalidReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrInvalidReadCnt", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrInvalidReadCnt indicates an expected call of BatchIncrInvalidReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchIncrInvalidReadCnt(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrInvalidReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrInvalidReadCnt), ctx, bizs, bizIds)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, bizs, bizIds)
+}
+
+// CountCollects mocks base method.
+func (m *MockInteractiveDAO) CountCollects(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCollects", ctx, biz, bizIds)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCollects indicates an expected call of CountCollects.
+func (mr *MockInteractiveDAOMockRecorder) CountCollects(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCollects", reflect.TypeOf((*MockInteractiveDAO)(nil).CountCollects), ctx, biz, bizIds)
+}
+
+// CountLikes mocks base method.
+func (m *MockInteractiveDAO) CountLikes(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLikes", ctx, biz, bizIds)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLikes indicates an expected call of CountLikes.
+func (mr *MockInteractiveDAOMockRecorder) CountLikes(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLikes", reflect.TypeOf((*MockInteractiveDAO)(nil).CountLikes), ctx, biz, bizIds)
+}
+
+// DeleteBiz mocks base method.
+func (m *MockInteractiveDAO) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBiz", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBiz indicates an expected call of DeleteBiz.
+func (mr *MockInteractiveDAOMockRecorder) DeleteBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBiz", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteBiz), ctx, biz, bizId)
+}
+
+// DeleteCollectInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectInfo(ctx context.Context, biz string, bizId, uId, cId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectInfo", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectInfo indicates an expected call of DeleteCollectInfo.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectInfo(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectInfo), ctx, biz, bizId, uId, cId)
+}
+
+// DeleteCollectRelation mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectRelation(ctx context.Context, biz string, bizId, uId, cId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectRelation", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectRelation indicates an expected call of DeleteCollectRelation.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectRelation(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectRelation", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectRelation), ctx, biz, bizId, uId, cId)
+}
+
+// DeleteFlushBefore mocks base method.
+func (m *MockInteractiveDAO) DeleteFlushBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlushBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFlushBefore indicates an expected call of DeleteFlushBefore.
+func (mr *MockInteractiveDAOMockRecorder) DeleteFlushBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlushBefore", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteFlushBefore), ctx, before, limit)
+}
+
+// DeleteLikeInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeInfo", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLikeInfo indicates an expected call of DeleteLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) DeleteLikeInfo(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeInfo), ctx, biz, bizId, uId)
+}
+
+// DeleteLikeRelation mocks base method.
+func (m *MockInteractiveDAO) DeleteLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLikeRelation", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLikeRelation indicates an expected call of DeleteLikeRelation.
+func (mr *MockInteractiveDAOMockRecorder) DeleteLikeRelation(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLikeRelation", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteLikeRelation), ctx, biz, bizId, uId)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64, likeCnt bool) (dao.ReactionChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", ctx, biz, bizId, uid, likeCnt)
+	ret0, _ := ret[0].(dao.ReactionChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockInteractiveDAOMockRecorder) DeleteReaction(ctx, biz, bizId, uid, likeCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteReaction), ctx, biz, bizId, uid, likeCnt)
+}
+
+// FlushCnt mocks base method.
+func (m *MockInteractiveDAO) FlushCnt(ctx context.Context, flushId, biz string, bizId, likeDelta, collectDelta int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushCnt", ctx, flushId, biz, bizId, likeDelta, collectDelta)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushCnt indicates an expected call of FlushCnt.
+func (mr *MockInteractiveDAOMockRecorder) FlushCnt(ctx, flushId, biz, bizId, likeDelta, collectDelta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).FlushCnt), ctx, flushId, biz, bizId, likeDelta, collectDelta)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveDAO) Get(ctx context.Context, biz string, bizId int64) (dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveDAOMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveDAO)(nil).Get), ctx, biz, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveDAO) GetByIds(ctx context.Context, biz string, ids []int64) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveDAOMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetCollectedBizIds mocks base method.
+func (m *MockInteractiveDAO) GetCollectedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectedBizIds", ctx, biz, uid, bizIds)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectedBizIds indicates an expected call of GetCollectedBizIds.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectedBizIds(ctx, biz, uid, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectedBizIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectedBizIds), ctx, biz, uid, bizIds)
+}
+
+// GetCollectionInfo mocks base method.
+func (m *MockInteractiveDAO) GetCollectionInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectionInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectionInfo indicates an expected call of GetCollectionInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectionInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectionInfo), ctx, biz, bizId, uid)
+}
+
+// GetLikeInfo mocks base method.
+func (m *MockInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfo indicates an expected call of GetLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, bizId, uid)
+}
+
+// GetLikedBizIds mocks base method.
+func (m *MockInteractiveDAO) GetLikedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikedBizIds", ctx, biz, uid, bizIds)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikedBizIds indicates an expected call of GetLikedBizIds.
+func (mr *MockInteractiveDAOMockRecorder) GetLikedBizIds(ctx, biz, uid, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikedBizIds", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikedBizIds), ctx, biz, uid, bizIds)
+}
+
+// GetReaction mocks base method.
+func (m *MockInteractiveDAO) GetReaction(ctx context.Context, biz string, bizId, uid int64) (dao.UserReactionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReaction", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserReactionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReaction indicates an expected call of GetReaction.
+func (mr *MockInteractiveDAOMockRecorder) GetReaction(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReaction), ctx, biz, bizId, uid)
+}
+
+// GetReactionCnts mocks base method.
+func (m *MockInteractiveDAO) GetReactionCnts(ctx context.Context, biz string, bizId int64) ([]dao.InteractiveReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionCnts", ctx, biz, bizId)
+	ret0, _ := ret[0].([]dao.InteractiveReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionCnts indicates an expected call of GetReactionCnts.
+func (mr *MockInteractiveDAOMockRecorder) GetReactionCnts(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReactionCnts), ctx, biz, bizId)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrReadCnt), ctx, biz, bizId)
+}
+
+// InsertCollectInfo mocks base method.
+func (m *MockInteractiveDAO) InsertCollectInfo(ctx context.Context, biz string, bizId, uId, cId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectInfo", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCollectInfo indicates an expected call of InsertCollectInfo.
+func (mr *MockInteractiveDAOMockRecorder) InsertCollectInfo(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertCollectInfo), ctx, biz, bizId, uId, cId)
+}
+
+// InsertCollectRelation mocks base method.
+func (m *MockInteractiveDAO) InsertCollectRelation(ctx context.Context, biz string, bizId, uId, cId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectRelation", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCollectRelation indicates an expected call of InsertCollectRelation.
+func (mr *MockInteractiveDAOMockRecorder) InsertCollectRelation(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectRelation", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertCollectRelation), ctx, biz, bizId, uId, cId)
+}
+
+// InsertLikeInfo mocks base method.
+func (m *MockInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeInfo", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertLikeInfo indicates an expected call of InsertLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) InsertLikeInfo(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeInfo), ctx, biz, bizId, uId)
+}
+
+// InsertLikeRelation mocks base method.
+func (m *MockInteractiveDAO) InsertLikeRelation(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLikeRelation", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertLikeRelation indicates an expected call of InsertLikeRelation.
+func (mr *MockInteractiveDAOMockRecorder) InsertLikeRelation(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLikeRelation", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertLikeRelation), ctx, biz, bizId, uId)
+}
+
+// ListInteractives mocks base method.
+func (m *MockInteractiveDAO) ListInteractives(ctx context.Context, startId int64, limit int) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInteractives", ctx, startId, limit)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInteractives indicates an expected call of ListInteractives.
+func (mr *MockInteractiveDAOMockRecorder) ListInteractives(ctx, startId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInteractives", reflect.TypeOf((*MockInteractiveDAO)(nil).ListInteractives), ctx, startId, limit)
+}
+
+// ListLikedByUser mocks base method.
+func (m *MockInteractiveDAO) ListLikedByUser(ctx context.Context, biz string, uid, cursorUtime, cursorId int64, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikedByUser", ctx, biz, uid, cursorUtime, cursorId, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikedByUser indicates an expected call of ListLikedByUser.
+func (mr *MockInteractiveDAOMockRecorder) ListLikedByUser(ctx, biz, uid, cursorUtime, cursorId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikedByUser", reflect.TypeOf((*MockInteractiveDAO)(nil).ListLikedByUser), ctx, biz, uid, cursorUtime, cursorId, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockInteractiveDAO) ListLikers(ctx context.Context, biz string, bizId, cursorUtime, cursorId int64, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, biz, bizId, cursorUtime, cursorId, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockInteractiveDAOMockRecorder) ListLikers(ctx, biz, bizId, cursorUtime, cursorId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockInteractiveDAO)(nil).ListLikers), ctx, biz, bizId, cursorUtime, cursorId, limit)
+}
+
+// ListRecentLiked mocks base method.
+func (m *MockInteractiveDAO) ListRecentLiked(ctx context.Context, biz string, uid int64, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentLiked", ctx, biz, uid, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentLiked indicates an expected call of ListRecentLiked.
+func (mr *MockInteractiveDAOMockRecorder) ListRecentLiked(ctx, biz, uid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentLiked", reflect.TypeOf((*MockInteractiveDAO)(nil).ListRecentLiked), ctx, biz, uid, limit)
+}
+
+// RepairCnt mocks base method.
+func (m *MockInteractiveDAO) RepairCnt(ctx context.Context, id, oldLikeCnt, oldCollectCnt, likeCnt, collectCnt int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairCnt", ctx, id, oldLikeCnt, oldCollectCnt, likeCnt, collectCnt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RepairCnt indicates an expected call of RepairCnt.
+func (mr *MockInteractiveDAOMockRecorder) RepairCnt(ctx, id, oldLikeCnt, oldCollectCnt, likeCnt, collectCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).RepairCnt), ctx, id, oldLikeCnt, oldCollectCnt, likeCnt, collectCnt)
+}
+
+// SaveUvCnt mocks base method.
+func (m *MockInteractiveDAO) SaveUvCnt(ctx context.Context, intrs []dao.Interactive, dailies []dao.InteractiveDailyUv) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUvCnt", ctx, intrs, dailies)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUvCnt indicates an expected call of SaveUvCnt.
+func (mr *MockInteractiveDAOMockRecorder) SaveUvCnt(ctx, intrs, dailies any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUvCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).SaveUvCnt), ctx, intrs, dailies)
+}
+
+// SetReaction mocks base method.
+func (m *MockInteractiveDAO) SetReaction(ctx context.Context, biz string, bizId, uid int64, reaction string, likeCnt bool) (dao.ReactionChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReaction", ctx, biz, bizId, uid, reaction, likeCnt)
+	ret0, _ := ret[0].(dao.ReactionChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReaction indicates an expected call of SetReaction.
+func (mr *MockInteractiveDAOMockRecorder) SetReaction(ctx, biz, bizId, uid, reaction, likeCnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).SetReaction), ctx, biz, bizId, uid, reaction, likeCnt)
+}
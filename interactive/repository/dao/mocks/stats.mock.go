@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./stats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./stats.go -package=daomocks -destination=mocks/stats.mock.go StatsDAO
+//
+
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	dao "red-feed/interactive/repository/dao"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatsDAO is a mock of StatsDAO interface.
+type MockStatsDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsDAOMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsDAOMockRecorder is the mock recorder for MockStatsDAO.
+type MockStatsDAOMockRecorder struct {
+	mock *MockStatsDAO
+}
+
+// NewMockStatsDAO creates a new mock instance.
+func NewMockStatsDAO(ctrl *gomock.Controller) *MockStatsDAO {
+	mock := &MockStatsDAO{ctrl: ctrl}
+	mock.recorder = &MockStatsDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsDAO) EXPECT() *MockStatsDAOMockRecorder {
+	return m.recorder
+}
+
+// BatchIncr mocks base method.
+func (m *MockStatsDAO) BatchIncr(ctx context.Context, rows []dao.InteractiveHourlyStats) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncr", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncr indicates an expected call of BatchIncr.
+func (mr *MockStatsDAOMockRecorder) BatchIncr(ctx, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncr", reflect.TypeOf((*MockStatsDAO)(nil).BatchIncr), ctx, rows)
+}
+
+// SumByBizId mocks base method.
+func (m *MockStatsDAO) SumByBizId(ctx context.Context, biz string, bizIds []int64, start, end int64) ([]dao.InteractiveHourlyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByBizId", ctx, biz, bizIds, start, end)
+	ret0, _ := ret[0].([]dao.InteractiveHourlyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByBizId indicates an expected call of SumByBizId.
+func (mr *MockStatsDAOMockRecorder) SumByBizId(ctx, biz, bizIds, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByBizId", reflect.TypeOf((*MockStatsDAO)(nil).SumByBizId), ctx, biz, bizIds, start, end)
+}
+
+// SumByHour mocks base method.
+func (m *MockStatsDAO) SumByHour(ctx context.Context, biz string, bizIds []int64, start, end int64) ([]dao.InteractiveHourlyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByHour", ctx, biz, bizIds, start, end)
+	ret0, _ := ret[0].([]dao.InteractiveHourlyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByHour indicates an expected call of SumByHour.
+func (mr *MockStatsDAOMockRecorder) SumByHour(ctx, biz, bizIds, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByHour", reflect.TypeOf((*MockStatsDAO)(nil).SumByHour), ctx, biz, bizIds, start, end)
+}
@@ -0,0 +1,83 @@
+package dao
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"red-feed/interactive/domain"
+	"time"
+)
+
+// OutboxDAO 点赞、收藏的事件和记录在同一个事务里面写进 outbox 表，再由任务发到 Kafka
+//
+//go:generate mockgen -source=./outbox.go -package=daomocks -destination=mocks/outbox.mock.go OutboxDAO
+type OutboxDAO interface {
+	// Claim 按照 id 顺序认领一批还没有发出去、也没有被别人认领的事件，lease 之内别人拿不到。
+	// 到期了还没删掉的，说明认领的实例挂了或者发送失败了，别人可以重新认领
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]InteractiveOutbox, error)
+	// Delete 发出去之后就删掉
+	Delete(ctx context.Context, ids []int64) error
+}
+
+type GORMOutboxDAO struct {
+	db *gorm.DB
+}
+
+func NewOutboxDAO(db *gorm.DB) OutboxDAO {
+	return &GORMOutboxDAO{
+		db: db,
+	}
+}
+
+func (d *GORMOutboxDAO) Claim(ctx context.Context, limit int, lease time.Duration) ([]InteractiveOutbox, error) {
+	now := time.Now().UnixMilli()
+	// 每次认领一个新的 token，先抢占再按照 token 查出来，多个实例同时跑也不会拿到同一行
+	token := uuid.NewString()
+	res := d.db.WithContext(ctx).Model(&InteractiveOutbox{}).
+		Where("lease_until < ?", now).
+		Order("id").Limit(limit).
+		Updates(map[string]any{
+			"claim_token": token,
+			"lease_until": now + lease.Milliseconds(),
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return nil, res.Error
+	}
+	var rows []InteractiveOutbox
+	err := d.db.WithContext(ctx).Where("claim_token = ?", token).Order("id").Find(&rows).Error
+	return rows, err
+}
+
+func (d *GORMOutboxDAO) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&InteractiveOutbox{}).Error
+}
+
+// insertOutbox 必须和点赞、收藏记录的修改在同一个事务里面
+func insertOutbox(tx *gorm.DB, typ domain.InteractiveEventType, biz string, bizId, uid int64, now int64) error {
+	return tx.Create(&InteractiveOutbox{
+		EventId: uuid.NewString(),
+		Type:    string(typ),
+		Biz:     biz,
+		BizId:   bizId,
+		Uid:     uid,
+		Ctime:   now,
+	}).Error
+}
+
+// InteractiveOutbox 还没有发出去的点赞、收藏事件
+type InteractiveOutbox struct {
+	Id      int64  `gorm:"primaryKey,autoIncrement"`
+	EventId string `gorm:"type:varchar(64)"`
+	Type    string `gorm:"type:varchar(32)"`
+	Biz     string `gorm:"type:varchar(128)"`
+	BizId   int64
+	Uid     int64
+	Ctime   int64
+	// ClaimToken 最近一次认领的 token
+	ClaimToken string `gorm:"type:varchar(64);index"`
+	// LeaseUntil 认领到什么时候，毫秒数
+	LeaseUntil int64 `gorm:"index"`
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestGORMOutboxDAO_Claim(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantIds []int64
+		wantErr error
+	}{
+		{
+			name: "先抢占再按照 token 查出来",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `interactive_outboxes` SET .*`claim_token`=.*`lease_until`=.* " +
+					"WHERE lease_until < \\? ORDER BY id LIMIT \\?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery("SELECT \\* FROM `interactive_outboxes` WHERE claim_token = \\? ORDER BY id").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "event_id"}).
+						AddRow(1, "e1").AddRow(2, "e2"))
+				return mockDB, mock
+			},
+			wantIds: []int64{1, 2},
+		},
+		{
+			name: "都被别人认领了",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `interactive_outboxes` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return mockDB, mock
+			},
+		},
+		{
+			name: "数据库错误",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `interactive_outboxes` SET .*").
+					WillReturnError(errors.New("db error"))
+				return mockDB, mock
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewOutboxDAO(openMockDB(t, mockDB))
+			rows, err := d.Claim(context.Background(), 2, time.Second)
+			assert.Equal(t, tc.wantErr, err)
+			var ids []int64
+			for _, row := range rows {
+				ids = append(ids, row.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"red-feed/interactive/domain"
+	"time"
+)
+
+func (d *GORMInteractiveDAO) GetReaction(ctx context.Context, biz string, bizId, uid int64) (UserReactionBiz, error) {
+	var res UserReactionBiz
+	err := d.db.WithContext(ctx).
+		Where("uid = ? AND biz = ? AND biz_id = ? AND status = ?", uid, biz, bizId, 1).
+		First(&res).Error
+	return res, err
+}
+
+func (d *GORMInteractiveDAO) GetReactionCnts(ctx context.Context, biz string, bizId int64) ([]InteractiveReaction, error) {
+	var res []InteractiveReaction
+	err := d.db.WithContext(ctx).
+		Where("biz = ? AND biz_id = ? AND cnt > ?", biz, bizId, 0).
+		Find(&res).Error
+	return res, err
+}
+
+// ReactionChange 换表情前后的变化，空字符串表示没有别的表情
+type ReactionChange struct {
+	Old string
+	New string
+	// Liked 从没有点赞变成了点赞，Unliked 反过来
+	Liked   bool
+	Unliked bool
+}
+
+func (d *GORMInteractiveDAO) SetReaction(ctx context.Context, biz string, bizId, uid int64,
+	reaction string, likeCnt bool) (ReactionChange, error) {
+	var res ReactionChange
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		liked, old, err := d.lockReactions(tx, biz, bizId, uid)
+		if err != nil {
+			return err
+		}
+		res.Old = old
+		if reaction == domain.ReactionLike {
+			if old != "" {
+				if err = d.deleteReaction(tx, biz, bizId, uid, old, now); err != nil {
+					return err
+				}
+			}
+			if !liked {
+				res.Liked, err = d.setLike(tx, biz, bizId, uid, true, likeCnt, now)
+			}
+			return err
+		}
+		res.New = reaction
+		if liked {
+			if res.Unliked, err = d.setLike(tx, biz, bizId, uid, false, likeCnt, now); err != nil {
+				return err
+			}
+		}
+		if old == reaction {
+			return nil
+		}
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]any{
+				"reaction": reaction,
+				"status":   1,
+				"utime":    now,
+			}),
+		}).Create(&UserReactionBiz{
+			Uid:      uid,
+			Biz:      biz,
+			BizId:    bizId,
+			Reaction: reaction,
+			Status:   1,
+			Ctime:    now,
+			Utime:    now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if old != "" {
+			if err = d.incrReactionCnt(tx, biz, bizId, old, -1, now); err != nil {
+				return err
+			}
+		}
+		return d.incrReactionCnt(tx, biz, bizId, reaction, 1, now)
+	})
+	if err != nil {
+		return ReactionChange{}, err
+	}
+	return res, nil
+}
+
+func (d *GORMInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64,
+	likeCnt bool) (ReactionChange, error) {
+	var res ReactionChange
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		liked, old, err := d.lockReactions(tx, biz, bizId, uid)
+		if err != nil {
+			return err
+		}
+		res.Old = old
+		if old != "" {
+			if err = d.deleteReaction(tx, biz, bizId, uid, old, now); err != nil {
+				return err
+			}
+		}
+		if liked {
+			res.Unliked, err = d.setLike(tx, biz, bizId, uid, false, likeCnt, now)
+		}
+		return err
+	})
+	if err != nil {
+		return ReactionChange{}, err
+	}
+	return res, nil
+}
+
+// lockReactions 锁住用户的点赞和表情记录，返回现在有没有点赞和别的表情。
+// 总是先锁点赞再锁表情，两个请求同时换表情也不会死锁。
+// 记录不存在的时候锁的是间隙，同一个用户同时加两个表情也只有一个能成功
+func (d *GORMInteractiveDAO) lockReactions(tx *gorm.DB, biz string, bizId, uid int64) (bool, string, error) {
+	var like UserLikeBiz
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("biz = ? AND biz_id = ? AND uid = ?", biz, bizId, uid).
+		First(&like).Error
+	if err != nil && err != ErrDataNotFound {
+		return false, "", err
+	}
+	liked := err == nil && like.Status == 1
+	var reaction UserReactionBiz
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		First(&reaction).Error
+	switch {
+	case err == ErrDataNotFound:
+		return liked, "", nil
+	case err != nil:
+		return false, "", err
+	case reaction.Status != 1:
+		return liked, "", nil
+	default:
+		return liked, reaction.Reaction, nil
+	}
+}
+
+// setLike 点赞或者取消点赞，和 InsertLikeInfo、DeleteLikeInfo 一样要写 outbox
+func (d *GORMInteractiveDAO) setLike(tx *gorm.DB, biz string, bizId, uid int64,
+	like bool, likeCnt bool, now int64) (bool, error) {
+	var (
+		changed bool
+		err     error
+	)
+	typ := domain.EventUnliked
+	if like {
+		typ = domain.EventLiked
+		changed, err = d.insertLikeRelation(tx, biz, bizId, uid, now)
+	} else {
+		changed, err = d.deleteLikeRelation(tx, biz, bizId, uid, now)
+	}
+	if err != nil || !changed {
+		return changed, err
+	}
+	if likeCnt {
+		if like {
+			err = d.incrLikeCnt(tx, biz, bizId, now)
+		} else {
+			err = d.decrLikeCnt(tx, biz, bizId, now)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, insertOutbox(tx, typ, biz, bizId, uid, now)
+}
+
+func (d *GORMInteractiveDAO) deleteReaction(tx *gorm.DB, biz string, bizId, uid int64, old string, now int64) error {
+	err := tx.Model(&UserReactionBiz{}).
+		Where("uid = ? AND biz = ? AND biz_id = ?", uid, biz, bizId).
+		Updates(map[string]any{
+			"status": 0,
+			"utime":  now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	return d.incrReactionCnt(tx, biz, bizId, old, -1, now)
+}
+
+func (d *GORMInteractiveDAO) incrReactionCnt(tx *gorm.DB, biz string, bizId int64, reaction string, delta int64, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"cnt":   gorm.Expr("cnt + ?", delta),
+			"utime": now,
+		}),
+	}).Create(&InteractiveReaction{
+		Biz:      biz,
+		BizId:    bizId,
+		Reaction: reaction,
+		Cnt:      delta,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
+
+// UserReactionBiz 用户对资源的表情，一个用户对一个资源只能有一个。
+// 默认的表情还是记在 UserLikeBiz 里面，这里只有别的表情
+type UserReactionBiz struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Uid      int64  `gorm:"uniqueIndex:uid_biz_id_type"`
+	BizId    int64  `gorm:"uniqueIndex:uid_biz_id_type"`
+	Biz      string `gorm:"uniqueIndex:uid_biz_id_type;type:varchar(128)"`
+	Reaction string `gorm:"type:varchar(32)"`
+	Ctime    int64
+	Utime    int64
+	Status   uint8 // 1 有效 0 取消了
+}
+
+// InteractiveReaction 每个资源每种表情的数量，默认的表情的数量就是 Interactive.LikeCnt
+type InteractiveReaction struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	BizId    int64  `gorm:"uniqueIndex:biz_id_type_reaction"`
+	Biz      string `gorm:"uniqueIndex:biz_id_type_reaction;type:varchar(128)"`
+	Reaction string `gorm:"uniqueIndex:biz_id_type_reaction;type:varchar(32)"`
+	Cnt      int64
+	Ctime    int64
+	Utime    int64
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGORMInteractiveDAO_SetReaction(t *testing.T) {
+	testcases := []struct {
+		name     string
+		mock     func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		reaction string
+		likeCnt  bool
+		want     ReactionChange
+		wantErr  error
+	}{
+		{
+			name: "点赞换成别的表情，同一个事务里面取消点赞",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `user_like_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_reaction_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactives` SET .*like_cnt-1.*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `user_reaction_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			reaction: "heart",
+			likeCnt:  true,
+			want:     ReactionChange{New: "heart", Unliked: true},
+		},
+		{
+			name: "别的表情换成点赞，写回模式不改点赞数",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `user_like_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT \\* FROM `user_reaction_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "reaction", "status"}).AddRow(1, "heart", 1))
+				mock.ExpectExec("UPDATE `user_reaction_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactive_reactions` .*").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO `user_like_bizs` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactive_outboxes` .*").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+				return mockDB, mock
+			},
+			reaction: "like",
+			want:     ReactionChange{Old: "heart", Liked: true},
+		},
+		{
+			name: "取消点赞失败，表情也不换",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `user_like_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(1, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_reaction_bizs` .* FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectExec("UPDATE `user_like_bizs` .*").
+					WillReturnError(errors.New("db error"))
+				mock.ExpectRollback()
+				return mockDB, mock
+			},
+			reaction: "heart",
+			likeCnt:  true,
+			wantErr:  errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			d := NewInteractiveDAO(openMockDB(t, mockDB))
+			res, err := d.SetReaction(context.Background(), "article", 1, 2, tc.reaction, tc.likeCnt)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
+)
+
+// StatsDAO 按小时分桶的阅读、点赞、收藏数，按天的查询的时候再合起来
+//
+//go:generate mockgen -source=./stats.go -package=daomocks -destination=mocks/stats.mock.go StatsDAO
+type StatsDAO interface {
+	// BatchIncr 每一行的计数加到对应的小时上
+	BatchIncr(ctx context.Context, rows []InteractiveHourlyStats) error
+	// SumByHour 这些资源在 [start, end) 之间每个小时的计数加起来，没有计数的小时不在结果里
+	SumByHour(ctx context.Context, biz string, bizIds []int64, start, end int64) ([]InteractiveHourlyStats, error)
+	// SumByBizId 这些资源在 [start, end) 之间各自的计数，没有计数的资源不在结果里
+	SumByBizId(ctx context.Context, biz string, bizIds []int64, start, end int64) ([]InteractiveHourlyStats, error)
+}
+
+type GORMStatsDAO struct {
+	db *gorm.DB
+}
+
+func NewStatsDAO(db *gorm.DB) StatsDAO {
+	return &GORMStatsDAO{
+		db: db,
+	}
+}
+
+func (d *GORMStatsDAO) BatchIncr(ctx context.Context, rows []InteractiveHourlyStats) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range rows {
+		rows[i].Ctime = now
+		rows[i].Utime = now
+	}
+	// 按照唯一索引的顺序加锁，几个消费者同时写的时候不会互相死锁
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].BizId != rows[j].BizId {
+			return rows[i].BizId < rows[j].BizId
+		}
+		if rows[i].Biz != rows[j].Biz {
+			return rows[i].Biz < rows[j].Biz
+		}
+		return rows[i].Hour < rows[j].Hour
+	})
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":    gorm.Expr("read_cnt + VALUES(read_cnt)"),
+			"like_cnt":    gorm.Expr("like_cnt + VALUES(like_cnt)"),
+			"collect_cnt": gorm.Expr("collect_cnt + VALUES(collect_cnt)"),
+			"utime":       now,
+		}),
+	}).Create(&rows).Error
+}
+
+func (d *GORMStatsDAO) SumByHour(ctx context.Context, biz string, bizIds []int64,
+	start, end int64) ([]InteractiveHourlyStats, error) {
+	var res []InteractiveHourlyStats
+	err := d.sum(ctx, biz, bizIds, start, end).
+		Select("hour, SUM(read_cnt) AS read_cnt, SUM(like_cnt) AS like_cnt, SUM(collect_cnt) AS collect_cnt").
+		Group("hour").
+		Order("hour").
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMStatsDAO) SumByBizId(ctx context.Context, biz string, bizIds []int64,
+	start, end int64) ([]InteractiveHourlyStats, error) {
+	var res []InteractiveHourlyStats
+	err := d.sum(ctx, biz, bizIds, start, end).
+		Select("biz_id, SUM(read_cnt) AS read_cnt, SUM(like_cnt) AS like_cnt, SUM(collect_cnt) AS collect_cnt").
+		Group("biz_id").
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMStatsDAO) sum(ctx context.Context, biz string, bizIds []int64, start, end int64) *gorm.DB {
+	return d.db.WithContext(ctx).Model(&InteractiveHourlyStats{}).
+		Where("biz = ? AND biz_id IN ? AND hour >= ? AND hour < ?", biz, bizIds, start, end)
+}
+
+// InteractiveHourlyStats 每个资源每个小时新增的阅读、点赞、收藏
+type InteractiveHourlyStats struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	BizId int64  `gorm:"uniqueIndex:biz_id_type_hour"`
+	Biz   string `gorm:"uniqueIndex:biz_id_type_hour;type:varchar(128)"`
+	// Hour 这个小时开始的毫秒数
+	Hour       int64 `gorm:"uniqueIndex:biz_id_type_hour"`
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	Ctime      int64
+	Utime      int64
+}
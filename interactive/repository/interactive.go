@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
 	"red-feed/interactive/domain"
 	"red-feed/interactive/repository/cache"
 	"red-feed/interactive/repository/dao"
 	"red-feed/pkg/logger"
+	"time"
 )
 
+//go:generate mockgen -source=./interactive.go -package=repomocks -destination=mocks/interactive.mock.go InteractiveRepository
 type InteractiveRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
 	IncrLike(ctx context.Context, biz string, bizId, uId int64) error
@@ -19,7 +22,19 @@ type InteractiveRepository interface {
 	Liked(ctx context.Context, biz string, bizId int64, uId int64) (bool, error)
 	Collected(ctx context.Context, biz string, bizId int64, uId int64) (bool, error)
 	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
+	BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error
 	GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error)
+	DeleteBiz(ctx context.Context, biz string, bizId int64) error
+	// GetUserStateByIds 批量查询用户是否点赞、收藏了这些资源，只返回点赞或者收藏了的
+	GetUserStateByIds(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]domain.UserState, error)
+	ListLikers(ctx context.Context, biz string, bizId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error)
+	ListLikedByUser(ctx context.Context, biz string, uid int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error)
+	// SetReaction 换成点赞也走这里，和原来的表情在一个事务里面换掉
+	SetReaction(ctx context.Context, biz string, bizId, uId int64, reaction string) error
+	// DeleteReaction 点赞和别的表情都取消
+	DeleteReaction(ctx context.Context, biz string, bizId, uId int64) error
+	// Reaction 用户用的默认以外的表情，没有就是空字符串
+	Reaction(ctx context.Context, biz string, bizId, uId int64) (string, error)
 }
 
 type CachedInteractiveRepository struct {
@@ -28,6 +43,14 @@ type CachedInteractiveRepository struct {
 	l     logger.Logger
 }
 
+func (r *CachedInteractiveRepository) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	err := r.dao.DeleteBiz(ctx, biz, bizId)
+	if err != nil {
+		return err
+	}
+	return r.cache.Del(ctx, biz, bizId)
+}
+
 func (r *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
 	vals, err := r.dao.GetByIds(ctx, biz, ids)
 	if err != nil {
@@ -39,8 +62,140 @@ func (r *CachedInteractiveRepository) GetByIds(ctx context.Context, biz string,
 		}), nil
 }
 
+func (r *CachedInteractiveRepository) GetUserStateByIds(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]domain.UserState, error) {
+	var (
+		eg        errgroup.Group
+		liked     []int64
+		collected []int64
+	)
+	eg.Go(func() error {
+		var err error
+		liked, err = r.likedBizIds(ctx, biz, uid, bizIds)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		collected, err = r.dao.GetCollectedBizIds(ctx, biz, uid, bizIds)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.UserState, len(liked)+len(collected))
+	for _, id := range liked {
+		res[id] = domain.UserState{BizId: id, Liked: true}
+	}
+	for _, id := range collected {
+		state := res[id]
+		state.BizId = id
+		state.Collected = true
+		res[id] = state
+	}
+	return res, nil
+}
+
+// likedBizIds 优先用缓存里面用户最近的点赞，缓存不完整的时候剩下的再查数据库
+func (r *CachedInteractiveRepository) likedBizIds(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, error) {
+	liked, complete, err := r.cache.GetLiked(ctx, biz, uid, bizIds)
+	switch err {
+	case nil:
+	case cache.ErrKeyNotExist:
+		liked, complete, err = r.loadLiked(ctx, biz, uid, bizIds)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		r.l.Error("查询点赞缓存失败", logger.Error(err),
+			logger.String("biz", biz), logger.Int64("uid", uid))
+		return r.dao.GetLikedBizIds(ctx, biz, uid, bizIds)
+	}
+	if complete {
+		return liked, nil
+	}
+	// 缓存里只有最近的点赞，缓存里没有的还要查数据库
+	hit := make(map[int64]struct{}, len(liked))
+	for _, id := range liked {
+		hit[id] = struct{}{}
+	}
+	rest := make([]int64, 0, len(bizIds)-len(liked))
+	for _, id := range bizIds {
+		if _, ok := hit[id]; !ok {
+			rest = append(rest, id)
+		}
+	}
+	if len(rest) == 0 {
+		return liked, nil
+	}
+	more, err := r.dao.GetLikedBizIds(ctx, biz, uid, rest)
+	if err != nil {
+		return nil, err
+	}
+	return append(liked, more...), nil
+}
+
+// loadLiked 从数据库加载用户最近的点赞写到缓存里，顺便回答这一次的查询
+func (r *CachedInteractiveRepository) loadLiked(ctx context.Context, biz string, uid int64, bizIds []int64) ([]int64, bool, error) {
+	// 多查一个，用来判断是不是用户全部的点赞
+	likes, err := r.dao.ListRecentLiked(ctx, biz, uid, cache.RecentLikedLimit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	complete := len(likes) <= cache.RecentLikedLimit
+	if !complete {
+		likes = likes[:cache.RecentLikedLimit]
+	}
+	items := make([]domain.LikedItem, 0, len(likes))
+	recent := make(map[int64]struct{}, len(likes))
+	for _, like := range likes {
+		items = append(items, domain.LikedItem{BizId: like.BizId, Utime: time.UnixMilli(like.Utime)})
+		recent[like.BizId] = struct{}{}
+	}
+	if er := r.cache.SetLiked(ctx, biz, uid, items, complete); er != nil {
+		r.l.Error("回写点赞缓存失败", logger.Error(er),
+			logger.String("biz", biz), logger.Int64("uid", uid))
+	}
+	liked := make([]int64, 0, len(bizIds))
+	for _, id := range bizIds {
+		if _, ok := recent[id]; ok {
+			liked = append(liked, id)
+		}
+	}
+	return liked, complete, nil
+}
+
+func (r *CachedInteractiveRepository) ListLikers(ctx context.Context, biz string, bizId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	likes, err := r.dao.ListLikers(ctx, biz, bizId, cursor.Utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserLikeBiz, domain.LikeRecord](likes, r.toLikeRecord), nil
+}
+
+func (r *CachedInteractiveRepository) ListLikedByUser(ctx context.Context, biz string, uid int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	likes, err := r.dao.ListLikedByUser(ctx, biz, uid, cursor.Utime, cursor.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserLikeBiz, domain.LikeRecord](likes, r.toLikeRecord), nil
+}
+
 func (r *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
-	return r.dao.BatchIncrReadCnt(ctx, bizs, bizIds)
+	err := r.dao.BatchIncrReadCnt(ctx, bizs, bizIds)
+	if err != nil {
+		return err
+	}
+	// 数据库已经成功了，缓存更新失败等过期就好，不要让消费者重试
+	if er := r.cache.BatchIncrReadCntIfPresent(ctx, bizs, bizIds); er != nil {
+		r.l.Error("批量更新阅读数缓存失败", logger.Error(er), logger.Int("cnt", len(bizIds)))
+	}
+	return nil
+}
+
+// BatchIncrInvalidReadCnt 只是给分析用的，缓存里面不维护
+func (r *CachedInteractiveRepository) BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	return r.dao.BatchIncrInvalidReadCnt(ctx, bizs, bizIds)
 }
 
 func (r *CachedInteractiveRepository) Liked(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
@@ -74,11 +229,10 @@ func (r *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId
 		return intr, nil
 	}
 	// 缓存没查到，再查数据库
-	intrDAO, err := r.dao.Get(ctx, biz, bizId)
+	intr, err = r.load(ctx, biz, bizId)
 	if err != nil {
 		return domain.Interactive{}, err
 	}
-	intr = r.toDomain(intrDAO)
 	go func() {
 		setErr := r.cache.Set(ctx, biz, bizId, intr)
 		if setErr != nil {
@@ -90,66 +244,86 @@ func (r *CachedInteractiveRepository) Get(ctx context.Context, biz string, bizId
 
 func (r *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uId int64) error {
 	// 操作数据库增加点赞计数和用户点赞关系
-	err := r.dao.InsertLikeInfo(ctx, biz, bizId, uId)
-	if err != nil {
+	changed, err := r.dao.InsertLikeInfo(ctx, biz, bizId, uId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
 		return err
 	}
-	go func() {
-		// 在缓存中维护住 biz_bizId 的点赞数
-		cErr := r.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
-		if cErr != nil {
-			r.l.Error("IncrLikeCntIfPresent error", logger.Error(cErr))
-		}
-	}()
+	go r.syncLikeCache(ctx, biz, bizId, uId, true)
 	return nil
 }
 
 func (r *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uId int64) error {
 	// 操作数据库减少点赞计数和用户点赞关系
-	err := r.dao.DeleteLikeInfo(ctx, biz, bizId, uId)
-	if err != nil {
+	changed, err := r.dao.DeleteLikeInfo(ctx, biz, bizId, uId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
 		return err
 	}
-	go func() {
-		// 在缓存中维护住 biz_bizId 的点赞数
-		cErr := r.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
-		if cErr != nil {
-			r.l.Error("DecrLikeCntIfPresent error", logger.Error(cErr))
-		}
-	}()
+	go r.syncLikeCache(ctx, biz, bizId, uId, false)
 	return nil
 }
 
 func (r *CachedInteractiveRepository) IncrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
 	// 操作数据库增加收藏计数和用户收藏关系
-	err := r.dao.InsertCollectInfo(ctx, biz, bizId, uId, cId)
-	if err != nil {
+	changed, err := r.dao.InsertCollectInfo(ctx, biz, bizId, uId, cId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
 		return err
 	}
-	go func() {
-		// 在缓存中维护住 biz_bizId 的点赞数
-		cErr := r.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
-		if cErr != nil {
-			r.l.Error("IncrCollectCntIfPresent error", logger.Error(cErr))
-		}
-	}()
+	go r.syncCollectCache(ctx, biz, bizId, true)
 	return nil
 }
 
 func (r *CachedInteractiveRepository) DecrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
 	// 操作数据库增加收藏计数和用户收藏关系
-	err := r.dao.DeleteCollectInfo(ctx, biz, bizId, uId, cId)
-	if err != nil {
+	changed, err := r.dao.DeleteCollectInfo(ctx, biz, bizId, uId, cId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
 		return err
 	}
-	go func() {
+	go r.syncCollectCache(ctx, biz, bizId, false)
+	return nil
+}
+
+// syncLikeCache 点赞或者取消点赞之后，维护缓存里面的点赞数和用户最近的点赞
+func (r *CachedInteractiveRepository) syncLikeCache(ctx context.Context, biz string, bizId, uId int64, liked bool) {
+	if liked {
 		// 在缓存中维护住 biz_bizId 的点赞数
-		cErr := r.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+		cErr := r.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+		if cErr != nil {
+			r.l.Error("IncrLikeCntIfPresent error", logger.Error(cErr))
+		}
+		// 用户最近点赞的缓存也要加上
+		cErr = r.cache.AddLikedIfPresent(ctx, biz, uId, domain.LikedItem{BizId: bizId, Utime: time.Now()})
 		if cErr != nil {
-			r.l.Error("DecrCollectCntIfPresent error", logger.Error(cErr))
+			r.l.Error("AddLikedIfPresent error", logger.Error(cErr))
 		}
-	}()
-	return nil
+		return
+	}
+	cErr := r.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+	if cErr != nil {
+		r.l.Error("DecrLikeCntIfPresent error", logger.Error(cErr))
+	}
+	cErr = r.cache.RemoveLiked(ctx, biz, uId, bizId)
+	if cErr != nil {
+		r.l.Error("RemoveLiked error", logger.Error(cErr))
+	}
+}
+
+// syncCollectCache 收藏或者取消收藏之后，维护缓存里面的收藏数
+func (r *CachedInteractiveRepository) syncCollectCache(ctx context.Context, biz string, bizId int64, collected bool) {
+	if collected {
+		cErr := r.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
+		if cErr != nil {
+			r.l.Error("IncrCollectCntIfPresent error", logger.Error(cErr))
+		}
+		return
+	}
+	cErr := r.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
+	if cErr != nil {
+		r.l.Error("DecrCollectCntIfPresent error", logger.Error(cErr))
+	}
 }
 
 func (r *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
@@ -160,12 +334,41 @@ func (r *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz strin
 	return r.cache.IncrReadCntIfPresent(ctx, biz, bizId)
 }
 
+// load 从数据库加载计数和默认以外的表情的数量
+func (r *CachedInteractiveRepository) load(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	intrDAO, err := r.dao.Get(ctx, biz, bizId)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	reactions, err := r.dao.GetReactionCnts(ctx, biz, bizId)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	intr := r.toDomain(intrDAO)
+	intr.ReactionCnts = make(map[string]int64, len(reactions))
+	for _, reaction := range reactions {
+		intr.ReactionCnts[reaction.Reaction] = reaction.Cnt
+	}
+	return intr, nil
+}
+
 func (r *CachedInteractiveRepository) toDomain(intrDAO dao.Interactive) domain.Interactive {
 	return domain.Interactive{
 		BizId:      intrDAO.BizId,
 		CollectCnt: intrDAO.CollectCnt,
 		LikeCnt:    intrDAO.LikeCnt,
 		ReadCnt:    intrDAO.ReadCnt,
+		UvCnt:      intrDAO.UvCnt,
+	}
+}
+
+func (r *CachedInteractiveRepository) toLikeRecord(idx int, src dao.UserLikeBiz) domain.LikeRecord {
+	return domain.LikeRecord{
+		Id:    src.Id,
+		Uid:   src.Uid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Utime: time.UnixMilli(src.Utime),
 	}
 }
 
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestCachedInteractiveRepository_likedBizIds(t *testing.T) {
+	// 比缓存上限多一个，id 越小点赞越晚
+	overflow := make([]dao.UserLikeBiz, 0, cache.RecentLikedLimit+1)
+	overflowItems := make([]domain.LikedItem, 0, cache.RecentLikedLimit)
+	for i := int64(1); i <= cache.RecentLikedLimit+1; i++ {
+		utime := int64(cache.RecentLikedLimit+2-i) * 1000
+		overflow = append(overflow, dao.UserLikeBiz{BizId: i, Utime: utime})
+		if i <= cache.RecentLikedLimit {
+			overflowItems = append(overflowItems, domain.LikedItem{BizId: i, Utime: time.UnixMilli(utime)})
+		}
+	}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+		bizIds  []int64
+		want    []int64
+		wantErr error
+	}{
+		{
+			name: "缓存完整，直接用缓存",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return([]int64{1}, true, nil)
+				return daomocks.NewMockInteractiveDAO(ctrl), c
+			},
+			bizIds: []int64{1, 3},
+			want:   []int64{1},
+		},
+		{
+			name: "缓存不完整，没命中的查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3, 5}).
+					Return([]int64{1}, false, nil)
+				d.EXPECT().GetLikedBizIds(gomock.Any(), "article", int64(2), []int64{3, 5}).
+					Return([]int64{5}, nil)
+				return d, c
+			},
+			bizIds: []int64{1, 3, 5},
+			want:   []int64{1, 5},
+		},
+		{
+			name: "缓存不完整，但是全部命中",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return([]int64{1, 3}, false, nil)
+				return daomocks.NewMockInteractiveDAO(ctrl), c
+			},
+			bizIds: []int64{1, 3},
+			want:   []int64{1, 3},
+		},
+		{
+			name: "缓存不完整，查数据库失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return([]int64{1}, false, nil)
+				d.EXPECT().GetLikedBizIds(gomock.Any(), "article", int64(2), []int64{3}).
+					Return(nil, errors.New("db error"))
+				return d, c
+			},
+			bizIds:  []int64{1, 3},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "缓存没有，从数据库加载全部点赞",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return(nil, false, cache.ErrKeyNotExist)
+				d.EXPECT().ListRecentLiked(gomock.Any(), "article", int64(2), cache.RecentLikedLimit+1).
+					Return([]dao.UserLikeBiz{
+						{BizId: 3, Utime: 2000},
+						{BizId: 4, Utime: 1000},
+					}, nil)
+				c.EXPECT().SetLiked(gomock.Any(), "article", int64(2), []domain.LikedItem{
+					{BizId: 3, Utime: time.UnixMilli(2000)},
+					{BizId: 4, Utime: time.UnixMilli(1000)},
+				}, true).Return(nil)
+				return d, c
+			},
+			bizIds: []int64{1, 3},
+			want:   []int64{3},
+		},
+		{
+			name: "超过上限，只缓存最近的，剩下的查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, cache.RecentLikedLimit + 1, 5000}).
+					Return(nil, false, cache.ErrKeyNotExist)
+				d.EXPECT().ListRecentLiked(gomock.Any(), "article", int64(2), cache.RecentLikedLimit+1).
+					Return(overflow, nil)
+				c.EXPECT().SetLiked(gomock.Any(), "article", int64(2), overflowItems, false).Return(nil)
+				d.EXPECT().GetLikedBizIds(gomock.Any(), "article", int64(2), []int64{cache.RecentLikedLimit + 1, 5000}).
+					Return([]int64{cache.RecentLikedLimit + 1}, nil)
+				return d, c
+			},
+			bizIds: []int64{1, cache.RecentLikedLimit + 1, 5000},
+			want:   []int64{1, cache.RecentLikedLimit + 1},
+		},
+		{
+			name: "回写缓存失败不影响查询",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1}).
+					Return(nil, false, cache.ErrKeyNotExist)
+				d.EXPECT().ListRecentLiked(gomock.Any(), "article", int64(2), cache.RecentLikedLimit+1).
+					Return([]dao.UserLikeBiz{{BizId: 1, Utime: 1000}}, nil)
+				c.EXPECT().SetLiked(gomock.Any(), "article", int64(2), gomock.Any(), true).
+					Return(errors.New("redis error"))
+				return d, c
+			},
+			bizIds: []int64{1},
+			want:   []int64{1},
+		},
+		{
+			name: "加载的时候数据库错误",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1}).
+					Return(nil, false, cache.ErrKeyNotExist)
+				d.EXPECT().ListRecentLiked(gomock.Any(), "article", int64(2), cache.RecentLikedLimit+1).
+					Return(nil, errors.New("db error"))
+				return d, c
+			},
+			bizIds:  []int64{1},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "缓存出错，直接查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				c.EXPECT().GetLiked(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return(nil, false, errors.New("redis error"))
+				d.EXPECT().GetLikedBizIds(gomock.Any(), "article", int64(2), []int64{1, 3}).
+					Return([]int64{3}, nil)
+				return d, c
+			},
+			bizIds: []int64{1, 3},
+			want:   []int64{3},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewInteractiveRepository(d, c, &logger.NopLogger{}).(*CachedInteractiveRepository)
+			res, err := repo.likedBizIds(context.Background(), "article", 2, tc.bizIds)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
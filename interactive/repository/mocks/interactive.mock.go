@@ -0,0 +1,302 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./interactive.go -package=repomocks -destination=mocks/interactive.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveRepository is a mock of InteractiveRepository interface.
+type MockInteractiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractiveRepositoryMockRecorder is the mock recorder for MockInteractiveRepository.
+type MockInteractiveRepositoryMockRecorder struct {
+	mock *MockInteractiveRepository
+}
+
+// NewMockInteractiveRepository creates a new mock instance.
+func NewMockInteractiveRepository(ctrl *gomock.Controller) *MockInteractiveRepository {
+	mock := &MockInteractiveRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveRepository) EXPECT() *MockInteractiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrInvalidReadCnt mocks base method.
+func (m *MockInteractiveRepository) BatchIncrInvalidReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrInvalidReadCnt", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrInvalidReadCnt indicates an expected call of BatchIncrInvalidReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrInvalidReadCnt(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrInvalidReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchIncrInvalidReadCnt), ctx, bizs, bizIds)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, bizs, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrReadCnt(ctx, bizs, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchIncrReadCnt), ctx, bizs, bizIds)
+}
+
+// Collected mocks base method.
+func (m *MockInteractiveRepository) Collected(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collected", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collected indicates an expected call of Collected.
+func (mr *MockInteractiveRepositoryMockRecorder) Collected(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractiveRepository)(nil).Collected), ctx, biz, bizId, uId)
+}
+
+// DecrCollect mocks base method.
+func (m *MockInteractiveRepository) DecrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCollect", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCollect indicates an expected call of DecrCollect.
+func (mr *MockInteractiveRepositoryMockRecorder) DecrCollect(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCollect", reflect.TypeOf((*MockInteractiveRepository)(nil).DecrCollect), ctx, biz, bizId, uId, cId)
+}
+
+// DecrLike mocks base method.
+func (m *MockInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLike", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLike indicates an expected call of DecrLike.
+func (mr *MockInteractiveRepositoryMockRecorder) DecrLike(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLike", reflect.TypeOf((*MockInteractiveRepository)(nil).DecrLike), ctx, biz, bizId, uId)
+}
+
+// DeleteBiz mocks base method.
+func (m *MockInteractiveRepository) DeleteBiz(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBiz", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBiz indicates an expected call of DeleteBiz.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteBiz(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBiz", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteBiz), ctx, biz, bizId)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockInteractiveRepository) DeleteReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteReaction(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteReaction), ctx, biz, bizId, uId)
+}
+
+// Get mocks base method.
+func (m *MockInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractiveRepositoryMockRecorder) Get(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractiveRepository)(nil).Get), ctx, biz, bizId)
+}
+
+// GetByIds mocks base method.
+func (m *MockInteractiveRepository) GetByIds(ctx context.Context, biz string, ids []int64) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, biz, ids)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockInteractiveRepositoryMockRecorder) GetByIds(ctx, biz, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveRepository)(nil).GetByIds), ctx, biz, ids)
+}
+
+// GetUserStateByIds mocks base method.
+func (m *MockInteractiveRepository) GetUserStateByIds(ctx context.Context, biz string, uid int64, bizIds []int64) (map[int64]domain.UserState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStateByIds", ctx, biz, uid, bizIds)
+	ret0, _ := ret[0].(map[int64]domain.UserState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStateByIds indicates an expected call of GetUserStateByIds.
+func (mr *MockInteractiveRepositoryMockRecorder) GetUserStateByIds(ctx, biz, uid, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStateByIds", reflect.TypeOf((*MockInteractiveRepository)(nil).GetUserStateByIds), ctx, biz, uid, bizIds)
+}
+
+// IncrCollect mocks base method.
+func (m *MockInteractiveRepository) IncrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCollect", ctx, biz, bizId, uId, cId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCollect indicates an expected call of IncrCollect.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrCollect(ctx, biz, bizId, uId, cId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollect", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrCollect), ctx, biz, bizId, uId, cId)
+}
+
+// IncrLike mocks base method.
+func (m *MockInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLike", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLike indicates an expected call of IncrLike.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrLike(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLike", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrLike), ctx, biz, bizId, uId)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrReadCnt), ctx, biz, bizId)
+}
+
+// Liked mocks base method.
+func (m *MockInteractiveRepository) Liked(ctx context.Context, biz string, bizId, uId int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liked", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Liked indicates an expected call of Liked.
+func (mr *MockInteractiveRepositoryMockRecorder) Liked(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractiveRepository)(nil).Liked), ctx, biz, bizId, uId)
+}
+
+// ListLikedByUser mocks base method.
+func (m *MockInteractiveRepository) ListLikedByUser(ctx context.Context, biz string, uid int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikedByUser", ctx, biz, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.LikeRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikedByUser indicates an expected call of ListLikedByUser.
+func (mr *MockInteractiveRepositoryMockRecorder) ListLikedByUser(ctx, biz, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikedByUser", reflect.TypeOf((*MockInteractiveRepository)(nil).ListLikedByUser), ctx, biz, uid, cursor, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockInteractiveRepository) ListLikers(ctx context.Context, biz string, bizId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, biz, bizId, cursor, limit)
+	ret0, _ := ret[0].([]domain.LikeRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockInteractiveRepositoryMockRecorder) ListLikers(ctx, biz, bizId, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockInteractiveRepository)(nil).ListLikers), ctx, biz, bizId, cursor, limit)
+}
+
+// Reaction mocks base method.
+func (m *MockInteractiveRepository) Reaction(ctx context.Context, biz string, bizId, uId int64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reaction", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reaction indicates an expected call of Reaction.
+func (mr *MockInteractiveRepositoryMockRecorder) Reaction(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reaction", reflect.TypeOf((*MockInteractiveRepository)(nil).Reaction), ctx, biz, bizId, uId)
+}
+
+// SetReaction mocks base method.
+func (m *MockInteractiveRepository) SetReaction(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReaction", ctx, biz, bizId, uId, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReaction indicates an expected call of SetReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) SetReaction(ctx, biz, bizId, uId, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).SetReaction), ctx, biz, bizId, uId, reaction)
+}
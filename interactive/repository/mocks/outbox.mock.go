@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./outbox.go -package=repomocks -destination=mocks/outbox.mock.go InteractiveEventRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveEventRepository is a mock of InteractiveEventRepository interface.
+type MockInteractiveEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockInteractiveEventRepositoryMockRecorder is the mock recorder for MockInteractiveEventRepository.
+type MockInteractiveEventRepositoryMockRecorder struct {
+	mock *MockInteractiveEventRepository
+}
+
+// NewMockInteractiveEventRepository creates a new mock instance.
+func NewMockInteractiveEventRepository(ctrl *gomock.Controller) *MockInteractiveEventRepository {
+	mock := &MockInteractiveEventRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractiveEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveEventRepository) EXPECT() *MockInteractiveEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockInteractiveEventRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.InteractiveEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, limit, lease)
+	ret0, _ := ret[0].([]domain.InteractiveEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockInteractiveEventRepositoryMockRecorder) Claim(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockInteractiveEventRepository)(nil).Claim), ctx, limit, lease)
+}
+
+// MarkSent mocks base method.
+func (m *MockInteractiveEventRepository) MarkSent(ctx context.Context, evts []domain.InteractiveEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, evts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockInteractiveEventRepositoryMockRecorder) MarkSent(ctx, evts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockInteractiveEventRepository)(nil).MarkSent), ctx, evts)
+}
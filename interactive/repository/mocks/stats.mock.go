@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./stats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./stats.go -package=repomocks -destination=mocks/stats.mock.go StatsRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Incr mocks base method.
+func (m *MockStatsRepository) Incr(ctx context.Context, incrs []domain.StatsIncr) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Incr", ctx, incrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Incr indicates an expected call of Incr.
+func (mr *MockStatsRepositoryMockRecorder) Incr(ctx, incrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Incr", reflect.TypeOf((*MockStatsRepository)(nil).Incr), ctx, incrs)
+}
+
+// Series mocks base method.
+func (m *MockStatsRepository) Series(ctx context.Context, biz string, bizIds []int64, start, end time.Time, granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Series", ctx, biz, bizIds, start, end, granularity)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Series indicates an expected call of Series.
+func (mr *MockStatsRepositoryMockRecorder) Series(ctx, biz, bizIds, start, end, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Series", reflect.TypeOf((*MockStatsRepository)(nil).Series), ctx, biz, bizIds, start, end, granularity)
+}
+
+// SumByIds mocks base method.
+func (m *MockStatsRepository) SumByIds(ctx context.Context, biz string, bizIds []int64, start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByIds", ctx, biz, bizIds, start, end)
+	ret0, _ := ret[0].(map[int64]domain.StatsCnt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByIds indicates an expected call of SumByIds.
+func (mr *MockStatsRepositoryMockRecorder) SumByIds(ctx, biz, bizIds, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByIds", reflect.TypeOf((*MockStatsRepository)(nil).SumByIds), ctx, biz, bizIds, start, end)
+}
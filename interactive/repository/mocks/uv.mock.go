@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./uv.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./uv.go -package=repomocks -destination=mocks/uv.mock.go UvRepository
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/interactive/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUvRepository is a mock of UvRepository interface.
+type MockUvRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUvRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockUvRepositoryMockRecorder is the mock recorder for MockUvRepository.
+type MockUvRepositoryMockRecorder struct {
+	mock *MockUvRepository
+}
+
+// NewMockUvRepository creates a new mock instance.
+func NewMockUvRepository(ctrl *gomock.Controller) *MockUvRepository {
+	mock := &MockUvRepository{ctrl: ctrl}
+	mock.recorder = &MockUvRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUvRepository) EXPECT() *MockUvRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddVisits mocks base method.
+func (m *MockUvRepository) AddVisits(ctx context.Context, visits []domain.Visit) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddVisits", ctx, visits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddVisits indicates an expected call of AddVisits.
+func (mr *MockUvRepositoryMockRecorder) AddVisits(ctx, visits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVisits", reflect.TypeOf((*MockUvRepository)(nil).AddVisits), ctx, visits)
+}
+
+// PersistUv mocks base method.
+func (m *MockUvRepository) PersistUv(ctx context.Context, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersistUv", ctx, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PersistUv indicates an expected call of PersistUv.
+func (mr *MockUvRepositoryMockRecorder) PersistUv(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersistUv", reflect.TypeOf((*MockUvRepository)(nil).PersistUv), ctx, limit)
+}
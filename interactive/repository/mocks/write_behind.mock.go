@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./write_behind.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./write_behind.go -package=repomocks -destination=mocks/write_behind.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	repository "red-feed/interactive/repository"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCntFlushRepository is a mock of CntFlushRepository interface.
+type MockCntFlushRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCntFlushRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockCntFlushRepositoryMockRecorder is the mock recorder for MockCntFlushRepository.
+type MockCntFlushRepositoryMockRecorder struct {
+	mock *MockCntFlushRepository
+}
+
+// NewMockCntFlushRepository creates a new mock instance.
+func NewMockCntFlushRepository(ctrl *gomock.Controller) *MockCntFlushRepository {
+	mock := &MockCntFlushRepository{ctrl: ctrl}
+	mock.recorder = &MockCntFlushRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCntFlushRepository) EXPECT() *MockCntFlushRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanFlushed mocks base method.
+func (m *MockCntFlushRepository) CleanFlushed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanFlushed", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanFlushed indicates an expected call of CleanFlushed.
+func (mr *MockCntFlushRepositoryMockRecorder) CleanFlushed(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanFlushed", reflect.TypeOf((*MockCntFlushRepository)(nil).CleanFlushed), ctx, before, limit)
+}
+
+// FlushCnt mocks base method.
+func (m *MockCntFlushRepository) FlushCnt(ctx context.Context, limit int) (repository.FlushResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushCnt", ctx, limit)
+	ret0, _ := ret[0].(repository.FlushResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushCnt indicates an expected call of FlushCnt.
+func (mr *MockCntFlushRepositoryMockRecorder) FlushCnt(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushCnt", reflect.TypeOf((*MockCntFlushRepository)(nil).FlushCnt), ctx, limit)
+}
+
+// PendingCnt mocks base method.
+func (m *MockCntFlushRepository) PendingCnt(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingCnt", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingCnt indicates an expected call of PendingCnt.
+func (mr *MockCntFlushRepositoryMockRecorder) PendingCnt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingCnt", reflect.TypeOf((*MockCntFlushRepository)(nil).PendingCnt), ctx)
+}
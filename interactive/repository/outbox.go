@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/dao"
+	"time"
+)
+
+// InteractiveEventRepository 读取 outbox 里面还没有发出去的点赞、收藏事件
+//
+//go:generate mockgen -source=./outbox.go -package=repomocks -destination=mocks/outbox.mock.go InteractiveEventRepository
+type InteractiveEventRepository interface {
+	// Claim 认领一批还没有发出去的事件，lease 之内别的实例拿不到
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.InteractiveEvent, error)
+	// MarkSent 发出去了的不会再发
+	MarkSent(ctx context.Context, evts []domain.InteractiveEvent) error
+}
+
+type OutboxEventRepository struct {
+	dao dao.OutboxDAO
+}
+
+func NewInteractiveEventRepository(dao dao.OutboxDAO) InteractiveEventRepository {
+	return &OutboxEventRepository{
+		dao: dao,
+	}
+}
+
+func (r *OutboxEventRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]domain.InteractiveEvent, error) {
+	rows, err := r.dao.Claim(ctx, limit, lease)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.InteractiveOutbox, domain.InteractiveEvent](rows,
+		func(idx int, src dao.InteractiveOutbox) domain.InteractiveEvent {
+			return domain.InteractiveEvent{
+				Id:      src.Id,
+				EventId: src.EventId,
+				Type:    domain.InteractiveEventType(src.Type),
+				Biz:     src.Biz,
+				BizId:   src.BizId,
+				Uid:     src.Uid,
+				Ctime:   time.UnixMilli(src.Ctime),
+			}
+		}), nil
+}
+
+func (r *OutboxEventRepository) MarkSent(ctx context.Context, evts []domain.InteractiveEvent) error {
+	return r.dao.Delete(ctx, slice.Map[domain.InteractiveEvent, int64](evts,
+		func(idx int, src domain.InteractiveEvent) int64 {
+			return src.Id
+		}))
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+)
+
+func (r *CachedInteractiveRepository) SetReaction(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	change, err := r.dao.SetReaction(ctx, biz, bizId, uId, reaction, true)
+	if err != nil {
+		return err
+	}
+	r.syncReaction(ctx, biz, bizId, uId, change)
+	return nil
+}
+
+func (r *CachedInteractiveRepository) DeleteReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	change, err := r.dao.DeleteReaction(ctx, biz, bizId, uId, true)
+	if err != nil {
+		return err
+	}
+	r.syncReaction(ctx, biz, bizId, uId, change)
+	return nil
+}
+
+func (r *CachedInteractiveRepository) Reaction(ctx context.Context, biz string, bizId, uId int64) (string, error) {
+	res, err := r.dao.GetReaction(ctx, biz, bizId, uId)
+	switch err {
+	case nil:
+		return res.Reaction, nil
+	case dao.ErrDataNotFound:
+		return "", nil
+	default:
+		return "", err
+	}
+}
+
+// syncReaction 换表情之后维护缓存里面的表情数量、点赞数和用户最近的点赞
+func (r *CachedInteractiveRepository) syncReaction(ctx context.Context, biz string, bizId, uId int64, change dao.ReactionChange) {
+	if change.Old != change.New {
+		r.syncReactionCache(ctx, biz, bizId, change.Old, change.New)
+	}
+	if change.Liked || change.Unliked {
+		r.syncLikeCache(ctx, biz, bizId, uId, change.Liked)
+	}
+}
+
+// syncReactionCache 从 old 换成 reaction，空字符串表示没有
+func (r *CachedInteractiveRepository) syncReactionCache(ctx context.Context, biz string, bizId int64, old, reaction string) {
+	if old != "" {
+		if err := r.cache.IncrReactionCntIfPresent(ctx, biz, bizId, old, -1); err != nil {
+			r.l.Error("IncrReactionCntIfPresent error", logger.Error(err))
+		}
+	}
+	if reaction != "" {
+		if err := r.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, 1); err != nil {
+			r.l.Error("IncrReactionCntIfPresent error", logger.Error(err))
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestCachedInteractiveRepository_SetReaction(t *testing.T) {
+	testcases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+		reaction string
+		wantErr  error
+	}{
+		{
+			name: "第一次发表情，只加新的",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "heart", true).
+					Return(dao.ReactionChange{New: "heart"}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(1)).Return(nil)
+				return d, c
+			},
+			reaction: "heart",
+		},
+		{
+			name: "换表情，旧的减一新的加一",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "tada", true).
+					Return(dao.ReactionChange{Old: "heart", New: "tada"}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(-1)).Return(nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "tada", int64(1)).Return(nil)
+				return d, c
+			},
+			reaction: "tada",
+		},
+		{
+			name: "点赞换成别的表情，点赞数减一",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "heart", true).
+					Return(dao.ReactionChange{New: "heart", Unliked: true}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(1)).Return(nil)
+				c.EXPECT().DecrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().RemoveLiked(gomock.Any(), "article", int64(2), int64(1)).Return(nil)
+				return d, c
+			},
+			reaction: "heart",
+		},
+		{
+			name: "别的表情换成点赞，表情减一点赞数加一",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "like", true).
+					Return(dao.ReactionChange{Old: "heart", Liked: true}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(-1)).Return(nil)
+				c.EXPECT().IncrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().AddLikedIfPresent(gomock.Any(), "article", int64(2), gomock.Any()).Return(nil)
+				return d, c
+			},
+			reaction: "like",
+		},
+		{
+			name: "表情没变，不用动缓存",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "heart", true).
+					Return(dao.ReactionChange{Old: "heart", New: "heart"}, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			reaction: "heart",
+		},
+		{
+			name: "缓存失败也算成功",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "heart", true).
+					Return(dao.ReactionChange{New: "heart"}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "heart", int64(1)).
+					Return(errors.New("redis error"))
+				return d, c
+			},
+			reaction: "heart",
+		},
+		{
+			name: "数据库错误",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().SetReaction(gomock.Any(), "article", int64(1), int64(2), "heart", true).
+					Return(dao.ReactionChange{}, errors.New("db error"))
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+			reaction: "heart",
+			wantErr:  errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewInteractiveRepository(d, c, &logger.NopLogger{})
+			err := repo.SetReaction(context.Background(), "article", 1, 2, tc.reaction)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_DeleteReaction(t *testing.T) {
+	testcases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache)
+	}{
+		{
+			name: "取消表情，旧的减一",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().DeleteReaction(gomock.Any(), "article", int64(1), int64(2), true).
+					Return(dao.ReactionChange{Old: "laugh"}, nil)
+				c.EXPECT().IncrReactionCntIfPresent(gomock.Any(), "article", int64(1), "laugh", int64(-1)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "取消点赞",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				d.EXPECT().DeleteReaction(gomock.Any(), "article", int64(1), int64(2), true).
+					Return(dao.ReactionChange{Unliked: true}, nil)
+				c.EXPECT().DecrLikeCntIfPresent(gomock.Any(), "article", int64(1)).Return(nil)
+				c.EXPECT().RemoveLiked(gomock.Any(), "article", int64(2), int64(1)).Return(nil)
+				return d, c
+			},
+		},
+		{
+			name: "本来就没有表情",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().DeleteReaction(gomock.Any(), "article", int64(1), int64(2), true).
+					Return(dao.ReactionChange{}, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c := tc.mock(ctrl)
+			repo := NewInteractiveRepository(d, c, &logger.NopLogger{})
+			err := repo.DeleteReaction(context.Background(), "article", 1, 2)
+			assert.NoError(t, err)
+		})
+	}
+}
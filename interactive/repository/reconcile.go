@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+)
+
+// CntReconcileRepository 按照点赞、收藏记录核对计数
+type CntReconcileRepository interface {
+	// Reconcile 核对 id 大于 startId 的 limit 个计数，repair 为 true 的时候顺便修复
+	Reconcile(ctx context.Context, startId int64, limit int, repair bool) (ReconcileResult, error)
+}
+
+// ReconcileResult 一批核对的结果，LastId 是这一批最后一个计数的 id，下一批从它后面开始
+type ReconcileResult struct {
+	Checked    int
+	LastId     int64
+	Mismatches []domain.CntMismatch
+}
+
+type cntReconcileRepository struct {
+	dao   dao.InteractiveDAO
+	cache cache.InteractiveCache
+	delta cache.CntDeltaCache
+	l     logger.Logger
+}
+
+func NewCntReconcileRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache,
+	delta cache.CntDeltaCache, l logger.Logger) CntReconcileRepository {
+	return &cntReconcileRepository{
+		dao:   dao,
+		cache: cache,
+		delta: delta,
+		l:     l,
+	}
+}
+
+func (r *cntReconcileRepository) Reconcile(ctx context.Context, startId int64, limit int,
+	repair bool) (ReconcileResult, error) {
+	// 先读计数再数记录，中间有人点赞、收藏的话计数变了，修复的时候就不会覆盖
+	intrs, err := r.dao.ListInteractives(ctx, startId, limit)
+	if err != nil || len(intrs) == 0 {
+		return ReconcileResult{LastId: startId}, err
+	}
+	bizIds := make(map[string][]int64, 1)
+	for _, intr := range intrs {
+		bizIds[intr.Biz] = append(bizIds[intr.Biz], intr.BizId)
+	}
+	likes := make(map[string]map[int64]int64, len(bizIds))
+	collects := make(map[string]map[int64]int64, len(bizIds))
+	for biz, ids := range bizIds {
+		likes[biz], err = r.dao.CountLikes(ctx, biz, ids)
+		if err != nil {
+			return ReconcileResult{}, err
+		}
+		collects[biz], err = r.dao.CountCollects(ctx, biz, ids)
+		if err != nil {
+			return ReconcileResult{}, err
+		}
+	}
+	res := ReconcileResult{
+		Checked: len(intrs),
+		LastId:  intrs[len(intrs)-1].Id,
+	}
+	for _, intr := range intrs {
+		expectedLike, expectedCollect := likes[intr.Biz][intr.BizId], collects[intr.Biz][intr.BizId]
+		if intr.LikeCnt == expectedLike && intr.CollectCnt == expectedCollect {
+			continue
+		}
+		// 写回模式下还有一部分在 Redis 里面，数据库里面的计数加上它们才是完整的
+		pending, err := r.delta.Pending(ctx, intr.Biz, intr.BizId)
+		if err != nil {
+			r.l.Error("查询没有写回的计数失败", logger.Error(err),
+				logger.String("biz", intr.Biz), logger.Int64("bizId", intr.BizId))
+			continue
+		}
+		if intr.LikeCnt+pending.LikeCnt == expectedLike &&
+			intr.CollectCnt+pending.CollectCnt == expectedCollect {
+			continue
+		}
+		mismatch := domain.CntMismatch{
+			Biz:                intr.Biz,
+			BizId:              intr.BizId,
+			LikeCnt:            intr.LikeCnt + pending.LikeCnt,
+			ExpectedLikeCnt:    expectedLike,
+			CollectCnt:         intr.CollectCnt + pending.CollectCnt,
+			ExpectedCollectCnt: expectedCollect,
+		}
+		if repair {
+			mismatch.Repaired = r.repair(ctx, intr, expectedLike-pending.LikeCnt, expectedCollect-pending.CollectCnt)
+		}
+		res.Mismatches = append(res.Mismatches, mismatch)
+	}
+	return res, nil
+}
+
+// repair 修复数据库里面的计数，再删掉缓存，下一次查询的时候重新加载
+func (r *cntReconcileRepository) repair(ctx context.Context, intr dao.Interactive, likeCnt, collectCnt int64) bool {
+	ok, err := r.dao.RepairCnt(ctx, intr.Id, intr.LikeCnt, intr.CollectCnt, likeCnt, collectCnt)
+	if err != nil {
+		r.l.Error("修复计数失败", logger.Error(err),
+			logger.String("biz", intr.Biz), logger.Int64("bizId", intr.BizId))
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err = r.cache.Del(ctx, intr.Biz, intr.BizId); err != nil {
+		r.l.Error("删除计数缓存失败", logger.Error(err),
+			logger.String("biz", intr.Biz), logger.Int64("bizId", intr.BizId))
+	}
+	return true
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestCntReconcileRepository_Reconcile(t *testing.T) {
+	intrs := []dao.Interactive{
+		{Id: 11, Biz: "article", BizId: 1, LikeCnt: 3, CollectCnt: 1},
+		{Id: 12, Biz: "article", BizId: 2, LikeCnt: 5, CollectCnt: 0},
+	}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache)
+		repair  bool
+		wantRes ReconcileResult
+		wantErr error
+	}{
+		{
+			name: "计数都对得上",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs, nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 3, 2: 5}, nil)
+				d.EXPECT().CountCollects(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 1}, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl), cachemocks.NewMockCntDeltaCache(ctrl)
+			},
+			wantRes: ReconcileResult{Checked: 2, LastId: 12},
+		},
+		{
+			name: "对不上的只上报",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs, nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 3, 2: 4}, nil)
+				d.EXPECT().CountCollects(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 1}, nil)
+				dc.EXPECT().Pending(gomock.Any(), "article", int64(2)).Return(domain.CntDelta{}, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl), dc
+			},
+			wantRes: ReconcileResult{Checked: 2, LastId: 12, Mismatches: []domain.CntMismatch{
+				{Biz: "article", BizId: 2, LikeCnt: 5, ExpectedLikeCnt: 4},
+			}},
+		},
+		{
+			name: "加上没有写回的就对得上",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs, nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 3, 2: 7}, nil)
+				d.EXPECT().CountCollects(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 1}, nil)
+				dc.EXPECT().Pending(gomock.Any(), "article", int64(2)).Return(domain.CntDelta{LikeCnt: 2}, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl), dc
+			},
+			wantRes: ReconcileResult{Checked: 2, LastId: 12},
+		},
+		{
+			name: "修复成功，删除缓存",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs, nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 3, 2: 4}, nil)
+				d.EXPECT().CountCollects(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]int64{1: 1, 2: 2}, nil)
+				// 还有一个点赞没有写回，数据库里面只改成 3
+				dc.EXPECT().Pending(gomock.Any(), "article", int64(2)).Return(domain.CntDelta{LikeCnt: 1}, nil)
+				d.EXPECT().RepairCnt(gomock.Any(), int64(12), int64(5), int64(0), int64(3), int64(2)).
+					Return(true, nil)
+				c.EXPECT().Del(gomock.Any(), "article", int64(2)).Return(nil)
+				return d, c, dc
+			},
+			repair: true,
+			wantRes: ReconcileResult{Checked: 2, LastId: 12, Mismatches: []domain.CntMismatch{
+				{Biz: "article", BizId: 2, LikeCnt: 6, ExpectedLikeCnt: 4, ExpectedCollectCnt: 2, Repaired: true},
+			}},
+		},
+		{
+			name: "修复的时候计数变了，等下一次",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs[:1], nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1}).
+					Return(map[int64]int64{1: 2}, nil)
+				d.EXPECT().CountCollects(gomock.Any(), "article", []int64{1}).
+					Return(map[int64]int64{1: 1}, nil)
+				dc.EXPECT().Pending(gomock.Any(), "article", int64(1)).Return(domain.CntDelta{}, nil)
+				d.EXPECT().RepairCnt(gomock.Any(), int64(11), int64(3), int64(1), int64(2), int64(1)).
+					Return(false, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl), dc
+			},
+			repair: true,
+			wantRes: ReconcileResult{Checked: 1, LastId: 11, Mismatches: []domain.CntMismatch{
+				{Biz: "article", BizId: 1, LikeCnt: 3, ExpectedLikeCnt: 2, CollectCnt: 1, ExpectedCollectCnt: 1},
+			}},
+		},
+		{
+			name: "没有更多的了",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(nil, nil)
+				return d, cachemocks.NewMockInteractiveCache(ctrl), cachemocks.NewMockCntDeltaCache(ctrl)
+			},
+			wantRes: ReconcileResult{LastId: 10},
+		},
+		{
+			name: "数记录失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				d.EXPECT().ListInteractives(gomock.Any(), int64(10), 2).Return(intrs, nil)
+				d.EXPECT().CountLikes(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("db error"))
+				return d, cachemocks.NewMockInteractiveCache(ctrl), cachemocks.NewMockCntDeltaCache(ctrl)
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, dc := tc.mock(ctrl)
+			repo := NewCntReconcileRepository(d, c, dc, &logger.NopLogger{})
+			res, err := repo.Reconcile(context.Background(), 10, 2, tc.repair)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
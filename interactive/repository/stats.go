@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/dao"
+	"time"
+)
+
+//go:generate mockgen -source=./stats.go -package=repomocks -destination=mocks/stats.mock.go StatsRepository
+type StatsRepository interface {
+	// Incr 同一个资源同一个小时的先合并，再一起写
+	Incr(ctx context.Context, incrs []domain.StatsIncr) error
+	// Series 这些资源在 [start, end) 之间加起来的时间序列，没有计数的时间段也有，都是 0
+	Series(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+		granularity domain.StatsGranularity) ([]domain.StatsPoint, error)
+	// SumByIds 这些资源在 [start, end) 之间各自的计数，没有计数的不在结果里
+	SumByIds(ctx context.Context, biz string, bizIds []int64, start, end time.Time) (map[int64]domain.StatsCnt, error)
+}
+
+// HourlyStatsRepository 数据库里面只存按小时的，按天的是查询的时候加起来的
+type HourlyStatsRepository struct {
+	dao dao.StatsDAO
+}
+
+func NewStatsRepository(dao dao.StatsDAO) StatsRepository {
+	return &HourlyStatsRepository{
+		dao: dao,
+	}
+}
+
+func (r *HourlyStatsRepository) Incr(ctx context.Context, incrs []domain.StatsIncr) error {
+	type key struct {
+		biz   string
+		bizId int64
+		hour  int64
+	}
+	cnts := make(map[key]domain.StatsCnt, len(incrs))
+	for _, incr := range incrs {
+		k := key{biz: incr.Biz, bizId: incr.BizId, hour: incr.Time.Truncate(time.Hour).UnixMilli()}
+		cnts[k] = cnts[k].Add(incr.StatsCnt)
+	}
+	rows := make([]dao.InteractiveHourlyStats, 0, len(cnts))
+	for k, cnt := range cnts {
+		rows = append(rows, dao.InteractiveHourlyStats{
+			Biz:        k.biz,
+			BizId:      k.bizId,
+			Hour:       k.hour,
+			ReadCnt:    cnt.ReadCnt,
+			LikeCnt:    cnt.LikeCnt,
+			CollectCnt: cnt.CollectCnt,
+		})
+	}
+	return r.dao.BatchIncr(ctx, rows)
+}
+
+func (r *HourlyStatsRepository) Series(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+	granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	start = granularity.Truncate(start)
+	var rows []dao.InteractiveHourlyStats
+	if len(bizIds) > 0 {
+		var err error
+		rows, err = r.dao.SumByHour(ctx, biz, bizIds, start.UnixMilli(), end.UnixMilli())
+		if err != nil {
+			return nil, err
+		}
+	}
+	// 按天的把这一天里面的小时加起来
+	cnts := make(map[int64]domain.StatsCnt, len(rows))
+	for _, row := range rows {
+		t := granularity.Truncate(time.UnixMilli(row.Hour).In(start.Location())).UnixMilli()
+		cnts[t] = cnts[t].Add(r.toStatsCnt(row))
+	}
+	var res []domain.StatsPoint
+	for t := start; t.Before(end); t = granularity.Next(t) {
+		res = append(res, domain.StatsPoint{
+			Time:     t,
+			StatsCnt: cnts[t.UnixMilli()],
+		})
+	}
+	return res, nil
+}
+
+func (r *HourlyStatsRepository) SumByIds(ctx context.Context, biz string, bizIds []int64,
+	start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	if len(bizIds) == 0 {
+		return map[int64]domain.StatsCnt{}, nil
+	}
+	rows, err := r.dao.SumByBizId(ctx, biz, bizIds, start.UnixMilli(), end.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.StatsCnt, len(rows))
+	for _, row := range rows {
+		res[row.BizId] = r.toStatsCnt(row)
+	}
+	return res, nil
+}
+
+func (r *HourlyStatsRepository) toStatsCnt(row dao.InteractiveHourlyStats) domain.StatsCnt {
+	return domain.StatsCnt{
+		ReadCnt:    row.ReadCnt,
+		LikeCnt:    row.LikeCnt,
+		CollectCnt: row.CollectCnt,
+	}
+}
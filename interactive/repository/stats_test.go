@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"testing"
+	"time"
+)
+
+func TestHourlyStatsRepository_Incr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hour := time.Date(2026, 10, 19, 10, 0, 0, 0, time.Local)
+	d := daomocks.NewMockStatsDAO(ctrl)
+	// 同一个小时的合并成一行
+	d.EXPECT().BatchIncr(gomock.Any(), gomock.InAnyOrder([]dao.InteractiveHourlyStats{
+		{Biz: "article", BizId: 1, Hour: hour.UnixMilli(), ReadCnt: 2, LikeCnt: -1},
+		{Biz: "article", BizId: 1, Hour: hour.Add(time.Hour).UnixMilli(), CollectCnt: 1},
+	})).Return(nil)
+	repo := NewStatsRepository(d)
+	err := repo.Incr(context.Background(), []domain.StatsIncr{
+		{Biz: "article", BizId: 1, Time: hour.Add(time.Minute), StatsCnt: domain.StatsCnt{ReadCnt: 1}},
+		{Biz: "article", BizId: 1, Time: hour.Add(time.Minute * 59), StatsCnt: domain.StatsCnt{ReadCnt: 1}},
+		{Biz: "article", BizId: 1, Time: hour.Add(time.Minute * 30), StatsCnt: domain.StatsCnt{LikeCnt: -1}},
+		{Biz: "article", BizId: 1, Time: hour.Add(time.Hour), StatsCnt: domain.StatsCnt{CollectCnt: 1}},
+	})
+	assert.NoError(t, err)
+}
+
+func TestHourlyStatsRepository_Series(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+	testcases := []struct {
+		name        string
+		mock        func(ctrl *gomock.Controller) dao.StatsDAO
+		bizIds      []int64
+		start       time.Time
+		end         time.Time
+		granularity domain.StatsGranularity
+		wantRes     []domain.StatsPoint
+		wantErr     error
+	}{
+		{
+			name: "按天的把小时加起来，没有计数的天也有",
+			mock: func(ctrl *gomock.Controller) dao.StatsDAO {
+				d := daomocks.NewMockStatsDAO(ctrl)
+				d.EXPECT().SumByHour(gomock.Any(), "article", []int64{1, 2},
+					day.UnixMilli(), day.AddDate(0, 0, 3).UnixMilli()).
+					Return([]dao.InteractiveHourlyStats{
+						{Hour: day.Add(time.Hour).UnixMilli(), ReadCnt: 3, LikeCnt: 1},
+						{Hour: day.Add(time.Hour * 23).UnixMilli(), ReadCnt: 2},
+						{Hour: day.AddDate(0, 0, 2).UnixMilli(), ReadCnt: 1, CollectCnt: 1},
+					}, nil)
+				return d
+			},
+			bizIds:      []int64{1, 2},
+			start:       day,
+			end:         day.AddDate(0, 0, 3),
+			granularity: domain.StatsGranularityDay,
+			wantRes: []domain.StatsPoint{
+				{Time: day, StatsCnt: domain.StatsCnt{ReadCnt: 5, LikeCnt: 1}},
+				{Time: day.AddDate(0, 0, 1)},
+				{Time: day.AddDate(0, 0, 2), StatsCnt: domain.StatsCnt{ReadCnt: 1, CollectCnt: 1}},
+			},
+		},
+		{
+			name: "按小时的，开始时间往前取整",
+			mock: func(ctrl *gomock.Controller) dao.StatsDAO {
+				d := daomocks.NewMockStatsDAO(ctrl)
+				d.EXPECT().SumByHour(gomock.Any(), "article", []int64{1},
+					day.UnixMilli(), day.Add(time.Hour*2).UnixMilli()).
+					Return([]dao.InteractiveHourlyStats{
+						{Hour: day.Add(time.Hour).UnixMilli(), ReadCnt: 3},
+					}, nil)
+				return d
+			},
+			bizIds:      []int64{1},
+			start:       day.Add(time.Minute * 10),
+			end:         day.Add(time.Hour * 2),
+			granularity: domain.StatsGranularityHour,
+			wantRes: []domain.StatsPoint{
+				{Time: day},
+				{Time: day.Add(time.Hour), StatsCnt: domain.StatsCnt{ReadCnt: 3}},
+			},
+		},
+		{
+			name: "没有资源不用查",
+			mock: func(ctrl *gomock.Controller) dao.StatsDAO {
+				return daomocks.NewMockStatsDAO(ctrl)
+			},
+			start:       day,
+			end:         day.AddDate(0, 0, 1),
+			granularity: domain.StatsGranularityDay,
+			wantRes:     []domain.StatsPoint{{Time: day}},
+		},
+		{
+			name: "数据库错误",
+			mock: func(ctrl *gomock.Controller) dao.StatsDAO {
+				d := daomocks.NewMockStatsDAO(ctrl)
+				d.EXPECT().SumByHour(gomock.Any(), "article", []int64{1}, gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("db error"))
+				return d
+			},
+			bizIds:      []int64{1},
+			start:       day,
+			end:         day.AddDate(0, 0, 1),
+			granularity: domain.StatsGranularityDay,
+			wantErr:     errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := NewStatsRepository(tc.mock(ctrl))
+			res, err := repo.Series(context.Background(), "article", tc.bizIds, tc.start, tc.end, tc.granularity)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
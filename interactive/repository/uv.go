@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+)
+
+// UvRepository 每个资源有多少人读过。读者先记在 Redis 的 HyperLogLog 里面，定时把人数写进数据库
+//
+//go:generate mockgen -source=./uv.go -package=repomocks -destination=mocks/uv.mock.go UvRepository
+type UvRepository interface {
+	AddVisits(ctx context.Context, visits []domain.Visit) error
+	// PersistUv 把最多 limit 个有新读者的资源的人数写进数据库，返回写了多少个
+	PersistUv(ctx context.Context, limit int) (int, error)
+}
+
+type CachedUvRepository struct {
+	dao   dao.InteractiveDAO
+	cache cache.InteractiveCache
+	uv    cache.UvCache
+	l     logger.Logger
+}
+
+func NewUvRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache,
+	uv cache.UvCache, l logger.Logger) UvRepository {
+	return &CachedUvRepository{
+		dao:   dao,
+		cache: cache,
+		uv:    uv,
+		l:     l,
+	}
+}
+
+func (r *CachedUvRepository) AddVisits(ctx context.Context, visits []domain.Visit) error {
+	return r.uv.Add(ctx, visits)
+}
+
+func (r *CachedUvRepository) PersistUv(ctx context.Context, limit int) (int, error) {
+	cnts, err := r.uv.GetDirty(ctx, limit)
+	if err != nil || len(cnts) == 0 {
+		return 0, err
+	}
+	type key struct {
+		biz   string
+		bizId int64
+	}
+	// 同一个资源跨天了会有两条，总数是一样的
+	totals := make(map[key]int64, len(cnts))
+	dailies := make([]dao.InteractiveDailyUv, 0, len(cnts))
+	for _, cnt := range cnts {
+		k := key{biz: cnt.Biz, bizId: cnt.BizId}
+		totals[k] = max(totals[k], cnt.UvCnt)
+		dailies = append(dailies, dao.InteractiveDailyUv{
+			Biz:   cnt.Biz,
+			BizId: cnt.BizId,
+			Date:  cnt.Date,
+			UvCnt: cnt.DailyUvCnt,
+		})
+	}
+	intrs := make([]dao.Interactive, 0, len(totals))
+	for k, uvCnt := range totals {
+		intrs = append(intrs, dao.Interactive{
+			Biz:   k.biz,
+			BizId: k.bizId,
+			UvCnt: uvCnt,
+		})
+	}
+	if err = r.dao.SaveUvCnt(ctx, intrs, dailies); err != nil {
+		// 标记还在，下一次再写
+		return 0, err
+	}
+	if err = r.uv.ClearDirty(ctx, cnts); err != nil {
+		// 已经写进去了，下一次再写一遍一样的人数也没关系
+		r.l.Error("移出 UV 标记失败", logger.Error(err))
+	}
+	for _, intr := range intrs {
+		if err = r.cache.SetUvCntIfPresent(ctx, intr.Biz, intr.BizId, intr.UvCnt); err != nil {
+			// 缓存过期之后会从数据库加载新的
+			r.l.Error("更新缓存的 UV 失败", logger.Error(err),
+				logger.String("biz", intr.Biz), logger.Int64("bizId", intr.BizId))
+		}
+	}
+	return len(cnts), nil
+}
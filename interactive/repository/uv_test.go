@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestCachedUvRepository_PersistUv(t *testing.T) {
+	cnts := []domain.UvCnt{
+		{Biz: "article", BizId: 1, Date: "20261018", DailyUvCnt: 3, UvCnt: 10},
+		// 跨天了，总数以大的为准
+		{Biz: "article", BizId: 1, Date: "20261019", DailyUvCnt: 2, UvCnt: 11},
+	}
+	dailies := []dao.InteractiveDailyUv{
+		{Biz: "article", BizId: 1, Date: "20261018", UvCnt: 3},
+		{Biz: "article", BizId: 1, Date: "20261019", UvCnt: 2},
+	}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache)
+		wantCnt int
+		wantErr error
+	}{
+		{
+			name: "写进数据库，更新缓存",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				uv := cachemocks.NewMockUvCache(ctrl)
+				uv.EXPECT().GetDirty(gomock.Any(), 2).Return(cnts, nil)
+				d.EXPECT().SaveUvCnt(gomock.Any(), []dao.Interactive{
+					{Biz: "article", BizId: 1, UvCnt: 11},
+				}, dailies).Return(nil)
+				uv.EXPECT().ClearDirty(gomock.Any(), cnts).Return(nil)
+				c.EXPECT().SetUvCntIfPresent(gomock.Any(), "article", int64(1), int64(11)).Return(nil)
+				return d, c, uv
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "移出标记失败，下一次再写一遍",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				uv := cachemocks.NewMockUvCache(ctrl)
+				uv.EXPECT().GetDirty(gomock.Any(), 2).Return(cnts, nil)
+				d.EXPECT().SaveUvCnt(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				uv.EXPECT().ClearDirty(gomock.Any(), cnts).Return(errors.New("redis error"))
+				c.EXPECT().SetUvCntIfPresent(gomock.Any(), "article", int64(1), int64(11)).Return(nil)
+				return d, c, uv
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "没有新读者",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache) {
+				uv := cachemocks.NewMockUvCache(ctrl)
+				uv.EXPECT().GetDirty(gomock.Any(), 2).Return(nil, nil)
+				return daomocks.NewMockInteractiveDAO(ctrl), cachemocks.NewMockInteractiveCache(ctrl), uv
+			},
+		},
+		{
+			name: "写数据库失败，标记留着",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				uv := cachemocks.NewMockUvCache(ctrl)
+				uv.EXPECT().GetDirty(gomock.Any(), 2).Return(cnts, nil)
+				d.EXPECT().SaveUvCnt(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+				return d, cachemocks.NewMockInteractiveCache(ctrl), uv
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name: "更新缓存失败不影响结果",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.UvCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				uv := cachemocks.NewMockUvCache(ctrl)
+				uv.EXPECT().GetDirty(gomock.Any(), 2).Return(cnts[:1], nil)
+				d.EXPECT().SaveUvCnt(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				uv.EXPECT().ClearDirty(gomock.Any(), cnts[:1]).Return(nil)
+				c.EXPECT().SetUvCntIfPresent(gomock.Any(), "article", int64(1), int64(10)).
+					Return(errors.New("redis error"))
+				return d, c, uv
+			},
+			wantCnt: 1,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, uv := tc.mock(ctrl)
+			repo := NewUvRepository(d, c, uv, &logger.NopLogger{})
+			cnt, err := repo.PersistUv(context.Background(), 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	"red-feed/interactive/repository/dao"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// CntFlushRepository 把写回模式下攒在 Redis 里面的计数变化写回数据库
+//
+//go:generate mockgen -source=./write_behind.go -package=repomocks -destination=mocks/write_behind.mock.go CntFlushRepository
+type CntFlushRepository interface {
+	// FlushCnt 领取最多 limit 个资源的计数变化写回数据库
+	FlushCnt(ctx context.Context, limit int) (FlushResult, error)
+	// PendingCnt 还有多少个资源的计数变化没有写回
+	PendingCnt(ctx context.Context) (int64, error)
+	// CleanFlushed 删掉 before 之前写回的去重记录，一次最多删 limit 条，返回删了多少条
+	CleanFlushed(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// FlushResult 一次写回的结果。
+// Duplicated 是之前已经写回过、只是 Redis 没有清理掉的，Failed 的留在 Redis 里面下一次再写
+type FlushResult struct {
+	Claimed    int
+	Applied    int
+	Duplicated int
+	Failed     int
+}
+
+// WriteBehindInteractiveRepository 写回模式：点赞、收藏记录照样写数据库，
+// 但是计数不再每次都去更新 interactives 表的同一行，而是先攒在 Redis 里面，定时写回。
+// 热门资源的点赞就不会在这一行的行锁上排队。
+// 数据库里面的计数会落后一个写回周期，Get 会把还没有写回的加上，GetByIds 不管
+type WriteBehindInteractiveRepository struct {
+	*CachedInteractiveRepository
+	delta cache.CntDeltaCache
+}
+
+func NewWriteBehindInteractiveRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache,
+	delta cache.CntDeltaCache, l logger.Logger) *WriteBehindInteractiveRepository {
+	return &WriteBehindInteractiveRepository{
+		CachedInteractiveRepository: &CachedInteractiveRepository{
+			dao:   dao,
+			cache: cache,
+			l:     l,
+		},
+		delta: delta,
+	}
+}
+
+func (r *WriteBehindInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId, uId int64) error {
+	changed, err := r.dao.InsertLikeRelation(ctx, biz, bizId, uId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
+		return err
+	}
+	r.addDelta(ctx, biz, bizId, domain.CntDelta{LikeCnt: 1})
+	go r.syncLikeCache(ctx, biz, bizId, uId, true)
+	return nil
+}
+
+func (r *WriteBehindInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId, uId int64) error {
+	changed, err := r.dao.DeleteLikeRelation(ctx, biz, bizId, uId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
+		return err
+	}
+	r.addDelta(ctx, biz, bizId, domain.CntDelta{LikeCnt: -1})
+	go r.syncLikeCache(ctx, biz, bizId, uId, false)
+	return nil
+}
+
+func (r *WriteBehindInteractiveRepository) SetReaction(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	change, err := r.dao.SetReaction(ctx, biz, bizId, uId, reaction, false)
+	if err != nil {
+		return err
+	}
+	r.addLikeDelta(ctx, biz, bizId, change)
+	r.syncReaction(ctx, biz, bizId, uId, change)
+	return nil
+}
+
+func (r *WriteBehindInteractiveRepository) DeleteReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	change, err := r.dao.DeleteReaction(ctx, biz, bizId, uId, false)
+	if err != nil {
+		return err
+	}
+	r.addLikeDelta(ctx, biz, bizId, change)
+	r.syncReaction(ctx, biz, bizId, uId, change)
+	return nil
+}
+
+// addLikeDelta 换表情的时候点赞数变了也要攒起来
+func (r *WriteBehindInteractiveRepository) addLikeDelta(ctx context.Context, biz string, bizId int64, change dao.ReactionChange) {
+	switch {
+	case change.Liked:
+		r.addDelta(ctx, biz, bizId, domain.CntDelta{LikeCnt: 1})
+	case change.Unliked:
+		r.addDelta(ctx, biz, bizId, domain.CntDelta{LikeCnt: -1})
+	}
+}
+
+func (r *WriteBehindInteractiveRepository) IncrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	changed, err := r.dao.InsertCollectRelation(ctx, biz, bizId, uId, cId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
+		return err
+	}
+	r.addDelta(ctx, biz, bizId, domain.CntDelta{CollectCnt: 1})
+	go r.syncCollectCache(ctx, biz, bizId, true)
+	return nil
+}
+
+func (r *WriteBehindInteractiveRepository) DecrCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	changed, err := r.dao.DeleteCollectRelation(ctx, biz, bizId, uId, cId)
+	// 重复点赞、收藏的不用动计数
+	if err != nil || !changed {
+		return err
+	}
+	r.addDelta(ctx, biz, bizId, domain.CntDelta{CollectCnt: -1})
+	go r.syncCollectCache(ctx, biz, bizId, false)
+	return nil
+}
+
+func (r *WriteBehindInteractiveRepository) Get(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	intr, err := r.cache.Get(ctx, biz, bizId)
+	if err == nil {
+		return intr, nil
+	}
+	intr, err = r.load(ctx, biz, bizId)
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	// 缓存里面的计数是跟着点赞、收藏实时更新的，从数据库加载的时候要把没写回的加上
+	delta, err := r.delta.Pending(ctx, biz, bizId)
+	if err != nil {
+		// 不回写缓存，免得缓存里面一直少了这部分
+		r.l.Error("查询没有写回的计数失败", logger.Error(err),
+			logger.String("biz", biz), logger.Int64("bizId", bizId))
+		return intr, nil
+	}
+	intr.LikeCnt += delta.LikeCnt
+	intr.CollectCnt += delta.CollectCnt
+	go func() {
+		setErr := r.cache.Set(ctx, biz, bizId, intr)
+		if setErr != nil {
+			r.l.Error("回写interactive cache失败", logger.String("biz", biz), logger.Error(setErr))
+		}
+	}()
+	return intr, nil
+}
+
+func (r *WriteBehindInteractiveRepository) FlushCnt(ctx context.Context, limit int) (FlushResult, error) {
+	flushes, err := r.delta.Claim(ctx, limit)
+	if err != nil {
+		return FlushResult{}, err
+	}
+	res := FlushResult{Claimed: len(flushes)}
+	for _, f := range flushes {
+		// 点赞之后又取消了，不用写数据库
+		if !f.Delta.IsZero() {
+			applied, err := r.dao.FlushCnt(ctx, f.FlushId, f.Biz, f.BizId, f.Delta.LikeCnt, f.Delta.CollectCnt)
+			if err != nil {
+				// 留在 Redis 里面，下一次用同一个 flush id 再写
+				res.Failed++
+				r.l.Error("写回计数失败", logger.Error(err),
+					logger.String("biz", f.Biz), logger.Int64("bizId", f.BizId))
+				continue
+			}
+			if applied {
+				res.Applied++
+			} else {
+				res.Duplicated++
+			}
+		}
+		if err = r.delta.Finish(ctx, f); err != nil {
+			// 下一次会再写一次，数据库那边按照 flush id 去重
+			r.l.Error("清理写回的计数失败", logger.Error(err),
+				logger.String("biz", f.Biz), logger.Int64("bizId", f.BizId))
+		}
+	}
+	return res, nil
+}
+
+func (r *WriteBehindInteractiveRepository) PendingCnt(ctx context.Context) (int64, error) {
+	return r.delta.PendingCnt(ctx)
+}
+
+// addDelta 记录失败的时候点赞、收藏记录已经写进去了，用户重试也不会再记一次，计数会少一点，等对账修复
+func (r *WriteBehindInteractiveRepository) addDelta(ctx context.Context, biz string, bizId int64, delta domain.CntDelta) {
+	if err := r.delta.Add(ctx, biz, bizId, delta); err != nil {
+		r.l.Error("记录计数变化失败", logger.Error(err),
+			logger.String("biz", biz), logger.Int64("bizId", bizId))
+	}
+}
+
+func (r *WriteBehindInteractiveRepository) CleanFlushed(ctx context.Context, before time.Time, limit int) (int64, error) {
+	return r.dao.DeleteFlushBefore(ctx, before.UnixMilli(), limit)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository/cache"
+	cachemocks "red-feed/interactive/repository/cache/mocks"
+	"red-feed/interactive/repository/dao"
+	daomocks "red-feed/interactive/repository/dao/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestWriteBehindInteractiveRepository_FlushCnt(t *testing.T) {
+	applied := domain.CntFlush{FlushId: "f1", Biz: "article", BizId: 1, Delta: domain.CntDelta{LikeCnt: 3}}
+	duplicated := domain.CntFlush{FlushId: "f2", Biz: "article", BizId: 2, Delta: domain.CntDelta{CollectCnt: -1}}
+	failed := domain.CntFlush{FlushId: "f3", Biz: "article", BizId: 3, Delta: domain.CntDelta{LikeCnt: 1}}
+	zero := domain.CntFlush{FlushId: "f4", Biz: "article", BizId: 4}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntDeltaCache)
+		wantRes FlushResult
+		wantErr error
+	}{
+		{
+			name: "写回成功、重复写回、写回失败和没有变化的",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				dc.EXPECT().Claim(gomock.Any(), 10).
+					Return([]domain.CntFlush{applied, duplicated, failed, zero}, nil)
+				d.EXPECT().FlushCnt(gomock.Any(), "f1", "article", int64(1), int64(3), int64(0)).
+					Return(true, nil)
+				d.EXPECT().FlushCnt(gomock.Any(), "f2", "article", int64(2), int64(0), int64(-1)).
+					Return(false, nil)
+				d.EXPECT().FlushCnt(gomock.Any(), "f3", "article", int64(3), int64(1), int64(0)).
+					Return(false, errors.New("db error"))
+				// 失败的留着下一次再写，其它的都要清理掉
+				dc.EXPECT().Finish(gomock.Any(), applied).Return(nil)
+				dc.EXPECT().Finish(gomock.Any(), duplicated).Return(nil)
+				dc.EXPECT().Finish(gomock.Any(), zero).Return(nil)
+				return d, dc
+			},
+			wantRes: FlushResult{Claimed: 4, Applied: 1, Duplicated: 1, Failed: 1},
+		},
+		{
+			name: "清理失败不影响结果",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				dc.EXPECT().Claim(gomock.Any(), 10).Return([]domain.CntFlush{applied}, nil)
+				d.EXPECT().FlushCnt(gomock.Any(), "f1", "article", int64(1), int64(3), int64(0)).
+					Return(true, nil)
+				dc.EXPECT().Finish(gomock.Any(), applied).Return(errors.New("redis error"))
+				return d, dc
+			},
+			wantRes: FlushResult{Claimed: 1, Applied: 1},
+		},
+		{
+			name: "领取失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.CntDeltaCache) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				dc := cachemocks.NewMockCntDeltaCache(ctrl)
+				dc.EXPECT().Claim(gomock.Any(), 10).Return(nil, errors.New("redis error"))
+				return d, dc
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, dc := tc.mock(ctrl)
+			repo := NewWriteBehindInteractiveRepository(d, nil, dc, &logger.NopLogger{})
+			res, err := repo.FlushCnt(context.Background(), 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+)
+
+var (
+	ErrCollectionNotFound = errors.New("收藏夹不存在")
+	ErrDefaultCollection  = errors.New("默认收藏夹不能删除")
+)
+
+// defaultCollectionName 自动创建的默认收藏夹的名字
+const defaultCollectionName = "默认收藏夹"
+
+//go:generate mockgen -source=./collection.go -package=svcmocks -destination=mocks/collection.mock.go CollectionService
+type CollectionService interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Update 修改名字、描述和是否公开
+	Update(ctx context.Context, c domain.Collection) error
+	// Delete 删除收藏夹，里面的收藏也一起取消
+	Delete(ctx context.Context, uid int64, id int64) error
+	// List 自己的收藏夹，默认收藏夹在最前面
+	List(ctx context.Context, uid int64) ([]domain.Collection, error)
+	// ListPublic 别人公开的收藏夹
+	ListPublic(ctx context.Context, uid int64) ([]domain.Collection, error)
+	// ListItems 收藏夹里面的收藏，别人的收藏夹只有公开了才能看
+	ListItems(ctx context.Context, uid int64, cid int64, offset int, limit int) ([]domain.CollectionItem, error)
+	// Move 把收藏挪到另一个收藏夹，返回挪动的数量
+	Move(ctx context.Context, uid int64, from int64, to int64, biz string, bizIds []int64) (int64, error)
+}
+
+type collectionService struct {
+	repo repository.CollectionRepository
+}
+
+func NewCollectionService(repo repository.CollectionRepository) CollectionService {
+	return &collectionService{
+		repo: repo,
+	}
+}
+
+func (s *collectionService) Create(ctx context.Context, c domain.Collection) (int64, error) {
+	c.IsDefault = false
+	return s.repo.Create(ctx, c)
+}
+
+func (s *collectionService) Update(ctx context.Context, c domain.Collection) error {
+	err := s.repo.Update(ctx, c)
+	if err == repository.ErrCollectionNotFound {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (s *collectionService) Delete(ctx context.Context, uid int64, id int64) error {
+	c, err := ownedCollection(ctx, s.repo, uid, id)
+	if err != nil {
+		return err
+	}
+	if c.IsDefault {
+		return ErrDefaultCollection
+	}
+	err = s.repo.Delete(ctx, id, uid)
+	if err == repository.ErrCollectionNotFound {
+		return ErrCollectionNotFound
+	}
+	return err
+}
+
+func (s *collectionService) List(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	// 保证用户至少能看到默认收藏夹
+	_, err := s.repo.FindDefault(ctx, uid, defaultCollectionName)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindByUid(ctx, uid, false)
+}
+
+func (s *collectionService) ListPublic(ctx context.Context, uid int64) ([]domain.Collection, error) {
+	return s.repo.FindByUid(ctx, uid, true)
+}
+
+func (s *collectionService) ListItems(ctx context.Context, uid int64, cid int64,
+	offset int, limit int) ([]domain.CollectionItem, error) {
+	c, err := s.repo.GetById(ctx, cid)
+	if err == repository.ErrCollectionNotFound {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 私密的收藏夹对别人来说就是不存在
+	if c.Uid != uid && !c.Public {
+		return nil, ErrCollectionNotFound
+	}
+	return s.repo.ListItems(ctx, cid, offset, limit)
+}
+
+func (s *collectionService) Move(ctx context.Context, uid int64, from int64, to int64,
+	biz string, bizIds []int64) (int64, error) {
+	if len(bizIds) == 0 || from == to {
+		return 0, nil
+	}
+	if _, err := ownedCollection(ctx, s.repo, uid, from); err != nil {
+		return 0, err
+	}
+	if _, err := ownedCollection(ctx, s.repo, uid, to); err != nil {
+		return 0, err
+	}
+	return s.repo.MoveItems(ctx, uid, from, to, biz, bizIds)
+}
+
+// ownedCollection 查询自己的收藏夹，别人的也当作不存在
+func ownedCollection(ctx context.Context, repo repository.CollectionRepository,
+	uid int64, id int64) (domain.Collection, error) {
+	c, err := repo.GetById(ctx, id)
+	if err == repository.ErrCollectionNotFound {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	if c.Uid != uid {
+		return domain.Collection{}, ErrCollectionNotFound
+	}
+	return c, nil
+}
+
+// resolveCollection 收藏的时候不传收藏夹就放进默认收藏夹，传了要检查是不是自己的
+func resolveCollection(ctx context.Context, repo repository.CollectionRepository,
+	uid int64, cid int64) (int64, error) {
+	if cid > 0 {
+		_, err := ownedCollection(ctx, repo, uid, cid)
+		return cid, err
+	}
+	c, err := repo.FindDefault(ctx, uid, defaultCollectionName)
+	if err != nil {
+		return 0, err
+	}
+	return c.Id, nil
+}
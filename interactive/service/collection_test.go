@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+	repomocks "red-feed/interactive/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestCollectionService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockCollectionRepository(ctrl)
+	// 默认收藏夹只能自动创建
+	repo.EXPECT().Create(gomock.Any(), domain.Collection{Uid: 2, Name: "Go"}).Return(int64(3), nil)
+	svc := NewCollectionService(repo)
+	id, err := svc.Create(context.Background(), domain.Collection{Uid: 2, Name: "Go", IsDefault: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+}
+
+func TestCollectionService_Delete(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.CollectionRepository
+		wantErr error
+	}{
+		{
+			name: "删除成功",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 2}, nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(3), int64(2)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "默认收藏夹不能删除",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(3)).
+					Return(domain.Collection{Id: 3, Uid: 2, IsDefault: true}, nil)
+				return repo
+			},
+			wantErr: ErrDefaultCollection,
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 4}, nil)
+				return repo
+			},
+			wantErr: ErrCollectionNotFound,
+		},
+		{
+			name: "并发删掉了",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 2}, nil)
+				repo.EXPECT().Delete(gomock.Any(), int64(3), int64(2)).Return(repository.ErrCollectionNotFound)
+				return repo
+			},
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCollectionService(tc.mock(ctrl))
+			err := svc.Delete(context.Background(), 2, 3)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCollectionService_List(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.CollectionRepository
+		want    []domain.Collection
+		wantErr error
+	}{
+		{
+			name: "没有默认收藏夹的先创建",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().FindDefault(gomock.Any(), int64(2), defaultCollectionName).
+					Return(domain.Collection{Id: 1, Uid: 2, IsDefault: true}, nil)
+				repo.EXPECT().FindByUid(gomock.Any(), int64(2), false).Return([]domain.Collection{
+					{Id: 1, Uid: 2, IsDefault: true},
+					{Id: 3, Uid: 2},
+				}, nil)
+				return repo
+			},
+			want: []domain.Collection{
+				{Id: 1, Uid: 2, IsDefault: true},
+				{Id: 3, Uid: 2},
+			},
+		},
+		{
+			name: "创建默认收藏夹失败",
+			mock: func(ctrl *gomock.Controller) repository.CollectionRepository {
+				repo := repomocks.NewMockCollectionRepository(ctrl)
+				repo.EXPECT().FindDefault(gomock.Any(), int64(2), defaultCollectionName).
+					Return(domain.Collection{}, errors.New("db error"))
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewCollectionService(tc.mock(ctrl))
+			res, err := svc.List(context.Background(), 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestInteractiveService_Collect(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository)
+		cid     int64
+		wantErr error
+	}{
+		{
+			name: "不传收藏夹，放进默认收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				collection := repomocks.NewMockCollectionRepository(ctrl)
+				collection.EXPECT().FindDefault(gomock.Any(), int64(2), defaultCollectionName).
+					Return(domain.Collection{Id: 1, Uid: 2, IsDefault: true}, nil)
+				repo.EXPECT().IncrCollect(gomock.Any(), "article", int64(10), int64(2), int64(1)).Return(nil)
+				return repo, collection
+			},
+		},
+		{
+			name: "收藏到别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				collection := repomocks.NewMockCollectionRepository(ctrl)
+				collection.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 4}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), collection
+			},
+			cid:     3,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collection := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, collection, nil, domain.DefaultReactions, collectBizs(), &logger.NopLogger{})
+			err := svc.Collect(context.Background(), "article", 10, 2, tc.cid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveService_CancelCollect(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository)
+		cid     int64
+		wantErr error
+	}{
+		{
+			name: "不传收藏夹，不管在哪个收藏夹都取消",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().DecrCollect(gomock.Any(), "article", int64(10), int64(2), int64(0)).Return(nil)
+				return repo, repomocks.NewMockCollectionRepository(ctrl)
+			},
+		},
+		{
+			name: "指定自己的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				collection := repomocks.NewMockCollectionRepository(ctrl)
+				collection.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 2}, nil)
+				repo.EXPECT().DecrCollect(gomock.Any(), "article", int64(10), int64(2), int64(3)).Return(nil)
+				return repo, collection
+			},
+			cid: 3,
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) (repository.InteractiveRepository, repository.CollectionRepository) {
+				collection := repomocks.NewMockCollectionRepository(ctrl)
+				collection.EXPECT().GetById(gomock.Any(), int64(3)).Return(domain.Collection{Id: 3, Uid: 4}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), collection
+			},
+			cid:     3,
+			wantErr: ErrCollectionNotFound,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, collection := tc.mock(ctrl)
+			svc := NewInteractiveService(repo, collection, nil, domain.DefaultReactions, collectBizs(), &logger.NopLogger{})
+			err := svc.CancelCollect(context.Background(), "article", 10, 2, tc.cid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func collectBizs() *domain.BizRegistry {
+	return domain.NewBizRegistry(domain.BizConfig{
+		Biz:      "article",
+		Counters: []domain.Counter{domain.CounterCollect},
+	})
+}
@@ -6,6 +6,14 @@ import (
 	"red-feed/interactive/domain"
 	"red-feed/interactive/repository"
 	"red-feed/pkg/logger"
+	"time"
+)
+
+const (
+	// statsMaxHourRange 按小时的时间序列最多查多久，免得点太多
+	statsMaxHourRange = time.Hour * 24 * 7
+	// statsMaxDayRange 按天的时间序列和汇总最多查多久
+	statsMaxDayRange = time.Hour * 24 * 90
 )
 
 //go:generate mockgen -source=./interactive.go -package=svcmocks -destination=mocks/interactive.mock.go InteractiveService
@@ -14,17 +22,89 @@ type InteractiveService interface {
 	Like(ctx context.Context, biz string, bizId, uId int64) error                                   // 点赞
 	CancelLike(ctx context.Context, biz string, bizId, uId int64) error                             // 取消点赞
 	Collect(ctx context.Context, biz string, bizId, uId, cId int64) error                           // 收藏
-	CancelCollect(ctx context.Context, biz string, bizId, uId, cId int64) error                     // 取消收藏
+	CancelCollect(ctx context.Context, biz string, bizId, uId, cId int64) error                     // 取消收藏，不传收藏夹就从所在的收藏夹取消
 	Get(ctx context.Context, biz string, bizId int64, uId int64) (domain.Interactive, error)        // 获取收藏点赞信息
 	GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) // 拿一批文章的interactive信息，用于ranking计算score
+	// GetUserStateByIds 批量查询用户是否点赞、收藏了，每个 bizId 都有结果，用于列表页展示
+	GetUserStateByIds(ctx context.Context, biz string, uId int64, bizIds []int64) (map[int64]domain.UserState, error)
+	// ListLikers 谁点赞了这个资源，最近点赞的在前面
+	ListLikers(ctx context.Context, biz string, bizId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error)
+	// ListLikedByUser 用户点赞过的资源，最近点赞的在前面
+	ListLikedByUser(ctx context.Context, biz string, uId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error)
+	// GetStats 这些资源在 [start, end) 之间每个小时或者每一天新增的计数，几个资源的加在一起
+	GetStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+		granularity domain.StatsGranularity) ([]domain.StatsPoint, error)
+	// GetStatsByIds 这些资源在 [start, end) 之间各自新增的计数，没有计数的不在结果里
+	GetStatsByIds(ctx context.Context, biz string, bizIds []int64, start, end time.Time) (map[int64]domain.StatsCnt, error)
+	// React 一个用户对一个资源只有一个表情，换表情会替换掉原来的。默认的表情就是点赞
+	React(ctx context.Context, biz string, bizId, uId int64, reaction string) error
+	// CancelReaction 取消用户的表情，包括点赞
+	CancelReaction(ctx context.Context, biz string, bizId, uId int64) error
+	// ListReactions 可以用的表情
+	ListReactions(ctx context.Context) ([]domain.Reaction, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
-	l    logger.Logger
+	repo       repository.InteractiveRepository
+	collection repository.CollectionRepository
+	stats      repository.StatsRepository
+	reactions  domain.Reactions
+	bizs       *domain.BizRegistry
+	l          logger.Logger
+}
+
+func (s *interactiveService) React(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	if !s.reactions.Contains(reaction) {
+		return domain.ErrInvalidReaction
+	}
+	if err := s.bizs.CheckExist(ctx, biz, bizId, domain.CounterLike); err != nil {
+		return err
+	}
+	// 换成别的表情就不算点赞了，换成点赞就去掉别的表情
+	return s.repo.SetReaction(ctx, biz, bizId, uId, reaction)
+}
+
+func (s *interactiveService) CancelReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	if err := s.bizs.Check(biz, domain.CounterLike); err != nil {
+		return err
+	}
+	return s.repo.DeleteReaction(ctx, biz, bizId, uId)
+}
+
+func (s *interactiveService) ListReactions(ctx context.Context) ([]domain.Reaction, error) {
+	return s.reactions, nil
+}
+
+func (s *interactiveService) GetStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+	granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	if _, err := s.bizs.Get(biz); err != nil {
+		return nil, err
+	}
+	maxRange := statsMaxDayRange
+	if granularity == domain.StatsGranularityHour {
+		maxRange = statsMaxHourRange
+	}
+	if !granularity.Valid() || !end.After(start) || end.Sub(start) > maxRange {
+		return nil, domain.ErrInvalidStatsRange
+	}
+	return s.stats.Series(ctx, biz, bizIds, start, end, granularity)
+}
+
+func (s *interactiveService) GetStatsByIds(ctx context.Context, biz string, bizIds []int64,
+	start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	if _, err := s.bizs.Get(biz); err != nil {
+		return nil, err
+	}
+	if !end.After(start) || end.Sub(start) > statsMaxDayRange {
+		return nil, domain.ErrInvalidStatsRange
+	}
+	return s.stats.SumByIds(ctx, biz, bizIds, start, end)
 }
 
 func (s *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	if _, err := s.bizs.Get(biz); err != nil {
+		return nil, err
+	}
 	intrs, err := s.repo.GetByIds(ctx, biz, bizIds)
 	if err != nil {
 		return nil, err
@@ -36,57 +116,153 @@ func (s *interactiveService) GetByIds(ctx context.Context, biz string, bizIds []
 	return res, nil
 }
 
+func (s *interactiveService) GetUserStateByIds(ctx context.Context, biz string, uId int64, bizIds []int64) (map[int64]domain.UserState, error) {
+	if _, err := s.bizs.Get(biz); err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.UserState, len(bizIds))
+	for _, id := range bizIds {
+		res[id] = domain.UserState{BizId: id}
+	}
+	// 匿名读者不可能点赞和收藏，不用查
+	if uId <= 0 || len(bizIds) == 0 {
+		return res, nil
+	}
+	states, err := s.repo.GetUserStateByIds(ctx, biz, uId, bizIds)
+	if err != nil {
+		return nil, err
+	}
+	for id, state := range states {
+		res[id] = state
+	}
+	return res, nil
+}
+
+func (s *interactiveService) ListLikers(ctx context.Context, biz string, bizId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	if err := s.bizs.Check(biz, domain.CounterLike); err != nil {
+		return nil, err
+	}
+	return s.repo.ListLikers(ctx, biz, bizId, cursor, limit)
+}
+
+func (s *interactiveService) ListLikedByUser(ctx context.Context, biz string, uId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	if err := s.bizs.Check(biz, domain.CounterLike); err != nil {
+		return nil, err
+	}
+	return s.repo.ListLikedByUser(ctx, biz, uId, cursor, limit)
+}
+
 func (s *interactiveService) Like(ctx context.Context, biz string, bizId, uId int64) error {
-	return s.repo.IncrLike(ctx, biz, bizId, uId)
+	if err := s.bizs.CheckExist(ctx, biz, bizId, domain.CounterLike); err != nil {
+		return err
+	}
+	// 点赞也是一种表情，要把原来别的表情去掉
+	return s.repo.SetReaction(ctx, biz, bizId, uId, domain.ReactionLike)
 }
 
 func (s *interactiveService) CancelLike(ctx context.Context, biz string, bizId, uId int64) error {
+	if err := s.bizs.Check(biz, domain.CounterLike); err != nil {
+		return err
+	}
 	return s.repo.DecrLike(ctx, biz, bizId, uId)
 }
 
 func (s *interactiveService) Collect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	if err := s.bizs.CheckExist(ctx, biz, bizId, domain.CounterCollect); err != nil {
+		return err
+	}
+	cId, err := resolveCollection(ctx, s.collection, uId, cId)
+	if err != nil {
+		return err
+	}
 	return s.repo.IncrCollect(ctx, biz, bizId, uId, cId)
 }
 
 func (s *interactiveService) CancelCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	if err := s.bizs.Check(biz, domain.CounterCollect); err != nil {
+		return err
+	}
+	// 一个资源只会在一个收藏夹里面，不传收藏夹就是不管在哪个收藏夹都取消
+	if cId > 0 {
+		if _, err := ownedCollection(ctx, s.collection, uId, cId); err != nil {
+			return err
+		}
+	}
 	return s.repo.DecrCollect(ctx, biz, bizId, uId, cId)
 }
 
 func (s *interactiveService) Get(ctx context.Context, biz string, bizId int64, uId int64) (domain.Interactive, error) {
+	if _, err := s.bizs.Get(biz); err != nil {
+		return domain.Interactive{}, err
+	}
 	intr, err := s.repo.Get(ctx, biz, bizId)
 	if err != nil {
 		return domain.Interactive{}, err
 	}
+	// 默认的表情就是点赞，数量只记在 LikeCnt 上
+	reactionCnts := make(map[string]int64, len(intr.ReactionCnts)+1)
+	for reaction, cnt := range intr.ReactionCnts {
+		reactionCnts[reaction] = cnt
+	}
+	if intr.LikeCnt > 0 {
+		reactionCnts[domain.ReactionLike] = intr.LikeCnt
+	}
+	intr.ReactionCnts = reactionCnts
+	// 匿名读者不可能点赞和收藏，不用查
+	if uId <= 0 {
+		return intr, nil
+	}
+	// 每个 goroutine 只写自己的字段，错误等 Wait 之后再统一看
 	var eg errgroup.Group
 	eg.Go(func() error {
-		intr.Liked, err = s.repo.Liked(ctx, biz, bizId, uId)
-		return err
+		liked, er := s.repo.Liked(ctx, biz, bizId, uId)
+		intr.Liked = liked
+		return er
 	})
 	eg.Go(func() error {
-		intr.Collected, err = s.repo.Collected(ctx, biz, bizId, uId)
-		return err
+		collected, er := s.repo.Collected(ctx, biz, bizId, uId)
+		intr.Collected = collected
+		return er
+	})
+	eg.Go(func() error {
+		reaction, er := s.repo.Reaction(ctx, biz, bizId, uId)
+		intr.Reaction = reaction
+		return er
 	})
 	// 说明是登录过的，补充用户是否点赞或者
 	// 新的打印日志的形态 zap 本身就有这种用法
-	err = eg.Wait()
-	if err != nil {
-		// 这个查询失败只需要记录日志就可以，不需要中断执行
-		s.l.Error("查询用户是否点赞和收藏的信息失败",
+	if err = eg.Wait(); err != nil {
+		// 这个查询失败只需要记录日志就可以，计数照样返回，查到的部分也照样用
+		s.l.Error("查询用户是否点赞、收藏和表情的信息失败",
 			logger.String("biz", biz),
 			logger.Int64("bizId", bizId),
 			logger.Int64("uid", uId),
 			logger.Error(err))
 	}
-	return intr, err
+	if intr.Liked {
+		intr.Reaction = domain.ReactionLike
+	}
+	return intr, nil
 }
 
 func (s *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	if err := s.bizs.Check(biz, domain.CounterRead); err != nil {
+		return err
+	}
 	return s.repo.IncrReadCnt(ctx, biz, bizId)
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository, l logger.Logger) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository,
+	collection repository.CollectionRepository, stats repository.StatsRepository,
+	reactions domain.Reactions, bizs *domain.BizRegistry, l logger.Logger) InteractiveService {
 	return &interactiveService{
-		repo: repo,
-		l:    l,
+		repo:       repo,
+		collection: collection,
+		stats:      stats,
+		reactions:  reactions,
+		bizs:       bizs,
+		l:          l,
 	}
 }
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/repository"
+	repomocks "red-feed/interactive/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestInteractiveService_Get(t *testing.T) {
+	testcases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+		uid  int64
+
+		want    domain.Interactive
+		wantErr error
+	}{
+		{
+			name: "点过赞的，表情就是点赞",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "article", int64(10)).
+					Return(domain.Interactive{BizId: 10, LikeCnt: 3}, nil)
+				repo.EXPECT().Liked(gomock.Any(), "article", int64(10), int64(2)).Return(true, nil)
+				repo.EXPECT().Collected(gomock.Any(), "article", int64(10), int64(2)).Return(true, nil)
+				repo.EXPECT().Reaction(gomock.Any(), "article", int64(10), int64(2)).Return("", nil)
+				return repo
+			},
+			uid: 2,
+			want: domain.Interactive{BizId: 10, LikeCnt: 3, Liked: true, Collected: true,
+				Reaction: domain.ReactionLike, ReactionCnts: map[string]int64{domain.ReactionLike: 3}},
+		},
+		{
+			name: "查用户的状态失败，只记日志，查到的照样返回",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "article", int64(10)).
+					Return(domain.Interactive{BizId: 10, LikeCnt: 3}, nil)
+				repo.EXPECT().Liked(gomock.Any(), "article", int64(10), int64(2)).Return(false, errors.New("redis错误"))
+				repo.EXPECT().Collected(gomock.Any(), "article", int64(10), int64(2)).Return(true, nil)
+				repo.EXPECT().Reaction(gomock.Any(), "article", int64(10), int64(2)).Return("heart", nil)
+				return repo
+			},
+			uid: 2,
+			want: domain.Interactive{BizId: 10, LikeCnt: 3, Collected: true,
+				Reaction: "heart", ReactionCnts: map[string]int64{domain.ReactionLike: 3}},
+		},
+		{
+			name: "查计数失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "article", int64(10)).
+					Return(domain.Interactive{}, errors.New("db错误"))
+				return repo
+			},
+			uid:     2,
+			wantErr: errors.New("db错误"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractiveService(tc.mock(ctrl), nil, nil, domain.DefaultReactions,
+				domain.NewBizRegistry(domain.BizConfig{Biz: "article"}), &logger.NopLogger{})
+			intr, err := svc.Get(context.Background(), "article", 10, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, intr)
+		})
+	}
+}
@@ -16,23 +16,50 @@ import (
 var thirdPartySet = wire.NewSet(ioc.InitDB,
 	ioc.InitLogger,
 	ioc.InitKafka,
+	ioc.InitSyncProducer,
 	// 暂时不理会 consumer 怎么启动
 	ioc.InitRedis)
 
 var interactiveSvcProvider = wire.NewSet(
 	service.NewInteractiveService,
-	repository.NewInteractiveRepository,
+	ioc.InitReactions,
+	ioc.InitBizRegistry,
+	ioc.InitInteractiveRepository,
+	repository.NewWriteBehindInteractiveRepository,
+	repository.NewCollectionRepository,
+	repository.NewStatsRepository,
 	dao.NewInteractiveDAO,
+	dao.NewStatsDAO,
+	dao.NewCollectionDAO,
 	cache.NewRedisInteractiveCache,
+	cache.NewRedisCntDeltaCache,
 )
 
 func InitAPP() *App {
 	wire.Build(interactiveSvcProvider,
 		thirdPartySet,
 		events.NewInteractiveReadEventConsumer,
+		cache.NewRedisEventDedupCache,
+		cache.NewRedisReadLimitCache,
+		cache.NewRedisUvCache,
+		repository.NewUvRepository,
+		ioc.InitReadGuard,
+		events.NewInteractiveDeleteEventConsumer,
+		events.NewInteractiveStatsConsumer,
 		grpc.NewInteractiveServiceServer,
 		ioc.NewConsumers,
 		ioc.InitGRPCXServer,
+		ioc.InitRegistry,
+		ioc.InitCntFlushJob,
+		ioc.InitFlushCleanJob,
+		repository.NewCntReconcileRepository,
+		ioc.InitCntReconcileJob,
+		dao.NewOutboxDAO,
+		repository.NewInteractiveEventRepository,
+		events.NewKafkaInteractiveEventProducer,
+		ioc.InitOutboxRelayJob,
+		ioc.InitUvPersistJob,
+		ioc.InitJobs,
 		wire.Struct(new(App), "*"),
 	)
 	return new(App)
@@ -23,24 +23,53 @@ func InitAPP() *App {
 	db := ioc.InitDB()
 	interactiveDAO := dao.NewInteractiveDAO(db)
 	cmdable := ioc.InitRedis()
-	interactiveCache := cache.NewRedisInteractiveCache(cmdable)
+	bizRegistry := ioc.InitBizRegistry()
+	interactiveCache := cache.NewRedisInteractiveCache(cmdable, bizRegistry)
+	cntDeltaCache := cache.NewRedisCntDeltaCache(cmdable)
 	logger := ioc.InitLogger()
-	interactiveRepository := repository.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service.NewInteractiveService(interactiveRepository, logger)
-	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
-	server := ioc.InitGRPCXServer(interactiveServiceServer)
+	writeBehindInteractiveRepository := repository.NewWriteBehindInteractiveRepository(interactiveDAO, interactiveCache, cntDeltaCache, logger)
+	interactiveRepository := ioc.InitInteractiveRepository(interactiveDAO, interactiveCache, writeBehindInteractiveRepository, logger)
+	collectionDAO := dao.NewCollectionDAO(db)
+	collectionRepository := repository.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	statsDAO := dao.NewStatsDAO(db)
+	statsRepository := repository.NewStatsRepository(statsDAO)
+	reactions := ioc.InitReactions()
+	interactiveService := service.NewInteractiveService(interactiveRepository, collectionRepository, statsRepository, reactions, bizRegistry, logger)
+	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService, logger)
+	registry := ioc.InitRegistry()
+	server := ioc.InitGRPCXServer(interactiveServiceServer, registry, logger)
 	client := ioc.InitKafka()
-	consumer := events.NewInteractiveReadEventConsumer(client, logger, interactiveRepository)
-	v := ioc.NewConsumers(consumer)
+	uvCache := cache.NewRedisUvCache(cmdable)
+	uvRepository := repository.NewUvRepository(interactiveDAO, interactiveCache, uvCache, logger)
+	eventDedupCache := cache.NewRedisEventDedupCache(cmdable)
+	readLimitCache := cache.NewRedisReadLimitCache(cmdable)
+	readGuard := ioc.InitReadGuard(readLimitCache, logger)
+	consumer := events.NewInteractiveReadEventConsumer(client, logger, interactiveRepository, uvRepository, statsRepository, eventDedupCache, readGuard)
+	interactiveDeleteEventConsumer := events.NewInteractiveDeleteEventConsumer(client, interactiveRepository, logger)
+	interactiveStatsConsumer := events.NewInteractiveStatsConsumer(client, statsRepository, eventDedupCache, logger)
+	v := ioc.NewConsumers(consumer, interactiveDeleteEventConsumer, interactiveStatsConsumer)
+	cntFlushJob := ioc.InitCntFlushJob(writeBehindInteractiveRepository, logger)
+	flushCleanJob := ioc.InitFlushCleanJob(writeBehindInteractiveRepository, logger)
+	cntReconcileRepository := repository.NewCntReconcileRepository(interactiveDAO, interactiveCache, cntDeltaCache, logger)
+	cntReconcileJob := ioc.InitCntReconcileJob(cntReconcileRepository, logger)
+	outboxDAO := dao.NewOutboxDAO(db)
+	interactiveEventRepository := repository.NewInteractiveEventRepository(outboxDAO)
+	syncProducer := ioc.InitSyncProducer(client)
+	interactiveEventProducer := events.NewKafkaInteractiveEventProducer(syncProducer)
+	outboxRelayJob := ioc.InitOutboxRelayJob(interactiveEventRepository, interactiveEventProducer)
+	uvPersistJob := ioc.InitUvPersistJob(uvRepository, logger)
+	cron := ioc.InitJobs(logger, cntFlushJob, flushCleanJob, cntReconcileJob, outboxRelayJob, uvPersistJob)
 	app := &App{
 		server:    server,
 		consumers: v,
+		cron:      cron,
+		l:         logger,
 	}
 	return app
 }
 
 // wire.go:
 
-var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitRedis)
+var thirdPartySet = wire.NewSet(ioc.InitDB, ioc.InitLogger, ioc.InitKafka, ioc.InitSyncProducer, ioc.InitRedis)
 
-var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, repository.NewInteractiveRepository, dao.NewInteractiveDAO, cache.NewRedisInteractiveCache)
+var interactiveSvcProvider = wire.NewSet(service.NewInteractiveService, ioc.InitReactions, ioc.InitBizRegistry, ioc.InitInteractiveRepository, repository.NewWriteBehindInteractiveRepository, repository.NewCollectionRepository, repository.NewStatsRepository, dao.NewInteractiveDAO, dao.NewStatsDAO, dao.NewCollectionDAO, cache.NewRedisInteractiveCache, cache.NewRedisCntDeltaCache)
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"context"
+	intrv1 "red-feed/api/proto/gen/intr/v1"
+	"red-feed/interactive/domain"
+	igrpc "red-feed/interactive/grpc"
+	"red-feed/interactive/service"
+	"time"
+)
+
+var _ service.InteractiveService = (*RemoteInteractiveService)(nil)
+
+// RemoteInteractiveService 通过 gRPC 调用 interactive 服务，用起来和本地的 InteractiveService 一样
+type RemoteInteractiveService struct {
+	client intrv1.InteractiveServiceClient
+}
+
+func NewRemoteInteractiveService(client intrv1.InteractiveServiceClient) *RemoteInteractiveService {
+	return &RemoteInteractiveService{
+		client: client,
+	}
+}
+
+func (r *RemoteInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	_, err := r.client.IncrReadCnt(ctx, &intrv1.IncrReadCntRequest{Biz: biz, BizId: bizId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) Like(ctx context.Context, biz string, bizId, uId int64) error {
+	_, err := r.client.Like(ctx, &intrv1.LikeRequest{Biz: biz, BizId: bizId, Uid: uId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) CancelLike(ctx context.Context, biz string, bizId, uId int64) error {
+	_, err := r.client.CancelLike(ctx, &intrv1.CancelLikeRequest{Biz: biz, BizId: bizId, Uid: uId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) Collect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	_, err := r.client.Collect(ctx, &intrv1.CollectRequest{Biz: biz, BizId: bizId, Uid: uId, Cid: cId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) CancelCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	_, err := r.client.CancelCollect(ctx, &intrv1.CancelCollectRequest{Biz: biz, BizId: bizId, Uid: uId, Cid: cId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) Get(ctx context.Context, biz string, bizId int64, uId int64) (domain.Interactive, error) {
+	resp, err := r.client.Get(ctx, &intrv1.GetRequest{Biz: biz, BizId: bizId, Uid: uId})
+	if err != nil {
+		return domain.Interactive{}, r.fromStatus(err)
+	}
+	return r.toDomain(resp.GetIntr()), nil
+}
+
+func (r *RemoteInteractiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	resp, err := r.client.GetByIds(ctx, &intrv1.GetByIdsRequest{Biz: biz, BizIds: bizIds})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	res := make(map[int64]domain.Interactive, len(resp.GetIntrs()))
+	for k, v := range resp.GetIntrs() {
+		res[k] = r.toDomain(v)
+	}
+	return res, nil
+}
+
+func (r *RemoteInteractiveService) GetUserStateByIds(ctx context.Context, biz string, uId int64,
+	bizIds []int64) (map[int64]domain.UserState, error) {
+	resp, err := r.client.GetUserStateByIds(ctx, &intrv1.GetUserStateByIdsRequest{Biz: biz, Uid: uId, BizIds: bizIds})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	res := make(map[int64]domain.UserState, len(resp.GetStates()))
+	for k, v := range resp.GetStates() {
+		res[k] = domain.UserState{
+			BizId:     v.GetBizId(),
+			Liked:     v.GetLiked(),
+			Collected: v.GetCollected(),
+		}
+	}
+	return res, nil
+}
+
+func (r *RemoteInteractiveService) ListLikers(ctx context.Context, biz string, bizId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	resp, err := r.client.ListLikers(ctx, &intrv1.ListLikersRequest{
+		Biz:    biz,
+		BizId:  bizId,
+		Cursor: cursor.String(),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	return r.toLikeRecords(resp.GetRecords()), nil
+}
+
+func (r *RemoteInteractiveService) ListLikedByUser(ctx context.Context, biz string, uId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	resp, err := r.client.ListLikedByUser(ctx, &intrv1.ListLikedByUserRequest{
+		Biz:    biz,
+		Uid:    uId,
+		Cursor: cursor.String(),
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	return r.toLikeRecords(resp.GetRecords()), nil
+}
+
+func (r *RemoteInteractiveService) GetStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+	granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	resp, err := r.client.GetStats(ctx, &intrv1.GetStatsRequest{
+		Biz:         biz,
+		BizIds:      bizIds,
+		Start:       start.UnixMilli(),
+		End:         end.UnixMilli(),
+		Granularity: string(granularity),
+	})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	res := make([]domain.StatsPoint, 0, len(resp.GetPoints()))
+	for _, p := range resp.GetPoints() {
+		res = append(res, domain.StatsPoint{
+			Time:     time.UnixMilli(p.GetTime()),
+			StatsCnt: r.toStatsCnt(p.GetCnt()),
+		})
+	}
+	return res, nil
+}
+
+func (r *RemoteInteractiveService) GetStatsByIds(ctx context.Context, biz string, bizIds []int64,
+	start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	resp, err := r.client.GetStatsByIds(ctx, &intrv1.GetStatsByIdsRequest{
+		Biz:    biz,
+		BizIds: bizIds,
+		Start:  start.UnixMilli(),
+		End:    end.UnixMilli(),
+	})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	res := make(map[int64]domain.StatsCnt, len(resp.GetCnts()))
+	for k, v := range resp.GetCnts() {
+		res[k] = r.toStatsCnt(v)
+	}
+	return res, nil
+}
+
+func (r *RemoteInteractiveService) React(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	_, err := r.client.React(ctx, &intrv1.ReactRequest{Biz: biz, BizId: bizId, Uid: uId, Reaction: reaction})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) CancelReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	_, err := r.client.CancelReaction(ctx, &intrv1.CancelReactionRequest{Biz: biz, BizId: bizId, Uid: uId})
+	return r.fromStatus(err)
+}
+
+func (r *RemoteInteractiveService) ListReactions(ctx context.Context) ([]domain.Reaction, error) {
+	resp, err := r.client.ListReactions(ctx, &intrv1.ListReactionsRequest{})
+	if err != nil {
+		return nil, r.fromStatus(err)
+	}
+	res := make([]domain.Reaction, 0, len(resp.GetReactions()))
+	for _, v := range resp.GetReactions() {
+		res = append(res, domain.Reaction{
+			Key:   v.GetKey(),
+			Emoji: v.GetEmoji(),
+		})
+	}
+	return res, nil
+}
+
+// fromStatus 按照状态码和 ErrorInfo 的 reason 还原成原来的错误，错误信息改了也不影响
+func (r *RemoteInteractiveService) fromStatus(err error) error {
+	return igrpc.FromStatus(err)
+}
+
+func (r *RemoteInteractiveService) toDomain(intr *intrv1.Interactive) domain.Interactive {
+	return domain.Interactive{
+		Biz:          intr.GetBiz(),
+		BizId:        intr.GetBizId(),
+		ReadCnt:      intr.GetReadCnt(),
+		LikeCnt:      intr.GetLikeCnt(),
+		CollectCnt:   intr.GetCollectCnt(),
+		UvCnt:        intr.GetUvCnt(),
+		Liked:        intr.GetLiked(),
+		Collected:    intr.GetCollected(),
+		ReactionCnts: intr.GetReactionCnts(),
+		Reaction:     intr.GetReaction(),
+	}
+}
+
+func (r *RemoteInteractiveService) toLikeRecords(records []*intrv1.LikeRecord) []domain.LikeRecord {
+	res := make([]domain.LikeRecord, 0, len(records))
+	for _, v := range records {
+		res = append(res, domain.LikeRecord{
+			Id:    v.GetId(),
+			Uid:   v.GetUid(),
+			Biz:   v.GetBiz(),
+			BizId: v.GetBizId(),
+			Utime: time.UnixMilli(v.GetUtime()),
+		})
+	}
+	return res
+}
+
+func (r *RemoteInteractiveService) toStatsCnt(cnt *intrv1.StatsCnt) domain.StatsCnt {
+	return domain.StatsCnt{
+		ReadCnt:    cnt.GetReadCnt(),
+		LikeCnt:    cnt.GetLikeCnt(),
+		CollectCnt: cnt.GetCollectCnt(),
+	}
+}
@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"red-feed/interactive/domain"
+	"red-feed/interactive/service"
+	"sync/atomic"
+	"time"
+)
+
+var _ service.InteractiveService = (*SwitchInteractiveService)(nil)
+
+// SwitchInteractiveService 按照百分比把流量分到本地和远程，用来把 interactive 模块慢慢迁出去。
+// 有用户的按照用户分，没有的按照资源分，同一个用户总是走同一边，threshold 改了马上生效。
+// interactive 服务查不了帖子，走远程的点赞、收藏、表情先在这边检查资源存不存在
+type SwitchInteractiveService struct {
+	local  service.InteractiveService
+	remote service.InteractiveService
+	bizs   *domain.BizRegistry
+	// threshold 走远程的百分比，0 全部走本地，100 全部走远程
+	threshold *atomic.Int32
+}
+
+func NewSwitchInteractiveService(local service.InteractiveService, remote service.InteractiveService,
+	bizs *domain.BizRegistry, threshold int32) *SwitchInteractiveService {
+	s := &SwitchInteractiveService{
+		local:     local,
+		remote:    remote,
+		bizs:      bizs,
+		threshold: new(atomic.Int32),
+	}
+	s.UpdateThreshold(threshold)
+	return s
+}
+
+// UpdateThreshold 超出 [0, 100] 的按照边界算
+func (s *SwitchInteractiveService) UpdateThreshold(threshold int32) {
+	s.threshold.Store(min(max(threshold, 0), 100))
+}
+
+func (s *SwitchInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	return s.selectSvc(bizId).IncrReadCnt(ctx, biz, bizId)
+}
+
+func (s *SwitchInteractiveService) Like(ctx context.Context, biz string, bizId, uId int64) error {
+	svc, err := s.selectChecked(ctx, uId, biz, bizId, domain.CounterLike)
+	if err != nil {
+		return err
+	}
+	return svc.Like(ctx, biz, bizId, uId)
+}
+
+func (s *SwitchInteractiveService) CancelLike(ctx context.Context, biz string, bizId, uId int64) error {
+	return s.selectSvc(uId).CancelLike(ctx, biz, bizId, uId)
+}
+
+func (s *SwitchInteractiveService) Collect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	svc, err := s.selectChecked(ctx, uId, biz, bizId, domain.CounterCollect)
+	if err != nil {
+		return err
+	}
+	return svc.Collect(ctx, biz, bizId, uId, cId)
+}
+
+func (s *SwitchInteractiveService) CancelCollect(ctx context.Context, biz string, bizId, uId, cId int64) error {
+	return s.selectSvc(uId).CancelCollect(ctx, biz, bizId, uId, cId)
+}
+
+func (s *SwitchInteractiveService) Get(ctx context.Context, biz string, bizId int64, uId int64) (domain.Interactive, error) {
+	return s.selectSvc(s.key(uId, bizId)).Get(ctx, biz, bizId, uId)
+}
+
+func (s *SwitchInteractiveService) GetByIds(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	return s.selectSvc(s.firstId(bizIds)).GetByIds(ctx, biz, bizIds)
+}
+
+func (s *SwitchInteractiveService) GetUserStateByIds(ctx context.Context, biz string, uId int64,
+	bizIds []int64) (map[int64]domain.UserState, error) {
+	return s.selectSvc(uId).GetUserStateByIds(ctx, biz, uId, bizIds)
+}
+
+func (s *SwitchInteractiveService) ListLikers(ctx context.Context, biz string, bizId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	return s.selectSvc(bizId).ListLikers(ctx, biz, bizId, cursor, limit)
+}
+
+func (s *SwitchInteractiveService) ListLikedByUser(ctx context.Context, biz string, uId int64,
+	cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	return s.selectSvc(uId).ListLikedByUser(ctx, biz, uId, cursor, limit)
+}
+
+func (s *SwitchInteractiveService) GetStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time,
+	granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	return s.selectSvc(s.firstId(bizIds)).GetStats(ctx, biz, bizIds, start, end, granularity)
+}
+
+func (s *SwitchInteractiveService) GetStatsByIds(ctx context.Context, biz string, bizIds []int64,
+	start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	return s.selectSvc(s.firstId(bizIds)).GetStatsByIds(ctx, biz, bizIds, start, end)
+}
+
+func (s *SwitchInteractiveService) React(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	svc, err := s.selectChecked(ctx, uId, biz, bizId, domain.CounterLike)
+	if err != nil {
+		return err
+	}
+	return svc.React(ctx, biz, bizId, uId, reaction)
+}
+
+func (s *SwitchInteractiveService) CancelReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	return s.selectSvc(uId).CancelReaction(ctx, biz, bizId, uId)
+}
+
+func (s *SwitchInteractiveService) ListReactions(ctx context.Context) ([]domain.Reaction, error) {
+	return s.selectSvc(0).ListReactions(ctx)
+}
+
+// selectChecked 走远程的先检查资源存不存在，本地的 service 自己会检查
+func (s *SwitchInteractiveService) selectChecked(ctx context.Context, uId int64, biz string, bizId int64,
+	counter domain.Counter) (service.InteractiveService, error) {
+	svc := s.selectSvc(uId)
+	if svc == s.remote {
+		if err := s.bizs.CheckExist(ctx, biz, bizId, counter); err != nil {
+			return nil, err
+		}
+	}
+	return svc, nil
+}
+
+// selectSvc 按照 key 的哈希分流，key 一样的总是走同一边
+func (s *SwitchInteractiveService) selectSvc(key int64) service.InteractiveService {
+	threshold := s.threshold.Load()
+	// 0 和 100 的时候不用算
+	switch threshold {
+	case 0:
+		return s.local
+	case 100:
+		return s.remote
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(binary.BigEndian.AppendUint64(nil, uint64(key)))
+	if int32(h.Sum32()%100) < threshold {
+		return s.remote
+	}
+	return s.local
+}
+
+// key 匿名用户按照资源分
+func (s *SwitchInteractiveService) key(uId, bizId int64) int64 {
+	if uId > 0 {
+		return uId
+	}
+	return bizId
+}
+
+// firstId 批量查询按照第一个资源分，同一批总是走同一边
+func (s *SwitchInteractiveService) firstId(bizIds []int64) int64 {
+	if len(bizIds) == 0 {
+		return 0
+	}
+	return bizIds[0]
+}
@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	intrv1 "red-feed/api/proto/gen/intr/v1"
+	"red-feed/interactive/domain"
+	igrpc "red-feed/interactive/grpc"
+	"red-feed/interactive/service"
+	svcmocks "red-feed/internal/service/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+// startServer 用 bufconn 在内存里面起一个 interactive 的 gRPC 服务
+func startServer(t *testing.T, svc service.InteractiveService) intrv1.InteractiveServiceClient {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	igrpc.NewInteractiveServiceServer(svc, &logger.NopLogger{}).Register(server)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+	return intrv1.NewInteractiveServiceClient(cc)
+}
+
+func TestRemoteInteractiveService_Get(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) service.InteractiveService
+		wantIntr domain.Interactive
+		wantErr  error
+	}{
+		{
+			name: "查询成功",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), "article", int64(1), int64(2)).Return(domain.Interactive{
+					Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 3, CollectCnt: 2, UvCnt: 8,
+					ReactionCnts: map[string]int64{"like": 3, "heart": 1},
+					Liked:        true, Reaction: "like",
+				}, nil)
+				return svc
+			},
+			wantIntr: domain.Interactive{
+				Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 3, CollectCnt: 2, UvCnt: 8,
+				ReactionCnts: map[string]int64{"like": 3, "heart": 1},
+				Liked:        true, Reaction: "like",
+			},
+		},
+		{
+			name: "认识的错误还原回来",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Get(gomock.Any(), "article", int64(1), int64(2)).
+					Return(domain.Interactive{}, domain.ErrUnknownBiz)
+				return svc
+			},
+			wantErr: domain.ErrUnknownBiz,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			remote := NewRemoteInteractiveService(startServer(t, tc.mock(ctrl)))
+			intr, err := remote.Get(context.Background(), "article", 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantIntr, intr)
+		})
+	}
+}
+
+func TestRemoteInteractiveService_ListLikers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc := svcmocks.NewMockInteractiveService(ctrl)
+	utime := time.UnixMilli(1700000000000)
+	svc.EXPECT().ListLikers(gomock.Any(), "article", int64(1),
+		domain.LikeCursor{Utime: 1700000001000, Id: 20}, 2).
+		Return([]domain.LikeRecord{
+			{Id: 19, Uid: 100, Biz: "article", BizId: 1, Utime: utime},
+			{Id: 18, Uid: 101, Biz: "article", BizId: 1, Utime: utime},
+		}, nil)
+	remote := NewRemoteInteractiveService(startServer(t, svc))
+	records, err := remote.ListLikers(context.Background(), "article", 1,
+		domain.LikeCursor{Utime: 1700000001000, Id: 20}, 2)
+	require.NoError(t, err)
+	// 游标要带上记录的 id，才能接着翻页
+	assert.Equal(t, domain.LikeCursor{Utime: 1700000000000, Id: 18}, records[1].Cursor())
+}
+
+func TestRemoteInteractiveService_Like(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.InteractiveService
+		wantErr error
+		// wantMsg 不认识的错误保留 gRPC 的错误，只比较信息
+		wantMsg string
+	}{
+		{
+			name: "点赞成功",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), gomock.Any()).Return(nil)
+				return svc
+			},
+		},
+		{
+			name: "资源不存在",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), gomock.Any()).Return(domain.ErrBizNotFound)
+				return svc
+			},
+			wantErr: domain.ErrBizNotFound,
+		},
+		{
+			name: "不认识的错误",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), gomock.Any()).Return(errors.New("db error"))
+				return svc
+			},
+			wantMsg: "系统错误",
+		},
+		{
+			name: "只是错误信息一样，没有 reason 的不还原",
+			mock: func(ctrl *gomock.Controller) service.InteractiveService {
+				svc := svcmocks.NewMockInteractiveService(ctrl)
+				svc.EXPECT().Like(gomock.Any(), "article", int64(1), gomock.Any()).
+					Return(status.Error(codes.NotFound, domain.ErrBizNotFound.Error()))
+				return svc
+			},
+			wantMsg: domain.ErrBizNotFound.Error(),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			remote := NewRemoteInteractiveService(startServer(t, tc.mock(ctrl)))
+			err := remote.Like(context.Background(), "article", 1, 2)
+			if tc.wantMsg != "" {
+				assert.NotEqual(t, domain.ErrBizNotFound, err)
+				assert.ErrorContains(t, err, tc.wantMsg)
+				return
+			}
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestSwitchInteractiveService(t *testing.T) {
+	testCases := []struct {
+		name      string
+		threshold int32
+		mock      func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService)
+	}{
+		{
+			name:      "全部走本地",
+			threshold: 0,
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				local := svcmocks.NewMockInteractiveService(ctrl)
+				local.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(nil).Times(10)
+				return local, svcmocks.NewMockInteractiveService(ctrl)
+			},
+		},
+		{
+			name:      "全部走远程",
+			threshold: 100,
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				remote := svcmocks.NewMockInteractiveService(ctrl)
+				remote.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(nil).Times(10)
+				return svcmocks.NewMockInteractiveService(ctrl), remote
+			},
+		},
+		{
+			name:      "超出范围按照边界算",
+			threshold: 200,
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				remote := svcmocks.NewMockInteractiveService(ctrl)
+				remote.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1)).Return(nil).Times(10)
+				return svcmocks.NewMockInteractiveService(ctrl), remote
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			local, remote := tc.mock(ctrl)
+			svc := NewSwitchInteractiveService(local, remote, domain.NewBizRegistry(), tc.threshold)
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, svc.IncrReadCnt(context.Background(), "article", 1))
+			}
+		})
+	}
+}
+
+func TestSwitchInteractiveService_UpdateThreshold(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	local := svcmocks.NewMockInteractiveService(ctrl)
+	remote := svcmocks.NewMockInteractiveService(ctrl)
+	local.EXPECT().ListReactions(gomock.Any()).Return(domain.DefaultReactions, nil)
+	remote.EXPECT().ListReactions(gomock.Any()).Return(domain.DefaultReactions, nil)
+	svc := NewSwitchInteractiveService(local, remote, domain.NewBizRegistry(), 0)
+	_, err := svc.ListReactions(context.Background())
+	require.NoError(t, err)
+	// 改了马上生效
+	svc.UpdateThreshold(100)
+	_, err = svc.ListReactions(context.Background())
+	require.NoError(t, err)
+}
+
+func TestSwitchInteractiveService_selectSvc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	local := svcmocks.NewMockInteractiveService(ctrl)
+	remote := svcmocks.NewMockInteractiveService(ctrl)
+	svc := NewSwitchInteractiveService(local, remote, domain.NewBizRegistry(), 30)
+	remoteCnt := 0
+	for uid := int64(1); uid <= 1000; uid++ {
+		selected := svc.selectSvc(uid)
+		// 同一个用户每次都走同一边
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, selected, svc.selectSvc(uid))
+		}
+		if selected == service.InteractiveService(remote) {
+			remoteCnt++
+		}
+	}
+	assert.InDelta(t, 300, remoteCnt, 60)
+}
+
+func TestSwitchInteractiveService_Like(t *testing.T) {
+	testCases := []struct {
+		name      string
+		threshold int32
+		exists    bool
+		mock      func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService)
+		wantErr   error
+	}{
+		{
+			name:      "走远程的先检查资源存不存在",
+			threshold: 100,
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				return svcmocks.NewMockInteractiveService(ctrl), svcmocks.NewMockInteractiveService(ctrl)
+			},
+			wantErr: domain.ErrBizNotFound,
+		},
+		{
+			name:      "走远程，资源存在",
+			threshold: 100,
+			exists:    true,
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				remote := svcmocks.NewMockInteractiveService(ctrl)
+				remote.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(nil)
+				return svcmocks.NewMockInteractiveService(ctrl), remote
+			},
+		},
+		{
+			name: "走本地的本地自己检查",
+			mock: func(ctrl *gomock.Controller) (service.InteractiveService, service.InteractiveService) {
+				local := svcmocks.NewMockInteractiveService(ctrl)
+				local.EXPECT().Like(gomock.Any(), "article", int64(1), int64(2)).Return(domain.ErrBizNotFound)
+				return local, svcmocks.NewMockInteractiveService(ctrl)
+			},
+			wantErr: domain.ErrBizNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			local, remote := tc.mock(ctrl)
+			bizs := domain.NewBizRegistry(domain.BizConfig{Biz: "article"})
+			err := bizs.SetExistChecker("article", func(ctx context.Context, bizId int64) (bool, error) {
+				return tc.exists, nil
+			})
+			require.NoError(t, err)
+			svc := NewSwitchInteractiveService(local, remote, bizs, tc.threshold)
+			err = svc.Like(context.Background(), "article", 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import "time"
+
+// ImportItem 压缩包里面一个文件的导入结果
+type ImportItem struct {
+	File string
+	// Id 导入之后的帖子 id
+	Id    int64
+	Title string
+	// Status 导入之后的状态，和导出的 front-matter 里面的 status 一样
+	Status string
+	// Error 导入失败的原因，成功的时候为空
+	Error string
+}
+
+type ExportTaskStatus uint8
+
+func (s ExportTaskStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ExportTaskStatusUnknown ExportTaskStatus = iota
+	ExportTaskStatusRunning
+	ExportTaskStatusDone
+	ExportTaskStatusFailed
+)
+
+// ExportTask 后台导出任务
+type ExportTask struct {
+	Id     string
+	Uid    int64
+	Status ExportTaskStatus
+	// Cnt 导出的帖子数量
+	Cnt   int
+	Error string
+	Ctime time.Time
+	Utime time.Time
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type ArticleStatus uint8
 
@@ -13,16 +17,46 @@ const (
 	ArticleStatusUnPublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusDeleted 进入了回收站，读者和作者的正常列表都看不到
+	ArticleStatusDeleted
+	// ArticleStatusReviewing 提交发表的时候命中了敏感词，等待人工审核，审核通过之后才会发表
+	ArticleStatusReviewing
+	// ArticleStatusRejected 人工审核没有通过，作者修改之后可以重新提交
+	ArticleStatusRejected
+)
+
+// ArticleVisibility 已发表帖子的可见范围
+type ArticleVisibility uint8
+
+func (v ArticleVisibility) ToUint8() uint8 {
+	return uint8(v)
+}
+
+func (v ArticleVisibility) Valid() bool {
+	return v <= ArticleVisibilityLink
+}
+
+const (
+	// ArticleVisibilityPublic 所有人可见，历史数据都是 0，所以作为默认值
+	ArticleVisibilityPublic ArticleVisibility = iota
+	// ArticleVisibilityFollowers 只有作者的关注者可见
+	ArticleVisibilityFollowers
+	// ArticleVisibilityLink 不出现在列表里，只有拿到分享链接的人可见
+	ArticleVisibilityLink
 )
 
 type Article struct {
-	Id      int64
-	Title   string
-	Content string
-	Author  Author
-	Status  ArticleStatus
-	Ctime   time.Time
-	Utime   time.Time
+	Id         int64
+	Title      string
+	Content    string
+	Author     Author
+	Status     ArticleStatus
+	Visibility ArticleVisibility
+	Tags       []string
+	Ctime      time.Time
+	Utime      time.Time
+	// Dtime 放入回收站的时间，没有删除的时候是零值
+	Dtime time.Time
 }
 
 func (a Article) Abstract() string {
@@ -41,3 +75,36 @@ type Author struct {
 	Id   int64
 	Name string
 }
+
+// NormalizeTags 去掉首尾空白和重复的标签，标签按照逗号拼接存储，所以不能包含逗号
+func NormalizeTags(tags []string) ([]string, bool) {
+	const (
+		maxTags   = 10
+		maxTagLen = 20
+	)
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if strings.Contains(tag, ",") || utf8.RuneCountInString(tag) > maxTagLen {
+			return nil, false
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	return res, len(res) <= maxTags
+}
+
+// ArticleDeleteEvent 帖子彻底删除的事件，和删除在同一个事务里面写进 outbox，再由任务发出去
+type ArticleDeleteEvent struct {
+	// Id outbox 里面的 id
+	Id  int64
+	Aid int64
+	Uid int64
+}
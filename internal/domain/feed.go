@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "rss"
+	FeedFormatAtom FeedFormat = "atom"
+)
+
+// FeedDocument 生成好的订阅源，直接写到响应里面
+type FeedDocument struct {
+	Content []byte
+	ETag    string
+	// Modified 最近一篇帖子的更新时间，没有帖子的时候是零值
+	Modified time.Time
+}
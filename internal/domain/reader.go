@@ -0,0 +1,10 @@
+package domain
+
+// Reader 读者端请求的来源，匿名读者 Uid 为 0
+type Reader struct {
+	Uid int64
+	// DeviceId 用来区分匿名读者
+	DeviceId  string
+	Ip        string
+	UserAgent string
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type ReviewStatus uint8
+
+func (s ReviewStatus) ToUint8() uint8 {
+	return uint8(s)
+}
+
+const (
+	ReviewStatusUnknown ReviewStatus = iota
+	// ReviewStatusPending 等待审核
+	ReviewStatusPending
+	ReviewStatusApproved
+	ReviewStatusRejected
+	// ReviewStatusCanceled 审核之前作者又重新提交了，旧的审核单作废
+	ReviewStatusCanceled
+)
+
+// ArticleReview 帖子的人工审核单
+type ArticleReview struct {
+	Id        int64
+	ArticleId int64
+	AuthorId  int64
+	// Title 提交审核时候的标题，方便审核人员在列表里面看
+	Title string
+	// Hits 机器审核命中的敏感词
+	Hits       []string
+	Status     ReviewStatus
+	Reason     string
+	ReviewerId int64
+	Ctime      time.Time
+	Utime      time.Time
+}
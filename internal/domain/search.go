@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// SearchArticle 搜索结果里面的帖子，Title 和 Snippet 已经做了高亮和转义
+type SearchArticle struct {
+	Id      int64
+	Title   string
+	Snippet string
+	Tags    []string
+	Author  Author
+	Utime   time.Time
+	Score   float64
+}
+
+type SearchResult struct {
+	// Total 命中的总数，用于分页
+	Total    int
+	Articles []SearchArticle
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/events/article/producer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/events/article/producer.go -package=evtmocks -destination=./internal/events/article/mocks/producer.mock.go
+//
+
+// Package evtmocks is a generated GoMock package.
+package evtmocks
+
+import (
+	context "context"
+	article "red-feed/internal/events/article"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+	isgomock struct{}
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProduceDeleteEvent mocks base method.
+func (m *MockProducer) ProduceDeleteEvent(ctx context.Context, evt article.DeleteEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceDeleteEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceDeleteEvent indicates an expected call of ProduceDeleteEvent.
+func (mr *MockProducerMockRecorder) ProduceDeleteEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceDeleteEvent", reflect.TypeOf((*MockProducer)(nil).ProduceDeleteEvent), ctx, evt)
+}
+
+// ProducePublishEvent mocks base method.
+func (m *MockProducer) ProducePublishEvent(ctx context.Context, evt article.PublishEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducePublishEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProducePublishEvent indicates an expected call of ProducePublishEvent.
+func (mr *MockProducerMockRecorder) ProducePublishEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducePublishEvent", reflect.TypeOf((*MockProducer)(nil).ProducePublishEvent), ctx, evt)
+}
+
+// ProduceReadEvent mocks base method.
+func (m *MockProducer) ProduceReadEvent(ctx context.Context, evt article.ReadEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceReadEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceReadEvent indicates an expected call of ProduceReadEvent.
+func (mr *MockProducerMockRecorder) ProduceReadEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceReadEvent", reflect.TypeOf((*MockProducer)(nil).ProduceReadEvent), ctx, evt)
+}
+
+// ProduceReviewEvent mocks base method.
+func (m *MockProducer) ProduceReviewEvent(ctx context.Context, evt article.ReviewEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceReviewEvent", ctx, evt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceReviewEvent indicates an expected call of ProduceReviewEvent.
+func (mr *MockProducerMockRecorder) ProduceReviewEvent(ctx, evt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceReviewEvent", reflect.TypeOf((*MockProducer)(nil).ProduceReviewEvent), ctx, evt)
+}
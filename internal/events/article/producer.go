@@ -4,12 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/IBM/sarama"
+	"github.com/google/uuid"
 )
 
-const topicReadEvent = "article_read_event"
+const (
+	topicReadEvent    = "article_read_event"
+	topicDeleteEvent  = "article_delete_event"
+	topicPublishEvent = "article_publish_event"
+	topicReviewEvent  = "article_review_event"
+)
 
 type Producer interface {
 	ProduceReadEvent(ctx context.Context, evt ReadEvent) error
+	// ProduceDeleteEvent 帖子被彻底删除之后发送，其它模块据此清理关联数据
+	ProduceDeleteEvent(ctx context.Context, evt DeleteEvent) error
+	// ProducePublishEvent 帖子发表、撤回、删除之后发送，搜索之类的模块据此更新自己的数据
+	ProducePublishEvent(ctx context.Context, evt PublishEvent) error
+	// ProduceReviewEvent 人工审核有结果之后发送，通知服务据此通知作者
+	ProduceReviewEvent(ctx context.Context, evt ReviewEvent) error
 }
 
 type KafkaProducer struct {
@@ -23,6 +35,9 @@ func NewKafkaProducer(pc sarama.SyncProducer) Producer {
 }
 
 func (k *KafkaProducer) ProduceReadEvent(ctx context.Context, evt ReadEvent) error {
+	if evt.EventId == "" {
+		evt.EventId = uuid.NewString()
+	}
 	data, err := json.Marshal(evt)
 	if err != nil {
 		return err
@@ -37,7 +52,74 @@ func (k *KafkaProducer) ProduceReadEvent(ctx context.Context, evt ReadEvent) err
 	return err
 }
 
+func (k *KafkaProducer) ProduceDeleteEvent(ctx context.Context, evt DeleteEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicDeleteEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (k *KafkaProducer) ProducePublishEvent(ctx context.Context, evt PublishEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicPublishEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (k *KafkaProducer) ProduceReviewEvent(ctx context.Context, evt ReviewEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	_, _, err = k.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: topicReviewEvent,
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+// ReadEvent 匿名读者 Uid 为 0，用 DeviceId 区分
 type ReadEvent struct {
+	// EventId 每个事件唯一，重复投递的时候消费者据此去重
+	EventId  string
+	Uid      int64
+	Aid      int64
+	DeviceId string
+	// Ip 和 UserAgent 给消费者识别刷量用
+	Ip        string
+	UserAgent string
+	// Ctime 阅读的时间，毫秒数。消息积压的时候消费者也能按照阅读的时间统计
+	Ctime int64
+}
+
+type DeleteEvent struct {
+	Aid int64
 	Uid int64
+}
+
+// PublishEvent 只带 id，消费者自己去线上库查最新的数据，避免消息乱序覆盖新数据
+type PublishEvent struct {
 	Aid int64
+	Uid int64
+	// Published 为 false 表示撤回或者删除了
+	Published bool
+}
+
+type ReviewEvent struct {
+	ReviewId int64
+	Aid      int64
+	Uid      int64
+	Approved bool
+	// Reason 驳回的原因
+	Reason string
 }
@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"github.com/IBM/sarama"
+	domain2 "red-feed/interactive/domain"
 	"red-feed/internal/domain"
 	"red-feed/internal/repository"
 	"red-feed/pkg/logger"
@@ -13,9 +14,20 @@ import (
 type HistoryConsumer struct {
 	client sarama.Client
 	repo   repository.HistoryRecordRepository
+	bizs   *domain2.BizRegistry
 	l      logger.Logger
 }
 
+func NewHistoryConsumer(client sarama.Client, repo repository.HistoryRecordRepository,
+	bizs *domain2.BizRegistry, l logger.Logger) *HistoryConsumer {
+	return &HistoryConsumer{
+		client: client,
+		repo:   repo,
+		bizs:   bizs,
+		l:      l,
+	}
+}
+
 func (hc *HistoryConsumer) Start() error {
 	cg, err := sarama.NewConsumerGroupFromClient("history",
 		hc.client)
@@ -36,11 +48,19 @@ func (hc *HistoryConsumer) Start() error {
 func (hc *HistoryConsumer) Consume(
 	msg *sarama.ConsumerMessage,
 	evt ReadEvent) error {
+	// 匿名读者没有阅读历史
+	if evt.Uid <= 0 {
+		return nil
+	}
+	// 和计数用同一份业务配置，没有注册的业务不记
+	if _, err := hc.bizs.Get(domain2.BizArticle); err != nil {
+		return err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 	return hc.repo.AddRecord(ctx, domain.HistoryRecord{
 		Uid:   evt.Uid,
-		Biz:   "article",
+		Biz:   domain2.BizArticle,
 		BizId: evt.Aid,
 	})
 }
@@ -0,0 +1,55 @@
+package article
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/saramax"
+	"time"
+)
+
+// SearchIndexer 搜索服务实现了这个接口，这里不直接依赖 service 包，不然会循环引用
+type SearchIndexer interface {
+	Sync(ctx context.Context, artId int64) error
+}
+
+// SearchConsumer 根据发表事件更新本实例的搜索索引
+type SearchConsumer struct {
+	client sarama.Client
+	svc    SearchIndexer
+	// groupId 重启之后还是同一个，接着上次提交的位置消费，停机期间发表的帖子也能索引到
+	groupId string
+	l       logger.Logger
+}
+
+func NewSearchConsumer(client sarama.Client, svc SearchIndexer, groupId string, l logger.Logger) *SearchConsumer {
+	return &SearchConsumer{
+		client:  client,
+		svc:     svc,
+		groupId: groupId,
+		l:       l,
+	}
+}
+
+func (s *SearchConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient(s.groupId, s.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := cg.Consume(context.Background(),
+			[]string{topicPublishEvent},
+			saramax.NewHandler[PublishEvent](s.l, s.Consume))
+		if err != nil {
+			s.l.Error("退出了消费循环异常", logger.Error(err))
+		}
+	}()
+	return err
+}
+
+func (s *SearchConsumer) Consume(msg *sarama.ConsumerMessage, evt PublishEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// 不管是发表还是撤回，都以线上库为准
+	return s.svc.Sync(ctx, evt.Aid)
+}
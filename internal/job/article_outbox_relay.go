@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"red-feed/internal/domain"
+	"red-feed/internal/events/article"
+	"red-feed/internal/repository"
+	"sync/atomic"
+	"time"
+)
+
+// ArticleOutboxRelayJob 把 outbox 里面帖子彻底删除的事件发到 Kafka，发成功了才删掉。
+// 发成功了但是没删掉的，认领过期之后会再发一遍，消费者清理数据是幂等的
+type ArticleOutboxRelayJob struct {
+	repo      repository.ArticleEventRepository
+	producer  article.Producer
+	batchSize int
+	timeout   time.Duration
+	// running 上一次还没跑完就跳过
+	running atomic.Bool
+}
+
+func NewArticleOutboxRelayJob(repo repository.ArticleEventRepository, producer article.Producer,
+	batchSize int, timeout time.Duration) *ArticleOutboxRelayJob {
+	return &ArticleOutboxRelayJob{
+		repo:      repo,
+		producer:  producer,
+		batchSize: batchSize,
+		timeout:   timeout,
+	}
+}
+
+func (j *ArticleOutboxRelayJob) Name() string {
+	return "article_outbox_relay"
+}
+
+func (j *ArticleOutboxRelayJob) Run() error {
+	if !j.running.CompareAndSwap(false, true) {
+		return nil
+	}
+	defer j.running.Store(false)
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.Relay(ctx)
+}
+
+// Relay 一批一批地发，直到发完，或者 ctx 超时。
+// 中间有一个发送失败就停下来，前面发成功的照样删掉
+func (j *ArticleOutboxRelayJob) Relay(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		evts, err := j.repo.ClaimDeleteEvents(ctx, j.batchSize, j.timeout)
+		if err != nil || len(evts) == 0 {
+			return err
+		}
+		sent := make([]domain.ArticleDeleteEvent, 0, len(evts))
+		for _, evt := range evts {
+			err = j.producer.ProduceDeleteEvent(ctx, article.DeleteEvent{
+				Aid: evt.Aid,
+				Uid: evt.Uid,
+			})
+			if err != nil {
+				break
+			}
+			sent = append(sent, evt)
+		}
+		if er := j.repo.MarkSent(ctx, sent); er != nil {
+			return er
+		}
+		if err != nil {
+			return err
+		}
+		if len(evts) < j.batchSize {
+			return nil
+		}
+	}
+}
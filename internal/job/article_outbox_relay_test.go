@@ -0,0 +1,71 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/internal/domain"
+	"red-feed/internal/events/article"
+	evtmocks "red-feed/internal/events/article/mocks"
+	"red-feed/internal/repository"
+	repomocks "red-feed/internal/repository/mocks"
+	"testing"
+	"time"
+)
+
+func TestArticleOutboxRelayJob_Relay(t *testing.T) {
+	evts := []domain.ArticleDeleteEvent{
+		{Id: 1, Aid: 10, Uid: 100},
+		{Id: 2, Aid: 11, Uid: 100},
+	}
+	testcases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (repository.ArticleEventRepository, article.Producer)
+		wantErr error
+	}{
+		{
+			name: "发完一批，不满一批就结束",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleEventRepository, article.Producer) {
+				repo := repomocks.NewMockArticleEventRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ClaimDeleteEvents(gomock.Any(), 10, time.Second).Return(evts, nil)
+				producer.EXPECT().ProduceDeleteEvent(gomock.Any(), article.DeleteEvent{Aid: 10, Uid: 100}).Return(nil)
+				producer.EXPECT().ProduceDeleteEvent(gomock.Any(), article.DeleteEvent{Aid: 11, Uid: 100}).Return(nil)
+				repo.EXPECT().MarkSent(gomock.Any(), evts).Return(nil)
+				return repo, producer
+			},
+		},
+		{
+			name: "中间发送失败，前面发出去的照样删掉",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleEventRepository, article.Producer) {
+				repo := repomocks.NewMockArticleEventRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ClaimDeleteEvents(gomock.Any(), 10, time.Second).Return(evts, nil)
+				producer.EXPECT().ProduceDeleteEvent(gomock.Any(), article.DeleteEvent{Aid: 10, Uid: 100}).Return(nil)
+				producer.EXPECT().ProduceDeleteEvent(gomock.Any(), article.DeleteEvent{Aid: 11, Uid: 100}).
+					Return(errors.New("kafka error"))
+				repo.EXPECT().MarkSent(gomock.Any(), evts[:1]).Return(nil)
+				return repo, producer
+			},
+			wantErr: errors.New("kafka error"),
+		},
+		{
+			name: "没有要发的",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleEventRepository, article.Producer) {
+				repo := repomocks.NewMockArticleEventRepository(ctrl)
+				repo.EXPECT().ClaimDeleteEvents(gomock.Any(), 10, time.Second).Return(nil, nil)
+				return repo, evtmocks.NewMockProducer(ctrl)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			err := NewArticleOutboxRelayJob(repo, producer, 10, time.Second).Relay(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
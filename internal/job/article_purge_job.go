@@ -0,0 +1,59 @@
+package job
+
+import (
+	"context"
+	"red-feed/internal/service"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// ArticlePurgeJob 彻底删除回收站里面超过保留时间的帖子
+type ArticlePurgeJob struct {
+	svc       service.ArticleService
+	l         logger.Logger
+	batchSize int
+	timeout   time.Duration
+}
+
+func NewArticlePurgeJob(svc service.ArticleService, l logger.Logger, timeout time.Duration) *ArticlePurgeJob {
+	return &ArticlePurgeJob{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+		timeout:   timeout,
+	}
+}
+
+func (a *ArticlePurgeJob) Name() string {
+	return "article_purge"
+}
+
+// Run 删除是有条件的，多个实例同时跑也只会删除一次，所以这里不需要分布式锁
+func (a *ArticlePurgeJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.timeout)
+	defer cancel()
+	return a.Purge(ctx)
+}
+
+// Purge 按照 id 分批清理，直到遍历完所有过期的帖子，或者 ctx 超时。
+// 删不掉的直接跳过，不会卡住后面的
+func (a *ArticlePurgeJob) Purge(ctx context.Context) error {
+	total := 0
+	var startId int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cnt, nextId, err := a.svc.PurgeTrash(ctx, startId, a.batchSize)
+		if err != nil {
+			return err
+		}
+		total += cnt
+		if nextId == 0 {
+			break
+		}
+		startId = nextId
+	}
+	a.l.Info("清理回收站完成", logger.Int("cnt", total))
+	return nil
+}
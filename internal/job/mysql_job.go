@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"golang.org/x/sync/semaphore"
 	"red-feed/internal/domain"
@@ -49,12 +50,15 @@ type Scheduler struct {
 	svc     service.JobService
 	l       logger.Logger
 	limiter *semaphore.Weighted
+	// interval 没有任务可以抢的时候，隔多久再抢
+	interval time.Duration
 }
 
 func NewScheduler(svc service.JobService, l logger.Logger) *Scheduler {
 	return &Scheduler{svc: svc, l: l,
-		limiter: semaphore.NewWeighted(200),
-		execs:   make(map[string]Executor)}
+		limiter:  semaphore.NewWeighted(200),
+		interval: time.Second,
+		execs:    make(map[string]Executor)}
 }
 
 func (s *Scheduler) RegisterExecutor(exec Executor) {
@@ -76,9 +80,19 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 		j, err := s.svc.Preempt(dbCtx)
 		cancel()
 		if err != nil {
+			s.limiter.Release(1)
 			// 你不能 return
 			// 你要继续下一轮
-			s.l.Error("抢占任务失败", logger.Error(err))
+			if !errors.Is(err, service.ErrNoJob) {
+				s.l.Error("抢占任务失败", logger.Error(err))
+			}
+			// 等一会儿再抢，不然没有任务的时候会一直查数据库
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.interval):
+			}
+			continue
 		}
 
 		exec, ok := s.execs[j.Executor]
@@ -87,20 +101,14 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 			// 线上就继续
 			s.l.Error("未找到对应的执行器",
 				logger.String("executor", j.Executor))
+			// 推到下一次再释放，不然马上又会被抢到
+			s.release(j)
 			continue
 		}
 		// 接下来就是执行
 		// 怎么执行？
 		go func() {
-			defer func() {
-				s.limiter.Release(1)
-				err1 := j.CancelFunc()
-				if err1 != nil {
-					s.l.Error("释放任务失败",
-						logger.Error(err1),
-						logger.Int64("jid", j.Id))
-				}
-			}()
+			defer s.release(j)
 			// 异步执行，不要阻塞主调度循环
 			// 执行完毕之后
 			// 这边要考虑超时控制，任务的超时控制
@@ -109,13 +117,22 @@ func (s *Scheduler) Schedule(ctx context.Context) error {
 				// 你也可以考虑在这里重试
 				s.l.Error("任务执行失败", logger.Error(err1))
 			}
-			// 你要不要考虑下一次调度？
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-			defer cancel()
-			err1 = s.svc.ResetNextTime(ctx, j)
-			if err1 != nil {
-				s.l.Error("设置下一次执行时间失败", logger.Error(err1))
-			}
 		}()
 	}
 }
+
+// release 设置下一次执行的时间，再释放任务
+func (s *Scheduler) release(j domain.Job) {
+	defer s.limiter.Release(1)
+	// 你要不要考虑下一次调度？
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.svc.ResetNextTime(ctx, j); err != nil {
+		s.l.Error("设置下一次执行时间失败", logger.Error(err))
+	}
+	if err := j.CancelFunc(); err != nil {
+		s.l.Error("释放任务失败",
+			logger.Error(err),
+			logger.Int64("jid", j.Id))
+	}
+}
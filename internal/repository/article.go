@@ -7,9 +7,12 @@ import (
 	"red-feed/internal/repository/cache"
 	"red-feed/internal/repository/dao"
 	"red-feed/pkg/logger"
+	"strings"
 	"time"
 )
 
+var ErrArticleNotFound = dao.ErrArticleNotFound
+
 type ArticleRepository interface {
 	Create(ctx context.Context, article domain.Article) (artId int64, err error)
 	Update(ctx context.Context, article domain.Article) error
@@ -17,9 +20,22 @@ type ArticleRepository interface {
 	SyncStatus(ctx context.Context, artId int64, authorId int64, status domain.ArticleStatus) error
 	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
 	ListPub(ctx context.Context, offset int, limit int) ([]domain.Article, error)
+	ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
 	GetById(ctx context.Context, artId int64) (domain.Article, error)
 	GetPubById(ctx context.Context, artId int64) (domain.Article, error)
+	GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
 	ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error)
+
+	Delete(ctx context.Context, artId int64, authorId int64) error
+	Restore(ctx context.Context, artId int64, authorId int64, after time.Time) error
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	HardDelete(ctx context.Context, artId int64, authorId int64) error
+	ListExpiredTrash(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.Article, error)
+	ListAllByAuthor(ctx context.Context, uid int64, startId int64, limit int) ([]domain.Article, error)
+	CountByAuthor(ctx context.Context, uid int64) (int64, error)
+	UpdateDraftStatus(ctx context.Context, artId int64, authorId int64, from domain.ArticleStatus, to domain.ArticleStatus) error
+	// ListPubForIndex 返回的帖子带上了作者名字
+	ListPubForIndex(ctx context.Context, startId int64, limit int) ([]domain.Article, error)
 }
 
 type CachedArticleRepository struct {
@@ -29,6 +45,70 @@ type CachedArticleRepository struct {
 	l        logger.Logger
 }
 
+func (r *CachedArticleRepository) Delete(ctx context.Context, artId int64, authorId int64) error {
+	err := r.dao.SoftDelete(ctx, artId, authorId)
+	if err != nil {
+		return err
+	}
+	// 作者端和读者端的缓存都要删掉，不然读者还能从缓存里面看到
+	r.evictArticle(ctx, artId, authorId)
+	return nil
+}
+
+func (r *CachedArticleRepository) Restore(ctx context.Context, artId int64, authorId int64, after time.Time) error {
+	err := r.dao.Restore(ctx, artId, authorId, after)
+	if err != nil {
+		return err
+	}
+	delErr := r.cache.DelFirstPage(ctx, authorId)
+	if delErr != nil {
+		r.l.Error("删除缓存：作者的第一页文章 失败", logger.Error(delErr), logger.Int64("authorId", authorId))
+	}
+	return nil
+}
+
+func (r *CachedArticleRepository) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	res, err := r.dao.ListTrash(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Article) domain.Article {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CachedArticleRepository) HardDelete(ctx context.Context, artId int64, authorId int64) error {
+	err := r.dao.HardDelete(ctx, artId, authorId)
+	if err != nil {
+		return err
+	}
+	r.evictArticle(ctx, artId, authorId)
+	return nil
+}
+
+func (r *CachedArticleRepository) ListExpiredTrash(ctx context.Context, before time.Time, startId int64, limit int) ([]domain.Article, error) {
+	res, err := r.dao.ListExpiredTrash(ctx, before, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Article) domain.Article {
+		return r.toDomain(src)
+	}), nil
+}
+
+// evictArticle 删除一篇帖子相关的所有缓存
+func (r *CachedArticleRepository) evictArticle(ctx context.Context, artId int64, authorId int64) {
+	if err := r.cache.DelFirstPage(ctx, authorId); err != nil {
+		r.l.Error("删除缓存：作者的第一页文章 失败", logger.Error(err), logger.Int64("authorId", authorId))
+	}
+	if err := r.cache.Del(ctx, artId); err != nil {
+		r.l.Error("删除缓存：作者端文章 失败", logger.Error(err), logger.Int64("artId", artId))
+	}
+	if err := r.cache.DelPub(ctx, artId); err != nil {
+		r.l.Error("删除缓存：读者端文章 失败", logger.Error(err), logger.Int64("artId", artId))
+	}
+}
+
 func (r *CachedArticleRepository) ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
 	res, err := r.dao.ListPubForRanking(ctx, start, offset, limit)
 	if err != nil {
@@ -39,6 +119,51 @@ func (r *CachedArticleRepository) ListPubForRanking(ctx context.Context, start t
 	}), nil
 }
 
+func (r *CachedArticleRepository) ListAllByAuthor(ctx context.Context, uid int64, startId int64, limit int) ([]domain.Article, error) {
+	res, err := r.dao.ListAllByAuthor(ctx, uid, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Article) domain.Article {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *CachedArticleRepository) CountByAuthor(ctx context.Context, uid int64) (int64, error) {
+	return r.dao.CountByAuthor(ctx, uid)
+}
+
+func (r *CachedArticleRepository) UpdateDraftStatus(ctx context.Context, artId int64, authorId int64,
+	from domain.ArticleStatus, to domain.ArticleStatus) error {
+	err := r.dao.UpdateDraftStatus(ctx, artId, authorId, from.ToUint8(), to.ToUint8())
+	if err != nil {
+		return err
+	}
+	r.evictArticle(ctx, artId, authorId)
+	return nil
+}
+
+func (r *CachedArticleRepository) ListPubForIndex(ctx context.Context, startId int64, limit int) ([]domain.Article, error) {
+	res, err := r.dao.ListPubForIndex(ctx, startId, limit)
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]int64, 0, len(res))
+	for _, src := range res {
+		uids = append(uids, src.AuthorId)
+	}
+	// 查不到作者不影响索引，只是不能按照作者名字搜索
+	users, err := r.userRepo.FindByIds(ctx, uids)
+	if err != nil {
+		r.l.Error("查询作者失败", logger.Error(err))
+	}
+	return slice.Map(res, func(idx int, src dao.PublishedArticle) domain.Article {
+		art := r.pubToDomain(src)
+		art.Author.Name = users[src.AuthorId].Nickname
+		return art
+	}), nil
+}
+
 func (r *CachedArticleRepository) ListPub(ctx context.Context, offset int, limit int) ([]domain.Article, error) {
 	res, err := r.dao.ListPub(ctx, offset, limit)
 	if err != nil {
@@ -49,6 +174,26 @@ func (r *CachedArticleRepository) ListPub(ctx context.Context, offset int, limit
 	}), nil
 }
 
+func (r *CachedArticleRepository) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	res, err := r.dao.ListPubByAuthor(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.PublishedArticle) domain.Article {
+		return r.pubToDomain(src)
+	}), nil
+}
+
+func (r *CachedArticleRepository) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	res, err := r.dao.GetPubByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.PublishedArticle) domain.Article {
+		return r.pubToDomain(src)
+	}), nil
+}
+
 func (r *CachedArticleRepository) GetById(ctx context.Context, artId int64) (domain.Article, error) {
 	cachedArt, err := r.cache.Get(ctx, artId)
 	if err == nil {
@@ -75,14 +220,18 @@ func (r *CachedArticleRepository) GetPubById(ctx context.Context, artId int64) (
 		return domain.Article{}, err
 	}
 	res = domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Status:  domain.ArticleStatus(art.Status),
-		Content: art.Content,
+		Id:         art.Id,
+		Title:      art.Title,
+		Status:     domain.ArticleStatus(art.Status),
+		Visibility: domain.ArticleVisibility(art.Visibility),
+		Tags:       r.splitTags(art.Tags),
+		Content:    art.Content,
 		Author: domain.Author{
 			Id:   user.Id,
 			Name: user.Nickname,
 		},
+		Ctime: time.UnixMilli(art.Ctime),
+		Utime: time.UnixMilli(art.Utime),
 	}
 	// 也可以同步
 	go func() {
@@ -128,25 +277,33 @@ func (r *CachedArticleRepository) List(ctx context.Context, uid int64, offset in
 }
 
 func (r *CachedArticleRepository) toDomain(art dao.Article) domain.Article {
-	return domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Status:  domain.ArticleStatus(art.Status),
-		Content: art.Content,
+	res := domain.Article{
+		Id:         art.Id,
+		Title:      art.Title,
+		Status:     domain.ArticleStatus(art.Status),
+		Visibility: domain.ArticleVisibility(art.Visibility),
+		Tags:       r.splitTags(art.Tags),
+		Content:    art.Content,
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
 		Ctime: time.UnixMilli(art.Ctime),
 		Utime: time.UnixMilli(art.Utime),
 	}
+	if art.Dtime > 0 {
+		res.Dtime = time.UnixMilli(art.Dtime)
+	}
+	return res
 }
 
 func (r *CachedArticleRepository) pubToDomain(art dao.PublishedArticle) domain.Article {
 	return domain.Article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Status:  domain.ArticleStatus(art.Status),
-		Content: art.Content,
+		Id:         art.Id,
+		Title:      art.Title,
+		Status:     domain.ArticleStatus(art.Status),
+		Visibility: domain.ArticleVisibility(art.Visibility),
+		Tags:       r.splitTags(art.Tags),
+		Content:    art.Content,
 		Author: domain.Author{
 			Id: art.AuthorId,
 		},
@@ -156,13 +313,27 @@ func (r *CachedArticleRepository) pubToDomain(art dao.PublishedArticle) domain.A
 }
 
 func (r *CachedArticleRepository) toEntity(art domain.Article) dao.Article {
-	return dao.Article{
-		Id:       art.Id,
-		Title:    art.Title,
-		Content:  art.Content,
-		AuthorId: art.Author.Id,
-		Status:   art.Status.ToUint8(),
+	res := dao.Article{
+		Id:         art.Id,
+		Title:      art.Title,
+		Content:    art.Content,
+		AuthorId:   art.Author.Id,
+		Status:     art.Status.ToUint8(),
+		Visibility: art.Visibility.ToUint8(),
+		Tags:       strings.Join(art.Tags, ","),
+	}
+	// 零值的 UnixMilli 是负数，只有导入的时候才会带上创建时间
+	if !art.Ctime.IsZero() {
+		res.Ctime = art.Ctime.UnixMilli()
+	}
+	return res
+}
+
+func (r *CachedArticleRepository) splitTags(tags string) []string {
+	if tags == "" {
+		return nil
 	}
+	return strings.Split(tags, ",")
 }
 
 func (r *CachedArticleRepository) SyncStatus(ctx context.Context, artId int64, authorId int64, status domain.ArticleStatus) error {
@@ -172,6 +343,10 @@ func (r *CachedArticleRepository) SyncStatus(ctx context.Context, artId int64, a
 		if delErr != nil {
 			r.l.Error("删除缓存：作者的第一页文章 失败", logger.Error(err), logger.Int64("authorId", authorId))
 		}
+		delErr = r.cache.DelPub(ctx, artId)
+		if delErr != nil {
+			r.l.Error("删除缓存：读者端文章 失败", logger.Error(delErr), logger.Int64("artId", artId))
+		}
 	}
 	return err
 }
@@ -183,6 +358,11 @@ func (r *CachedArticleRepository) Sync(ctx context.Context, article domain.Artic
 		if delErr != nil {
 			r.l.Error("删除缓存：作者的第一页文章 失败", logger.Error(err), logger.Int64("authorId", article.Author.Id))
 		}
+		// 重新发表可能修改了可见范围，读者端的缓存要删掉
+		delErr = r.cache.DelPub(ctx, artId)
+		if delErr != nil {
+			r.l.Error("删除缓存：读者端文章 失败", logger.Error(delErr), logger.Int64("artId", artId))
+		}
 	}
 	return artId, err
 }
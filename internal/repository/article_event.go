@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository/dao"
+	"time"
+)
+
+// ArticleEventRepository 读取 outbox 里面还没有发出去的帖子事件
+//
+//go:generate mockgen -source=./article_event.go -package=repomocks -destination=mocks/article_event.mock.go ArticleEventRepository
+type ArticleEventRepository interface {
+	// ClaimDeleteEvents 认领一批还没有发出去的彻底删除事件，lease 之内别的实例拿不到
+	ClaimDeleteEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.ArticleDeleteEvent, error)
+	// MarkSent 发出去了的不会再发
+	MarkSent(ctx context.Context, evts []domain.ArticleDeleteEvent) error
+}
+
+type OutboxArticleEventRepository struct {
+	dao dao.ArticleOutboxDAO
+}
+
+func NewArticleEventRepository(dao dao.ArticleOutboxDAO) ArticleEventRepository {
+	return &OutboxArticleEventRepository{
+		dao: dao,
+	}
+}
+
+func (r *OutboxArticleEventRepository) ClaimDeleteEvents(ctx context.Context, limit int,
+	lease time.Duration) ([]domain.ArticleDeleteEvent, error) {
+	rows, err := r.dao.Claim(ctx, limit, lease)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(rows, func(idx int, src dao.ArticleOutbox) domain.ArticleDeleteEvent {
+		return domain.ArticleDeleteEvent{
+			Id:  src.Id,
+			Aid: src.Aid,
+			Uid: src.Uid,
+		}
+	}), nil
+}
+
+func (r *OutboxArticleEventRepository) MarkSent(ctx context.Context, evts []domain.ArticleDeleteEvent) error {
+	return r.dao.Delete(ctx, slice.Map(evts, func(idx int, src domain.ArticleDeleteEvent) int64 {
+		return src.Id
+	}))
+}
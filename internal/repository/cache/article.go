@@ -16,9 +16,11 @@ type ArticleCache interface {
 
 	Set(ctx context.Context, art domain.Article) error
 	Get(ctx context.Context, id int64) (domain.Article, error)
+	Del(ctx context.Context, id int64) error
 
 	SetPub(ctx context.Context, article domain.Article) error
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
+	DelPub(ctx context.Context, id int64) error
 }
 type RedisArticleCache struct {
 	client redis.Cmdable
@@ -47,6 +49,10 @@ func (c *RedisArticleCache) Get(ctx context.Context, id int64) (domain.Article,
 	return res, err
 }
 
+func (c *RedisArticleCache) Del(ctx context.Context, id int64) error {
+	return c.client.Del(ctx, c.authorArtKey(id)).Err()
+}
+
 func (c *RedisArticleCache) SetPub(ctx context.Context, art domain.Article) error {
 	data, err := json.Marshal(art)
 	if err != nil {
@@ -69,6 +75,10 @@ func (c *RedisArticleCache) GetPub(ctx context.Context, id int64) (domain.Articl
 	return res, err
 }
 
+func (c *RedisArticleCache) DelPub(ctx context.Context, id int64) error {
+	return c.client.Del(ctx, c.readerArtKey(id)).Err()
+}
+
 func (c *RedisArticleCache) SetFirstPage(ctx context.Context, authorId int64, arts []domain.Article) error {
 	for i := range arts {
 		// 只缓存摘要部分
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"red-feed/internal/domain"
+	"time"
+)
+
+// ErrExportTaskNotFound 任务不存在或者已经过期
+var ErrExportTaskNotFound = redis.Nil
+
+type ExportTaskCache interface {
+	Set(ctx context.Context, task domain.ExportTask) error
+	Get(ctx context.Context, id string) (domain.ExportTask, error)
+}
+
+// RedisExportTaskCache 导出任务的状态只在下载链接有效期内有意义，所以直接放 Redis
+type RedisExportTaskCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisExportTaskCache(client redis.Cmdable) ExportTaskCache {
+	return &RedisExportTaskCache{
+		client:     client,
+		expiration: time.Hour * 24,
+	}
+}
+
+func (c *RedisExportTaskCache) Set(ctx context.Context, task domain.ExportTask) error {
+	val, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(task.Id), val, c.expiration).Err()
+}
+
+func (c *RedisExportTaskCache) Get(ctx context.Context, id string) (domain.ExportTask, error) {
+	val, err := c.client.Get(ctx, c.key(id)).Bytes()
+	if err != nil {
+		return domain.ExportTask{}, err
+	}
+	var task domain.ExportTask
+	err = json.Unmarshal(val, &task)
+	return task, err
+}
+
+func (c *RedisExportTaskCache) key(id string) string {
+	return fmt.Sprintf("article:export:%s", id)
+}
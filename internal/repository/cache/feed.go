@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"red-feed/internal/domain"
+	"time"
+)
+
+// ErrFeedNotFound 缓存没有或者已经过期
+var ErrFeedNotFound = redis.Nil
+
+type FeedCache interface {
+	Set(ctx context.Context, key string, doc domain.FeedDocument) error
+	Get(ctx context.Context, key string) (domain.FeedDocument, error)
+}
+
+// RedisFeedCache 订阅源不需要很实时，缓存一小段时间，挡住阅读器的轮询
+type RedisFeedCache struct {
+	client     redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisFeedCache(client redis.Cmdable) FeedCache {
+	return &RedisFeedCache{
+		client:     client,
+		expiration: time.Minute * 5,
+	}
+}
+
+func (c *RedisFeedCache) Set(ctx context.Context, key string, doc domain.FeedDocument) error {
+	val, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.key(key), val, c.expiration).Err()
+}
+
+func (c *RedisFeedCache) Get(ctx context.Context, key string) (domain.FeedDocument, error) {
+	val, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		return domain.FeedDocument{}, err
+	}
+	var doc domain.FeedDocument
+	err = json.Unmarshal(val, &doc)
+	return doc, err
+}
+
+func (c *RedisFeedCache) key(key string) string {
+	return fmt.Sprintf("feed:%s", key)
+}
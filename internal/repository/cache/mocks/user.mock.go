@@ -56,6 +56,21 @@ func (mr *MockRedisUserCacheMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisUserCache)(nil).Get), ctx, id)
 }
 
+// GetByIds mocks base method.
+func (m *MockRedisUserCache) GetByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIds", ctx, ids)
+	ret0, _ := ret[0].(map[int64]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIds indicates an expected call of GetByIds.
+func (mr *MockRedisUserCacheMockRecorder) GetByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockRedisUserCache)(nil).GetByIds), ctx, ids)
+}
+
 // Key mocks base method.
 func (m *MockRedisUserCache) Key(id int64) string {
 	m.ctrl.T.Helper()
@@ -83,3 +98,17 @@ func (mr *MockRedisUserCacheMockRecorder) Set(ctx, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisUserCache)(nil).Set), ctx, u)
 }
+
+// SetMulti mocks base method.
+func (m *MockRedisUserCache) SetMulti(ctx context.Context, users []domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMulti", ctx, users)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMulti indicates an expected call of SetMulti.
+func (mr *MockRedisUserCacheMockRecorder) SetMulti(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMulti", reflect.TypeOf((*MockRedisUserCache)(nil).SetMulti), ctx, users)
+}
@@ -12,6 +12,9 @@ import (
 type RedisUserCache interface {
 	Set(ctx context.Context, u domain.User) error
 	Get(ctx context.Context, id int64) (domain.User, error)
+	// GetByIds 一次 MGET，缓存里面没有的不在结果里
+	GetByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error)
+	SetMulti(ctx context.Context, users []domain.User) error
 	Key(id int64) string
 }
 
@@ -52,6 +55,43 @@ func (uc *UserCache) Get(ctx context.Context, id int64) (domain.User, error) {
 	return user, nil
 }
 
+func (uc *UserCache) GetByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, uc.Key(id))
+	}
+	vals, err := uc.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.User, len(ids))
+	for _, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			continue
+		}
+		var u domain.User
+		// 坏掉的缓存当作没有，回源的时候会覆盖掉
+		if json.Unmarshal([]byte(str), &u) == nil {
+			res[u.Id] = u
+		}
+	}
+	return res, nil
+}
+
+func (uc *UserCache) SetMulti(ctx context.Context, users []domain.User) error {
+	pipe := uc.client.Pipeline()
+	for _, u := range users {
+		val, err := json.Marshal(u)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, uc.Key(u.Id), val, uc.expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (uc *UserCache) Key(id int64) string {
 	return fmt.Sprintf("user:info:%d", id)
 }
@@ -10,6 +10,8 @@ import (
 	"time"
 )
 
+var ErrArticleNotFound = gorm.ErrRecordNotFound
+
 type ArticleDao interface {
 	Insert(ctx context.Context, art Article) (int64, error)
 	Update(ctx context.Context, art Article) error
@@ -17,9 +19,31 @@ type ArticleDao interface {
 	SyncStatus(ctx context.Context, artId int64, authorId int64, status uint8) error
 	List(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error)
 	ListPub(ctx context.Context, offset int, limit int) ([]PublishedArticle, error)
+	// ListPubByAuthor 作者公开的线上帖子，按照更新时间倒序
+	ListPubByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]PublishedArticle, error)
 	GetById(ctx context.Context, artId int64) (Article, error)
 	GetPubById(ctx context.Context, artId int64) (PublishedArticle, error)
+	// GetPubByIds 批量查询公开的线上帖子，不公开的直接跳过，不保证顺序
+	GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error)
 	ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]PublishedArticle, error)
+
+	// SoftDelete 把制作库和线上库的帖子都标记为删除，放入作者的回收站
+	SoftDelete(ctx context.Context, artId int64, authorId int64) error
+	// Restore 从回收站恢复为草稿，只恢复 after 之后删除的帖子
+	Restore(ctx context.Context, artId int64, authorId int64, after time.Time) error
+	ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error)
+	// HardDelete 彻底删除回收站中的帖子
+	HardDelete(ctx context.Context, artId int64, authorId int64) error
+	// ListExpiredTrash 按照 id 升序找出 before 之前就放入回收站的帖子，给清理任务用
+	ListExpiredTrash(ctx context.Context, before time.Time, startId int64, limit int) ([]Article, error)
+	// ListAllByAuthor 按照 id 升序遍历作者所有的帖子，包括回收站里面的，给导出用
+	ListAllByAuthor(ctx context.Context, authorId int64, startId int64, limit int) ([]Article, error)
+	// CountByAuthor 作者所有帖子的数量，包括回收站里面的
+	CountByAuthor(ctx context.Context, authorId int64) (int64, error)
+	// UpdateDraftStatus 只修改制作库的状态，当前状态不是 from 的时候返回 ErrArticleNotFound
+	UpdateDraftStatus(ctx context.Context, artId int64, authorId int64, from uint8, to uint8) error
+	// ListPubForIndex 按照 id 升序遍历所有公开的线上帖子，给搜索重建索引用
+	ListPubForIndex(ctx context.Context, startId int64, limit int) ([]PublishedArticle, error)
 }
 
 func NewGORMArticleDao(db *gorm.DB) ArticleDao {
@@ -30,18 +54,168 @@ type GORMArticleDao struct {
 	db *gorm.DB
 }
 
+func (d *GORMArticleDao) SoftDelete(ctx context.Context, artId int64, authorId int64) error {
+	now := time.Now().UnixMilli()
+	deleted := domain.ArticleStatusDeleted.ToUint8()
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? AND author_id = ? AND status <> ?", artId, authorId, deleted).
+			Updates(map[string]any{
+				"utime":  now,
+				"dtime":  now,
+				"status": deleted,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 帖子不存在、不是这个作者的，或者已经在回收站了
+			return ErrArticleNotFound
+		}
+		// 没发表过的草稿，线上库是没有数据的，这里不需要检查 RowsAffected
+		return tx.Model(&PublishedArticle{}).
+			Where("id = ? AND author_id = ?", artId, authorId).
+			Updates(map[string]any{
+				"utime":  now,
+				"dtime":  now,
+				"status": deleted,
+			}).Error
+	})
+}
+
+func (d *GORMArticleDao) Restore(ctx context.Context, artId int64, authorId int64, after time.Time) error {
+	// 恢复之后统一作为草稿，线上库依旧保持删除状态，作者需要重新发表
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ? AND dtime >= ?",
+			artId, authorId, domain.ArticleStatusDeleted.ToUint8(), after.UnixMilli()).
+		Updates(map[string]any{
+			"utime":  time.Now().UnixMilli(),
+			"dtime":  0,
+			"status": domain.ArticleStatusUnPublished.ToUint8(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func (d *GORMArticleDao) ListTrash(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
+	var arts = make([]Article, 0)
+	err := d.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? AND status = ?", authorId, domain.ArticleStatusDeleted.ToUint8()).
+		Offset(offset).
+		Limit(limit).
+		Order("dtime DESC").
+		Find(&arts).Error
+	return arts, err
+}
+
+// HardDelete 删除事件和删除在同一个事务里面写进 outbox，删掉了就一定能通知到其它模块
+func (d *GORMArticleDao) HardDelete(ctx context.Context, artId int64, authorId int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 只能删除回收站里面的，避免误删
+		res := tx.Where("id = ? AND author_id = ? AND status = ?",
+			artId, authorId, domain.ArticleStatusDeleted.ToUint8()).
+			Delete(&Article{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrArticleNotFound
+		}
+		err := tx.Where("id = ?", artId).Delete(&PublishedArticle{}).Error
+		if err != nil {
+			return err
+		}
+		return insertDeleteOutbox(tx, artId, authorId)
+	})
+}
+
+func (d *GORMArticleDao) ListExpiredTrash(ctx context.Context, before time.Time, startId int64, limit int) ([]Article, error) {
+	var arts = make([]Article, 0, limit)
+	err := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id > ? AND status = ? AND dtime < ?", startId, domain.ArticleStatusDeleted.ToUint8(), before.UnixMilli()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
 func (d *GORMArticleDao) ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]PublishedArticle, error) {
 	var res []PublishedArticle
 	err := d.db.WithContext(ctx).
-		Where("utime > ?", start.UnixMilli()).
+		Where("utime > ? AND status = ? AND visibility = ?", start.UnixMilli(),
+			domain.ArticleStatusPublished.ToUint8(), domain.ArticleVisibilityPublic.ToUint8()).
 		Order("utime DESC").Offset(offset).Limit(limit).Find(&res).Error
 	return res, err
 }
 
+func (d *GORMArticleDao) ListAllByAuthor(ctx context.Context, authorId int64, startId int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := d.db.WithContext(ctx).
+		Where("author_id = ? AND id > ?", authorId, startId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (d *GORMArticleDao) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	var cnt int64
+	err := d.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ?", authorId).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+func (d *GORMArticleDao) UpdateDraftStatus(ctx context.Context, artId int64, authorId int64, from uint8, to uint8) error {
+	res := d.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? AND author_id = ? AND status = ?", artId, authorId, from).
+		Updates(map[string]any{
+			"status": to,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrArticleNotFound
+	}
+	return nil
+}
+
+func (d *GORMArticleDao) ListPubForIndex(ctx context.Context, startId int64, limit int) ([]PublishedArticle, error) {
+	var arts []PublishedArticle
+	err := d.db.WithContext(ctx).
+		Where("id > ? AND status = ? AND visibility = ?", startId,
+			domain.ArticleStatusPublished.ToUint8(), domain.ArticleVisibilityPublic.ToUint8()).
+		Order("id ASC").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
 func (d *GORMArticleDao) ListPub(ctx context.Context, offset int, limit int) ([]PublishedArticle, error) {
 	var arts = make([]PublishedArticle, 0)
 	err := d.db.WithContext(ctx).Model(&PublishedArticle{}).
-		Where("status = ?", domain.ArticleStatusPublished.ToUint8()).
+		// 仅关注者可见和仅链接可见的都不出现在公共列表里
+		Where("status = ? AND visibility = ?", domain.ArticleStatusPublished.ToUint8(),
+			domain.ArticleVisibilityPublic.ToUint8()).
+		Offset(offset).
+		Limit(limit).
+		Order("utime DESC").
+		Find(&arts).Error
+	return arts, err
+}
+
+func (d *GORMArticleDao) ListPubByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]PublishedArticle, error) {
+	var arts = make([]PublishedArticle, 0)
+	err := d.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("author_id = ? AND status = ? AND visibility = ?", authorId,
+			domain.ArticleStatusPublished.ToUint8(), domain.ArticleVisibilityPublic.ToUint8()).
 		Offset(offset).
 		Limit(limit).
 		Order("utime DESC").
@@ -59,16 +233,26 @@ func (d *GORMArticleDao) GetById(ctx context.Context, artId int64) (Article, err
 
 func (d *GORMArticleDao) GetPubById(ctx context.Context, artId int64) (PublishedArticle, error) {
 	var art PublishedArticle
-	err := d.db.WithContext(ctx).Model(&Article{}).
-		Where("id = ?", artId).
+	err := d.db.WithContext(ctx).Model(&PublishedArticle{}).
+		// 回收站里面的帖子，读者是看不到的
+		Where("id = ? AND status <> ?", artId, domain.ArticleStatusDeleted.ToUint8()).
 		First(&art).Error
 	return art, err
 }
 
+func (d *GORMArticleDao) GetPubByIds(ctx context.Context, ids []int64) ([]PublishedArticle, error) {
+	var arts = make([]PublishedArticle, 0, len(ids))
+	err := d.db.WithContext(ctx).Model(&PublishedArticle{}).
+		Where("id IN ? AND status = ? AND visibility = ?", ids, domain.ArticleStatusPublished.ToUint8(),
+			domain.ArticleVisibilityPublic.ToUint8()).
+		Find(&arts).Error
+	return arts, err
+}
+
 func (d *GORMArticleDao) List(ctx context.Context, authorId int64, offset int, limit int) ([]Article, error) {
 	var arts = make([]Article, 0)
 	err := d.db.WithContext(ctx).Model(&Article{}).
-		Where("author_id = ?", authorId).
+		Where("author_id = ? AND status <> ?", authorId, domain.ArticleStatusDeleted.ToUint8()).
 		Offset(offset).
 		Limit(limit).
 		Order("utime DESC").
@@ -110,10 +294,12 @@ func (d *GORMArticleDao) Upsert(ctx context.Context, art PublishedArticle) error
 	err := d.db.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns: []clause.Column{{Name: "id"}},
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			"title":   art.Title,
-			"content": art.Content,
-			"utime":   art.Utime,
-			"status":  art.Status,
+			"title":      art.Title,
+			"content":    art.Content,
+			"utime":      art.Utime,
+			"status":     art.Status,
+			"visibility": art.Visibility,
+			"tags":       art.Tags,
 		}),
 	}).Create(&art).Error
 	return err
@@ -146,12 +332,16 @@ func (d *GORMArticleDao) Update(ctx context.Context, art Article) error {
 	art.Utime = now
 	// 依赖 gorm 忽略零值的特性，会用主键进行更新 可读性很差
 	res := d.db.WithContext(ctx).Model(&art).
-		Where("id=? AND author_id = ?", art.Id, art.AuthorId).
+		// 回收站里面的帖子不允许编辑，要先恢复
+		Where("id=? AND author_id = ? AND status <> ?", art.Id, art.AuthorId,
+			domain.ArticleStatusDeleted.ToUint8()).
 		Updates(map[string]any{
-			"title":   art.Title,
-			"content": art.Content,
-			"utime":   art.Utime,
-			"status":  art.Status,
+			"title":      art.Title,
+			"content":    art.Content,
+			"utime":      art.Utime,
+			"status":     art.Status,
+			"visibility": art.Visibility,
+			"tags":       art.Tags,
 		})
 	if res.Error != nil {
 		return res.Error
@@ -166,7 +356,10 @@ func (d *GORMArticleDao) Update(ctx context.Context, art Article) error {
 
 func (d *GORMArticleDao) Insert(ctx context.Context, art Article) (int64, error) {
 	now := time.Now().UnixMilli()
-	art.Ctime = now
+	// 从别的平台导入的帖子保留原来的创建时间
+	if art.Ctime == 0 {
+		art.Ctime = now
+	}
 	art.Utime = now
 	err := d.db.WithContext(ctx).Create(&art).Error
 	return art.Id, err
@@ -178,8 +371,14 @@ type Article struct {
 	Content  string `gorm:"type=BLOB"`
 	AuthorId int64  `gorm:"index"`
 	Status   uint8
-	Ctime    int64
-	Utime    int64
+	// Visibility 可见范围，只对已发表的帖子有意义
+	Visibility uint8
+	// Tags 标签，用逗号分隔，标签本身不允许有逗号
+	Tags  string `gorm:"type:varchar(1024)"`
+	Ctime int64
+	Utime int64
+	// Dtime 放入回收站的时间，清理任务按照这个字段来找过期的帖子
+	Dtime int64 `gorm:"index"`
 }
 
 type PublishedArticle struct {
@@ -0,0 +1,75 @@
+package dao
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ArticleOutboxDAO 帖子彻底删除的事件和删除在同一个事务里面写进 outbox 表，再由任务发到 Kafka
+//
+//go:generate mockgen -source=./article_outbox.go -package=daomocks -destination=mocks/article_outbox.mock.go ArticleOutboxDAO
+type ArticleOutboxDAO interface {
+	// Claim 按照 id 顺序认领一批还没有发出去的事件，lease 之内别的实例拿不到
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]ArticleOutbox, error)
+	// Delete 发出去之后就删掉
+	Delete(ctx context.Context, ids []int64) error
+}
+
+type GORMArticleOutboxDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleOutboxDAO(db *gorm.DB) ArticleOutboxDAO {
+	return &GORMArticleOutboxDAO{
+		db: db,
+	}
+}
+
+func (d *GORMArticleOutboxDAO) Claim(ctx context.Context, limit int, lease time.Duration) ([]ArticleOutbox, error) {
+	now := time.Now().UnixMilli()
+	// 先抢占再按照 token 查出来，多个实例同时跑也不会拿到同一行
+	token := uuid.NewString()
+	res := d.db.WithContext(ctx).Model(&ArticleOutbox{}).
+		Where("lease_until < ?", now).
+		Order("id").Limit(limit).
+		Updates(map[string]any{
+			"claim_token": token,
+			"lease_until": now + lease.Milliseconds(),
+		})
+	if res.Error != nil || res.RowsAffected == 0 {
+		return nil, res.Error
+	}
+	var rows []ArticleOutbox
+	err := d.db.WithContext(ctx).Where("claim_token = ?", token).Order("id").Find(&rows).Error
+	return rows, err
+}
+
+func (d *GORMArticleOutboxDAO) Delete(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return d.db.WithContext(ctx).Where("id IN ?", ids).Delete(&ArticleOutbox{}).Error
+}
+
+// insertDeleteOutbox 必须和彻底删除在同一个事务里面
+func insertDeleteOutbox(tx *gorm.DB, artId int64, authorId int64) error {
+	return tx.Create(&ArticleOutbox{
+		Aid:   artId,
+		Uid:   authorId,
+		Ctime: time.Now().UnixMilli(),
+	}).Error
+}
+
+// ArticleOutbox 还没有发出去的彻底删除事件
+type ArticleOutbox struct {
+	Id    int64 `gorm:"primaryKey,autoIncrement"`
+	Aid   int64
+	Uid   int64
+	Ctime int64
+	// ClaimToken 最近一次认领的 token
+	ClaimToken string `gorm:"type:varchar(64);index"`
+	// LeaseUntil 认领到什么时候，毫秒数
+	LeaseUntil int64 `gorm:"index"`
+}
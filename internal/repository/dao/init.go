@@ -10,6 +10,8 @@ func InitTable(db *gorm.DB) error {
 		&Article{},
 		&PublishedArticle{},
 		&Job{},
+		&ArticleReview{},
+		&ArticleOutbox{},
 	)
 
 }
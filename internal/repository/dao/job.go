@@ -3,10 +3,19 @@ package dao
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
+// ErrNoJob 现在没有可以抢占的任务
+var ErrNoJob = gorm.ErrRecordNotFound
+
+// jobLeaseTimeout 处于 running 状态，但是超过这么久没有续约的，认为执行的实例已经挂了，别人可以再抢
+const jobLeaseTimeout = time.Minute * 3
+
 type JobDAO interface {
+	// Upsert 按照 Name 插入任务，已经有了就只更新 cron 表达式、执行器和配置
+	Upsert(ctx context.Context, j Job) error
 	Preempt(ctx context.Context) (Job, error)
 	Release(ctx context.Context, id int64) error
 	UpdateUtime(ctx context.Context, id int64) error
@@ -18,6 +27,23 @@ type GORMJobDAO struct {
 	db *gorm.DB
 }
 
+func NewGORMJobDAO(db *gorm.DB) JobDAO {
+	return &GORMJobDAO{
+		db: db,
+	}
+}
+
+func (g *GORMJobDAO) Upsert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Status = jobStatusWaiting
+	j.Ctime = now
+	j.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"cron", "executor", "cfg", "utime"}),
+	}).Create(&j).Error
+}
+
 func (g *GORMJobDAO) UpdateUtime(ctx context.Context, id int64) error {
 	return g.db.WithContext(ctx).Model(&Job{}).
 		Where("id =?", id).Updates(map[string]any{
@@ -33,7 +59,7 @@ func (g *GORMJobDAO) UpdateNextTime(ctx context.Context, id int64, next time.Tim
 }
 
 func (g *GORMJobDAO) Stop(ctx context.Context, id int64) error {
-	return g.db.WithContext(ctx).
+	return g.db.WithContext(ctx).Model(&Job{}).
 		Where("id = ?", id).Updates(map[string]any{
 		"status": jobStatusPaused,
 		"utime":  time.Now().UnixMilli(),
@@ -59,13 +85,16 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 	// 特定一个 goroutine，最差情况下，要循环一百次
 	db := g.db.WithContext(ctx)
 	for {
-		now := time.Now()
+		now := time.Now().UnixMilli()
 		var j Job
 		// 分布式任务调度系统
 		// 1. 一次拉一批，我一次性取出 100 条来，然后，我随机从某一条开始，向后开始抢占
 		// 2. 我搞个随机偏移量，0-100 生成一个随机偏移量。兜底：第一轮没查到，偏移量回归到 0
 		// 3. 我搞一个 id 取余分配，status = ? AND next_time <=? AND id%10 = ? 兜底：不加余数条件，取next_time 最老的
-		err := db.WithContext(ctx).Where("status = ? AND next_time <=?", jobStatusWaiting, now).
+		// 续约超时的也可以抢，不然执行到一半挂掉的任务永远不会再跑
+		err := db.WithContext(ctx).
+			Where("(status = ? AND next_time <= ?) OR (status = ? AND utime < ?)",
+				jobStatusWaiting, now, jobStatusRunning, now-jobLeaseTimeout.Milliseconds()).
 			First(&j).Error
 		// 你找到了，可以被抢占的
 		// 找到之后你要干嘛？你要抢占
@@ -86,7 +115,7 @@ func (g *GORMJobDAO) Preempt(ctx context.Context) (Job, error) {
 				"version": j.Version + 1,
 			})
 		if res.Error != nil {
-			return Job{}, err
+			return Job{}, res.Error
 		}
 		if res.RowsAffected == 0 {
 			// 抢占失败，你只能说，我要继续下一轮
@@ -0,0 +1,95 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gormMySQL "gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGORMJobDAO_Preempt(t *testing.T) {
+	testcases := []struct {
+		name    string
+		mock    func(t *testing.T) (*sql.DB, sqlmock.Sqlmock)
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "到时间的和续约超时的都可以抢",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` WHERE \\(status = \\? AND next_time <= \\?\\) OR \\(status = \\? AND utime < \\?\\).*").
+					WithArgs(jobStatusWaiting, sqlmock.AnyArg(), jobStatusRunning, sqlmock.AnyArg(), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "article_purge", 3))
+				mock.ExpectExec("UPDATE `jobs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDB, mock
+			},
+			wantId: 1,
+		},
+		{
+			name: "被别人抢先了，再抢下一个",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "article_purge", 3))
+				mock.ExpectExec("UPDATE `jobs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT \\* FROM `jobs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(2, "ranking", 1))
+				mock.ExpectExec("UPDATE `jobs` SET .*").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return mockDB, mock
+			},
+			wantId: 2,
+		},
+		{
+			name: "没有任务",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				return mockDB, mock
+			},
+			wantErr: ErrNoJob,
+		},
+		{
+			name: "抢占出错",
+			mock: func(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+				mockDB, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectQuery("SELECT \\* FROM `jobs` .*").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "version"}).AddRow(1, "article_purge", 3))
+				mock.ExpectExec("UPDATE `jobs` SET .*").
+					WillReturnError(errors.New("db error"))
+				return mockDB, mock
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock := tc.mock(t)
+			db, err := gorm.Open(gormMySQL.New(gormMySQL.Config{
+				Conn:                      mockDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+			j, err := NewGORMJobDAO(db).Preempt(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, j.Id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -17,32 +17,32 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockGORMUserDAO is a mock of UserDAO interface.
-type MockGORMUserDAO struct {
+// MockUserDAO is a mock of UserDAO interface.
+type MockUserDAO struct {
 	ctrl     *gomock.Controller
-	recorder *MockGORMUserDAOMockRecorder
+	recorder *MockUserDAOMockRecorder
 	isgomock struct{}
 }
 
-// MockGORMUserDAOMockRecorder is the mock recorder for MockGORMUserDAO.
-type MockGORMUserDAOMockRecorder struct {
-	mock *MockGORMUserDAO
+// MockUserDAOMockRecorder is the mock recorder for MockUserDAO.
+type MockUserDAOMockRecorder struct {
+	mock *MockUserDAO
 }
 
-// NewMockGORMUserDAO creates a new mock instance.
-func NewMockGORMUserDAO(ctrl *gomock.Controller) *MockGORMUserDAO {
-	mock := &MockGORMUserDAO{ctrl: ctrl}
-	mock.recorder = &MockGORMUserDAOMockRecorder{mock}
+// NewMockUserDAO creates a new mock instance.
+func NewMockUserDAO(ctrl *gomock.Controller) *MockUserDAO {
+	mock := &MockUserDAO{ctrl: ctrl}
+	mock.recorder = &MockUserDAOMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockGORMUserDAO) EXPECT() *MockGORMUserDAOMockRecorder {
+func (m *MockUserDAO) EXPECT() *MockUserDAOMockRecorder {
 	return m.recorder
 }
 
 // FindByEmail mocks base method.
-func (m *MockGORMUserDAO) FindByEmail(ctx context.Context, email string) (dao.User, error) {
+func (m *MockUserDAO) FindByEmail(ctx context.Context, email string) (dao.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
 	ret0, _ := ret[0].(dao.User)
@@ -51,13 +51,13 @@ func (m *MockGORMUserDAO) FindByEmail(ctx context.Context, email string) (dao.Us
 }
 
 // FindByEmail indicates an expected call of FindByEmail.
-func (mr *MockGORMUserDAOMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockGORMUserDAO)(nil).FindByEmail), ctx, email)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserDAO)(nil).FindByEmail), ctx, email)
 }
 
 // FindById mocks base method.
-func (m *MockGORMUserDAO) FindById(ctx context.Context, id int64) (dao.User, error) {
+func (m *MockUserDAO) FindById(ctx context.Context, id int64) (dao.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindById", ctx, id)
 	ret0, _ := ret[0].(dao.User)
@@ -66,13 +66,28 @@ func (m *MockGORMUserDAO) FindById(ctx context.Context, id int64) (dao.User, err
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockGORMUserDAOMockRecorder) FindById(ctx, id any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) FindById(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockGORMUserDAO)(nil).FindById), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserDAO)(nil).FindById), ctx, id)
+}
+
+// FindByIds mocks base method.
+func (m *MockUserDAO) FindByIds(ctx context.Context, ids []int64) ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ctx, ids)
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockUserDAOMockRecorder) FindByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockUserDAO)(nil).FindByIds), ctx, ids)
 }
 
 // FindByPhone mocks base method.
-func (m *MockGORMUserDAO) FindByPhone(ctx context.Context, phone string) (dao.User, error) {
+func (m *MockUserDAO) FindByPhone(ctx context.Context, phone string) (dao.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByPhone", ctx, phone)
 	ret0, _ := ret[0].(dao.User)
@@ -81,13 +96,13 @@ func (m *MockGORMUserDAO) FindByPhone(ctx context.Context, phone string) (dao.Us
 }
 
 // FindByPhone indicates an expected call of FindByPhone.
-func (mr *MockGORMUserDAOMockRecorder) FindByPhone(ctx, phone any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) FindByPhone(ctx, phone any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhone", reflect.TypeOf((*MockGORMUserDAO)(nil).FindByPhone), ctx, phone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhone", reflect.TypeOf((*MockUserDAO)(nil).FindByPhone), ctx, phone)
 }
 
 // FindByWechat mocks base method.
-func (m *MockGORMUserDAO) FindByWechat(ctx context.Context, openID string) (dao.User, error) {
+func (m *MockUserDAO) FindByWechat(ctx context.Context, openID string) (dao.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindByWechat", ctx, openID)
 	ret0, _ := ret[0].(dao.User)
@@ -96,13 +111,13 @@ func (m *MockGORMUserDAO) FindByWechat(ctx context.Context, openID string) (dao.
 }
 
 // FindByWechat indicates an expected call of FindByWechat.
-func (mr *MockGORMUserDAOMockRecorder) FindByWechat(ctx, openID any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) FindByWechat(ctx, openID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockGORMUserDAO)(nil).FindByWechat), ctx, openID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWechat", reflect.TypeOf((*MockUserDAO)(nil).FindByWechat), ctx, openID)
 }
 
 // Insert mocks base method.
-func (m *MockGORMUserDAO) Insert(ctx context.Context, u dao.User) error {
+func (m *MockUserDAO) Insert(ctx context.Context, u dao.User) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Insert", ctx, u)
 	ret0, _ := ret[0].(error)
@@ -110,7 +125,7 @@ func (m *MockGORMUserDAO) Insert(ctx context.Context, u dao.User) error {
 }
 
 // Insert indicates an expected call of Insert.
-func (mr *MockGORMUserDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
+func (mr *MockUserDAOMockRecorder) Insert(ctx, u any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockGORMUserDAO)(nil).Insert), ctx, u)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockUserDAO)(nil).Insert), ctx, u)
 }
@@ -0,0 +1,120 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"red-feed/internal/domain"
+	"time"
+)
+
+// ErrReviewNotPending 审核单不存在，或者已经被处理过了
+var ErrReviewNotPending = errors.New("审核单不存在或者已经处理")
+
+type ArticleReviewDAO interface {
+	// Insert 同一篇帖子只保留一个待审核的审核单，旧的会被作废
+	Insert(ctx context.Context, r ArticleReview) (int64, error)
+	FindById(ctx context.Context, id int64) (ArticleReview, error)
+	// ListPending 先提交的先审核
+	ListPending(ctx context.Context, offset int, limit int) ([]ArticleReview, error)
+	ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]ArticleReview, error)
+	// UpdateStatus 只能处理待审核的审核单，并发审核的时候只有一个能成功
+	UpdateStatus(ctx context.Context, id int64, status uint8, reviewerId int64, reason string) error
+	// Reopen 审核通过之后发表失败，把审核单恢复成待审核，让审核人员重试
+	Reopen(ctx context.Context, id int64) error
+}
+
+type GORMArticleReviewDAO struct {
+	db *gorm.DB
+}
+
+func NewGORMArticleReviewDAO(db *gorm.DB) ArticleReviewDAO {
+	return &GORMArticleReviewDAO{db: db}
+}
+
+func (d *GORMArticleReviewDAO) Insert(ctx context.Context, r ArticleReview) (int64, error) {
+	now := time.Now().UnixMilli()
+	r.Ctime = now
+	r.Utime = now
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&ArticleReview{}).
+			Where("article_id = ? AND status = ?", r.ArticleId, domain.ReviewStatusPending.ToUint8()).
+			Updates(map[string]any{
+				"status": domain.ReviewStatusCanceled.ToUint8(),
+				"utime":  now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&r).Error
+	})
+	return r.Id, err
+}
+
+func (d *GORMArticleReviewDAO) FindById(ctx context.Context, id int64) (ArticleReview, error) {
+	var r ArticleReview
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&r).Error
+	return r, err
+}
+
+func (d *GORMArticleReviewDAO) ListPending(ctx context.Context, offset int, limit int) ([]ArticleReview, error) {
+	var res []ArticleReview
+	err := d.db.WithContext(ctx).
+		Where("status = ?", domain.ReviewStatusPending.ToUint8()).
+		Order("id ASC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMArticleReviewDAO) ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]ArticleReview, error) {
+	var res []ArticleReview
+	err := d.db.WithContext(ctx).
+		Where("author_id = ?", authorId).
+		Order("id DESC").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (d *GORMArticleReviewDAO) UpdateStatus(ctx context.Context, id int64, status uint8, reviewerId int64, reason string) error {
+	res := d.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("id = ? AND status = ?", id, domain.ReviewStatusPending.ToUint8()).
+		Updates(map[string]any{
+			"status":      status,
+			"reviewer_id": reviewerId,
+			"reason":      reason,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrReviewNotPending
+	}
+	return nil
+}
+
+func (d *GORMArticleReviewDAO) Reopen(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("id = ? AND status = ?", id, domain.ReviewStatusApproved.ToUint8()).
+		Updates(map[string]any{
+			"status":      domain.ReviewStatusPending.ToUint8(),
+			"reviewer_id": 0,
+			"utime":       time.Now().UnixMilli(),
+		}).Error
+}
+
+type ArticleReview struct {
+	Id        int64  `gorm:"primaryKey,autoIncrement"`
+	ArticleId int64  `gorm:"index"`
+	AuthorId  int64  `gorm:"index"`
+	Title     string `gorm:"type:varchar(1024)"`
+	// Hits 命中的敏感词，JSON 数组
+	Hits       string `gorm:"type:varchar(4096)"`
+	Status     uint8  `gorm:"index"`
+	Reason     string `gorm:"type:varchar(1024)"`
+	ReviewerId int64
+	Ctime      int64
+	Utime      int64
+}
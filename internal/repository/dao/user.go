@@ -18,6 +18,8 @@ var (
 type UserDAO interface {
 	FindByEmail(ctx context.Context, email string) (User, error)
 	FindById(ctx context.Context, id int64) (User, error)
+	// FindByIds 不存在的 id 直接忽略
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	FindByWechat(ctx context.Context, openID string) (User, error)
 	Insert(ctx context.Context, u User) error
@@ -51,6 +53,12 @@ func (dao *GORMUserDAO) FindById(ctx context.Context, id int64) (User, error) {
 	return user, result.Error
 }
 
+func (dao *GORMUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var users []User
+	err := dao.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (dao *GORMUserDAO) FindByPhone(ctx context.Context, phone string) (User, error) {
 	var user User
 	result := dao.db.WithContext(ctx).Where("phone = ?", phone).First(&user)
@@ -81,6 +89,7 @@ type User struct {
 	Email    sql.NullString `gorm:"unique"`
 	Phone    sql.NullString `gorm:"unique"`
 	Password string
+	Nickname string `gorm:"type:varchar(128)"`
 	// 微信的字段
 	WechatUnionID sql.NullString
 	WechatOpenID  sql.NullString `gorm:"unique"`
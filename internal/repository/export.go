@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository/cache"
+	"time"
+)
+
+var ErrExportTaskNotFound = cache.ErrExportTaskNotFound
+
+// ExportRepository 保存后台导出任务的状态和生成的压缩包
+type ExportRepository interface {
+	SaveTask(ctx context.Context, task domain.ExportTask) error
+	GetTask(ctx context.Context, id string) (domain.ExportTask, error)
+	CreateFile(ctx context.Context, id string) (io.WriteCloser, error)
+	OpenFile(ctx context.Context, id string) (io.ReadCloser, error)
+	// CleanFiles 删除 before 之前生成的压缩包，返回删除的数量
+	CleanFiles(ctx context.Context, before time.Time) (int, error)
+}
+
+// LocalExportRepository 压缩包放在本地目录。
+// 多实例部署的时候，要么把目录挂载成共享存储，要么换成对象存储的实现，
+// 不然下载请求落到别的实例上会找不到文件。
+type LocalExportRepository struct {
+	cache cache.ExportTaskCache
+	dir   string
+}
+
+func NewLocalExportRepository(cache cache.ExportTaskCache, dir string) ExportRepository {
+	return &LocalExportRepository{
+		cache: cache,
+		dir:   dir,
+	}
+}
+
+func (r *LocalExportRepository) SaveTask(ctx context.Context, task domain.ExportTask) error {
+	return r.cache.Set(ctx, task)
+}
+
+func (r *LocalExportRepository) GetTask(ctx context.Context, id string) (domain.ExportTask, error) {
+	return r.cache.Get(ctx, id)
+}
+
+func (r *LocalExportRepository) CreateFile(ctx context.Context, id string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(r.path(id))
+}
+
+func (r *LocalExportRepository) OpenFile(ctx context.Context, id string) (io.ReadCloser, error) {
+	return os.Open(r.path(id))
+}
+
+func (r *LocalExportRepository) CleanFiles(ctx context.Context, before time.Time) (int, error) {
+	entries, err := os.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	cnt := 0
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || e.IsDir() || !info.ModTime().Before(before) {
+			continue
+		}
+		if os.Remove(filepath.Join(r.dir, e.Name())) == nil {
+			cnt++
+		}
+	}
+	return cnt, nil
+}
+
+// path 任务 id 是服务端生成的 uuid，不会有路径穿越的问题
+func (r *LocalExportRepository) path(id string) string {
+	return filepath.Join(r.dir, id+".zip")
+}
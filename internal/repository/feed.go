@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository/cache"
+)
+
+var ErrFeedNotFound = cache.ErrFeedNotFound
+
+// FeedRepository 生成好的订阅源只放缓存，过期了就重新生成
+type FeedRepository interface {
+	Get(ctx context.Context, key string) (domain.FeedDocument, error)
+	Set(ctx context.Context, key string, doc domain.FeedDocument) error
+}
+
+type CachedFeedRepository struct {
+	cache cache.FeedCache
+}
+
+func NewCachedFeedRepository(cache cache.FeedCache) FeedRepository {
+	return &CachedFeedRepository{
+		cache: cache,
+	}
+}
+
+func (r *CachedFeedRepository) Get(ctx context.Context, key string) (domain.FeedDocument, error) {
+	return r.cache.Get(ctx, key)
+}
+
+func (r *CachedFeedRepository) Set(ctx context.Context, key string, doc domain.FeedDocument) error {
+	return r.cache.Set(ctx, key, doc)
+}
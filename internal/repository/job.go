@@ -7,7 +7,11 @@ import (
 	"time"
 )
 
+var ErrNoJob = dao.ErrNoJob
+
 type JobRepository interface {
+	// Upsert 注册任务，第一次注册的时候按照 cron 表达式算出下一次执行的时间
+	Upsert(ctx context.Context, j domain.Job) error
 	Preempt(ctx context.Context) (domain.Job, error)
 	Release(ctx context.Context, id int64) error
 	UpdateUtime(ctx context.Context, id int64) error
@@ -19,6 +23,22 @@ type CronJobRepository struct {
 	dao dao.JobDAO
 }
 
+func NewCronJobRepository(dao dao.JobDAO) JobRepository {
+	return &CronJobRepository{
+		dao: dao,
+	}
+}
+
+func (p *CronJobRepository) Upsert(ctx context.Context, j domain.Job) error {
+	return p.dao.Upsert(ctx, dao.Job{
+		Cfg:      j.Cfg,
+		Executor: j.Executor,
+		Name:     j.Name,
+		Cron:     j.Cron,
+		NextTime: j.NextTime().UnixMilli(),
+	})
+}
+
 func (p *CronJobRepository) UpdateUtime(ctx context.Context, id int64) error {
 	return p.dao.UpdateUtime(ctx, id)
 }
@@ -44,6 +64,7 @@ func (p *CronJobRepository) Preempt(ctx context.Context) (domain.Job, error) {
 		Cfg:      j.Cfg,
 		Id:       j.Id,
 		Name:     j.Name,
+		Cron:     j.Cron,
 		Executor: j.Executor,
 	}, nil
 }
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/article_event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/article_event.go -package=repomocks -destination=./internal/repository/mocks/article_event.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleEventRepository is a mock of ArticleEventRepository interface.
+type MockArticleEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleEventRepositoryMockRecorder is the mock recorder for MockArticleEventRepository.
+type MockArticleEventRepositoryMockRecorder struct {
+	mock *MockArticleEventRepository
+}
+
+// NewMockArticleEventRepository creates a new mock instance.
+func NewMockArticleEventRepository(ctrl *gomock.Controller) *MockArticleEventRepository {
+	mock := &MockArticleEventRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleEventRepository) EXPECT() *MockArticleEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeleteEvents mocks base method.
+func (m *MockArticleEventRepository) ClaimDeleteEvents(ctx context.Context, limit int, lease time.Duration) ([]domain.ArticleDeleteEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeleteEvents", ctx, limit, lease)
+	ret0, _ := ret[0].([]domain.ArticleDeleteEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeleteEvents indicates an expected call of ClaimDeleteEvents.
+func (mr *MockArticleEventRepositoryMockRecorder) ClaimDeleteEvents(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeleteEvents", reflect.TypeOf((*MockArticleEventRepository)(nil).ClaimDeleteEvents), ctx, limit, lease)
+}
+
+// MarkSent mocks base method.
+func (m *MockArticleEventRepository) MarkSent(ctx context.Context, evts []domain.ArticleDeleteEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, evts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockArticleEventRepositoryMockRecorder) MarkSent(ctx, evts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockArticleEventRepository)(nil).MarkSent), ctx, evts)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/export.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/export.go -package=repomocks -destination=./internal/repository/mocks/export.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	io "io"
+	domain "red-feed/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CleanFiles mocks base method.
+func (m *MockExportRepository) CleanFiles(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanFiles", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CleanFiles indicates an expected call of CleanFiles.
+func (mr *MockExportRepositoryMockRecorder) CleanFiles(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanFiles", reflect.TypeOf((*MockExportRepository)(nil).CleanFiles), ctx, before)
+}
+
+// CreateFile mocks base method.
+func (m *MockExportRepository) CreateFile(ctx context.Context, id string) (io.WriteCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFile", ctx, id)
+	ret0, _ := ret[0].(io.WriteCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFile indicates an expected call of CreateFile.
+func (mr *MockExportRepositoryMockRecorder) CreateFile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFile", reflect.TypeOf((*MockExportRepository)(nil).CreateFile), ctx, id)
+}
+
+// GetTask mocks base method.
+func (m *MockExportRepository) GetTask(ctx context.Context, id string) (domain.ExportTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, id)
+	ret0, _ := ret[0].(domain.ExportTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockExportRepositoryMockRecorder) GetTask(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockExportRepository)(nil).GetTask), ctx, id)
+}
+
+// OpenFile mocks base method.
+func (m *MockExportRepository) OpenFile(ctx context.Context, id string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", ctx, id)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockExportRepositoryMockRecorder) OpenFile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockExportRepository)(nil).OpenFile), ctx, id)
+}
+
+// SaveTask mocks base method.
+func (m *MockExportRepository) SaveTask(ctx context.Context, task domain.ExportTask) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTask indicates an expected call of SaveTask.
+func (mr *MockExportRepositoryMockRecorder) SaveTask(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTask", reflect.TypeOf((*MockExportRepository)(nil).SaveTask), ctx, task)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/review.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/review.go -package=repomocks -destination=./internal/repository/mocks/review.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "red-feed/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleReviewRepository is a mock of ArticleReviewRepository interface.
+type MockArticleReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleReviewRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockArticleReviewRepositoryMockRecorder is the mock recorder for MockArticleReviewRepository.
+type MockArticleReviewRepositoryMockRecorder struct {
+	mock *MockArticleReviewRepository
+}
+
+// NewMockArticleReviewRepository creates a new mock instance.
+func NewMockArticleReviewRepository(ctrl *gomock.Controller) *MockArticleReviewRepository {
+	mock := &MockArticleReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleReviewRepository) EXPECT() *MockArticleReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockArticleReviewRepository) Create(ctx context.Context, r domain.ArticleReview) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, r)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleReviewRepositoryMockRecorder) Create(ctx, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleReviewRepository)(nil).Create), ctx, r)
+}
+
+// FindById mocks base method.
+func (m *MockArticleReviewRepository) FindById(ctx context.Context, id int64) (domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockArticleReviewRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockArticleReviewRepository)(nil).FindById), ctx, id)
+}
+
+// ListByAuthor mocks base method.
+func (m *MockArticleReviewRepository) ListByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAuthor", ctx, authorId, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAuthor indicates an expected call of ListByAuthor.
+func (mr *MockArticleReviewRepositoryMockRecorder) ListByAuthor(ctx, authorId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockArticleReviewRepository)(nil).ListByAuthor), ctx, authorId, offset, limit)
+}
+
+// ListPending mocks base method.
+func (m *MockArticleReviewRepository) ListPending(ctx context.Context, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockArticleReviewRepositoryMockRecorder) ListPending(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockArticleReviewRepository)(nil).ListPending), ctx, offset, limit)
+}
+
+// Reopen mocks base method.
+func (m *MockArticleReviewRepository) Reopen(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockArticleReviewRepositoryMockRecorder) Reopen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockArticleReviewRepository)(nil).Reopen), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockArticleReviewRepository) UpdateStatus(ctx context.Context, id int64, status domain.ReviewStatus, reviewerId int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, status, reviewerId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockArticleReviewRepositoryMockRecorder) UpdateStatus(ctx, id, status, reviewerId, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockArticleReviewRepository)(nil).UpdateStatus), ctx, id, status, reviewerId, reason)
+}
@@ -86,6 +86,21 @@ func (mr *MockCachedUserRepositoryMockRecorder) FindById(ctx, id any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCachedUserRepository)(nil).FindById), ctx, id)
 }
 
+// FindByIds mocks base method.
+func (m *MockCachedUserRepository) FindByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ctx, ids)
+	ret0, _ := ret[0].(map[int64]domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockCachedUserRepositoryMockRecorder) FindByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockCachedUserRepository)(nil).FindByIds), ctx, ids)
+}
+
 // FindByPhone mocks base method.
 func (m *MockCachedUserRepository) FindByPhone(ctx *gin.Context, phone string) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ecodeclub/ekit/slice"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository/dao"
+	"time"
+)
+
+var (
+	ErrReviewNotFound   = dao.ErrArticleNotFound
+	ErrReviewNotPending = dao.ErrReviewNotPending
+)
+
+type ArticleReviewRepository interface {
+	Create(ctx context.Context, r domain.ArticleReview) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.ArticleReview, error)
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error)
+	ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]domain.ArticleReview, error)
+	UpdateStatus(ctx context.Context, id int64, status domain.ReviewStatus, reviewerId int64, reason string) error
+	Reopen(ctx context.Context, id int64) error
+}
+
+type articleReviewRepository struct {
+	dao dao.ArticleReviewDAO
+}
+
+func NewArticleReviewRepository(dao dao.ArticleReviewDAO) ArticleReviewRepository {
+	return &articleReviewRepository{dao: dao}
+}
+
+func (r *articleReviewRepository) Create(ctx context.Context, review domain.ArticleReview) (int64, error) {
+	hits, err := json.Marshal(review.Hits)
+	if err != nil {
+		return 0, err
+	}
+	return r.dao.Insert(ctx, dao.ArticleReview{
+		ArticleId: review.ArticleId,
+		AuthorId:  review.AuthorId,
+		Title:     review.Title,
+		Hits:      string(hits),
+		Status:    review.Status.ToUint8(),
+	})
+}
+
+func (r *articleReviewRepository) FindById(ctx context.Context, id int64) (domain.ArticleReview, error) {
+	review, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.ArticleReview{}, err
+	}
+	return r.toDomain(review), nil
+}
+
+func (r *articleReviewRepository) ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error) {
+	res, err := r.dao.ListPending(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.ArticleReview) domain.ArticleReview {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *articleReviewRepository) ListByAuthor(ctx context.Context, authorId int64, offset int, limit int) ([]domain.ArticleReview, error) {
+	res, err := r.dao.ListByAuthor(ctx, authorId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.ArticleReview) domain.ArticleReview {
+		return r.toDomain(src)
+	}), nil
+}
+
+func (r *articleReviewRepository) UpdateStatus(ctx context.Context, id int64, status domain.ReviewStatus, reviewerId int64, reason string) error {
+	return r.dao.UpdateStatus(ctx, id, status.ToUint8(), reviewerId, reason)
+}
+
+func (r *articleReviewRepository) Reopen(ctx context.Context, id int64) error {
+	return r.dao.Reopen(ctx, id)
+}
+
+func (r *articleReviewRepository) toDomain(review dao.ArticleReview) domain.ArticleReview {
+	var hits []string
+	// 解析失败不影响审核，审核人员还可以看帖子原文
+	_ = json.Unmarshal([]byte(review.Hits), &hits)
+	return domain.ArticleReview{
+		Id:         review.Id,
+		ArticleId:  review.ArticleId,
+		AuthorId:   review.AuthorId,
+		Title:      review.Title,
+		Hits:       hits,
+		Status:     domain.ReviewStatus(review.Status),
+		Reason:     review.Reason,
+		ReviewerId: review.ReviewerId,
+		Ctime:      time.UnixMilli(review.Ctime),
+		Utime:      time.UnixMilli(review.Utime),
+	}
+}
@@ -20,6 +20,8 @@ var (
 type CachedUserRepository interface {
 	Create(ctx context.Context, u domain.User) error
 	FindById(ctx context.Context, id int64) (domain.User, error)
+	// FindByIds 先批量查缓存，没命中的一次查数据库，不存在的用户不在结果里
+	FindByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error)
 	FindByPhone(ctx *gin.Context, phone string) (domain.User, error)
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindByWechat(ctx context.Context, openID string) (domain.User, error)
@@ -88,6 +90,41 @@ func (r *UserRepository) FindById(ctx context.Context, id int64) (domain.User, e
 	return u, err
 }
 
+func (r *UserRepository) FindByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	if len(ids) == 0 {
+		return map[int64]domain.User{}, nil
+	}
+	res, err := r.cache.GetByIds(ctx, ids)
+	if err != nil {
+		// 缓存出问题了就全部查数据库
+		res = make(map[int64]domain.User, len(ids))
+	}
+	missed := make([]int64, 0, len(ids)-len(res))
+	for _, id := range ids {
+		if _, ok := res[id]; !ok {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) == 0 {
+		return res, nil
+	}
+	ues, err := r.dao.FindByIds(ctx, missed)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]domain.User, 0, len(ues))
+	for _, ue := range ues {
+		u := r.entityToDomain(ue)
+		res[u.Id] = u
+		users = append(users, u)
+	}
+	if len(users) > 0 {
+		// 回写缓存失败不影响结果，下次再回源就是了
+		_ = r.cache.SetMulti(ctx, users)
+	}
+	return res, nil
+}
+
 func (r *UserRepository) Create(ctx context.Context, u domain.User) error {
 	return r.dao.Insert(ctx, r.domainToEntity(u))
 }
@@ -98,6 +135,7 @@ func (r *UserRepository) domainToEntity(user domain.User) dao.User {
 		Email:    sql.NullString{String: user.Email, Valid: user.Email != ""},
 		Phone:    sql.NullString{String: user.Phone, Valid: user.Phone != ""},
 		Password: user.Password,
+		Nickname: user.Nickname,
 		WechatOpenID: sql.NullString{
 			String: user.WechatInfo.OpenID,
 			Valid:  user.WechatInfo.OpenID != "",
@@ -116,6 +154,7 @@ func (r *UserRepository) entityToDomain(user dao.User) domain.User {
 		Email:    user.Email.String,
 		Password: user.Password,
 		Phone:    user.Phone.String,
+		Nickname: user.Nickname,
 		WechatInfo: domain.WechatInfo{
 			OpenID:  user.WechatOpenID.String,
 			UnionID: user.WechatUnionID.String,
@@ -31,7 +31,7 @@ func TestCachedUserRepository_FindById(t *testing.T) {
 		{
 			name: "缓存未命中，查询成功",
 			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
-				ud := daomocks.NewMockGORMUserDAO(ctrl)
+				ud := daomocks.NewMockUserDAO(ctrl)
 				uc := cachemocks.NewMockRedisUserCache(ctrl)
 				uc.EXPECT().Get(context.Background(), int64(123)).Return(domain.User{}, errors.New("no user found"))
 				ud.EXPECT().FindById(context.Background(), int64(123)).
@@ -71,7 +71,7 @@ func TestCachedUserRepository_FindById(t *testing.T) {
 		{
 			name: "缓存直接命中",
 			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
-				ud := daomocks.NewMockGORMUserDAO(ctrl)
+				ud := daomocks.NewMockUserDAO(ctrl)
 				uc := cachemocks.NewMockRedisUserCache(ctrl)
 				uc.EXPECT().Get(context.Background(), int64(123)).Return(domain.User{
 					Id:       123,
@@ -96,7 +96,7 @@ func TestCachedUserRepository_FindById(t *testing.T) {
 		{
 			name: "缓存未命中，且走数据库查询失败",
 			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
-				ud := daomocks.NewMockGORMUserDAO(ctrl)
+				ud := daomocks.NewMockUserDAO(ctrl)
 				uc := cachemocks.NewMockRedisUserCache(ctrl)
 				uc.EXPECT().Get(context.Background(), int64(123)).Return(domain.User{}, errors.New("no user found"))
 				ud.EXPECT().FindById(context.Background(), int64(123)).
@@ -122,3 +122,77 @@ func TestCachedUserRepository_FindById(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedUserRepository_FindByIds(t *testing.T) {
+	testcases := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache)
+		ids       []int64
+		wantUsers map[int64]domain.User
+		wantErr   error
+	}{
+		{
+			name: "全部命中缓存",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
+				ud := daomocks.NewMockUserDAO(ctrl)
+				uc := cachemocks.NewMockRedisUserCache(ctrl)
+				uc.EXPECT().GetByIds(gomock.Any(), []int64{1, 2}).Return(map[int64]domain.User{
+					1: {Id: 1, Nickname: "大明"},
+					2: {Id: 2, Nickname: "小明"},
+				}, nil)
+				return ud, uc
+			},
+			ids: []int64{1, 2},
+			wantUsers: map[int64]domain.User{
+				1: {Id: 1, Nickname: "大明"},
+				2: {Id: 2, Nickname: "小明"},
+			},
+		},
+		{
+			name: "部分命中，没命中的一次查数据库并回写缓存",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
+				ud := daomocks.NewMockUserDAO(ctrl)
+				uc := cachemocks.NewMockRedisUserCache(ctrl)
+				uc.EXPECT().GetByIds(gomock.Any(), []int64{1, 2, 3}).Return(map[int64]domain.User{
+					1: {Id: 1, Nickname: "大明"},
+				}, nil)
+				// 3 不存在
+				ud.EXPECT().FindByIds(gomock.Any(), []int64{2, 3}).Return([]dao.User{
+					{Id: 2, Nickname: "小明"},
+				}, nil)
+				uc.EXPECT().SetMulti(gomock.Any(), []domain.User{
+					{Id: 2, Nickname: "小明", Ctime: time.UnixMilli(0)},
+				}).Return(errors.New("redis错误"))
+				return ud, uc
+			},
+			ids: []int64{1, 2, 3},
+			wantUsers: map[int64]domain.User{
+				1: {Id: 1, Nickname: "大明"},
+				2: {Id: 2, Nickname: "小明", Ctime: time.UnixMilli(0)},
+			},
+		},
+		{
+			name: "缓存出错，全部查数据库",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, cache.RedisUserCache) {
+				ud := daomocks.NewMockUserDAO(ctrl)
+				uc := cachemocks.NewMockRedisUserCache(ctrl)
+				uc.EXPECT().GetByIds(gomock.Any(), []int64{1}).Return(nil, errors.New("redis错误"))
+				ud.EXPECT().FindByIds(gomock.Any(), []int64{1}).Return(nil, errors.New("db错误"))
+				return ud, uc
+			},
+			ids:     []int64{1},
+			wantErr: errors.New("db错误"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ud, uc := tc.mock(ctrl)
+			repo := NewUserRepository(ud, uc)
+			users, err := repo.FindByIds(context.Background(), tc.ids)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantUsers, users)
+		})
+	}
+}
@@ -0,0 +1,512 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"path"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository"
+	"red-feed/pkg/frontmatter"
+	"red-feed/pkg/logger"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvalidArchive     = errors.New("不是合法的 zip 文件")
+	ErrTooManyFiles       = errors.New("压缩包里面的文件太多")
+	ErrExportTaskNotFound = errors.New("导出任务不存在或者已经过期")
+	ErrExportNotReady     = errors.New("导出任务还没有完成")
+)
+
+//go:generate mockgen -source=archive.go -package=svcmocks -destination=mocks/archive.mock.go ArchiveService
+type ArchiveService interface {
+	// Import 把 zip 里面的 Markdown 文件按照 front-matter 里面的状态导入，单个文件失败不影响其它文件
+	Import(ctx context.Context, uid int64, r io.ReaderAt, size int64) ([]domain.ImportItem, error)
+	// Export 把作者所有的帖子打包成 zip 写到 w 里面，返回导出的帖子数量
+	Export(ctx context.Context, uid int64, w io.Writer) (int, error)
+	// NeedAsyncExport 帖子太多的时候，同步导出会占用连接太久，要走后台任务
+	NeedAsyncExport(ctx context.Context, uid int64) (bool, error)
+	StartExport(ctx context.Context, uid int64) (domain.ExportTask, error)
+	GetExportTask(ctx context.Context, uid int64, taskId string) (domain.ExportTask, error)
+	// OpenExport 打开后台任务生成的压缩包，调用者负责关闭
+	OpenExport(ctx context.Context, uid int64, taskId string) (io.ReadCloser, error)
+}
+
+// articleMeta Markdown 文件开头的 front-matter
+type articleMeta struct {
+	Title   string    `yaml:"title"`
+	Tags    []string  `yaml:"tags,omitempty"`
+	Status  string    `yaml:"status,omitempty"`
+	Created time.Time `yaml:"created,omitempty"`
+	Updated time.Time `yaml:"updated,omitempty"`
+}
+
+// exportManifest 压缩包里面的 manifest.json，记录每篇帖子的状态变化和审核记录
+type exportManifest struct {
+	AuthorId   int64             `json:"authorId"`
+	ExportedAt time.Time         `json:"exportedAt"`
+	Articles   []manifestArticle `json:"articles"`
+}
+
+type manifestArticle struct {
+	Id         int64            `json:"id"`
+	File       string           `json:"file"`
+	Title      string           `json:"title"`
+	Status     string           `json:"status"`
+	Visibility uint8            `json:"visibility"`
+	Tags       []string         `json:"tags"`
+	Ctime      time.Time        `json:"ctime"`
+	Utime      time.Time        `json:"utime"`
+	Dtime      *time.Time       `json:"dtime,omitempty"`
+	Reviews    []manifestReview `json:"reviews,omitempty"`
+}
+
+type manifestReview struct {
+	Id     int64     `json:"id"`
+	Hits   []string  `json:"hits"`
+	Status uint8     `json:"status"`
+	Reason string    `json:"reason,omitempty"`
+	Ctime  time.Time `json:"ctime"`
+	Utime  time.Time `json:"utime"`
+}
+
+var articleStatusNames = map[domain.ArticleStatus]string{
+	domain.ArticleStatusUnPublished: "draft",
+	domain.ArticleStatusPublished:   "published",
+	domain.ArticleStatusPrivate:     "private",
+	domain.ArticleStatusDeleted:     "deleted",
+	domain.ArticleStatusReviewing:   "reviewing",
+	domain.ArticleStatusRejected:    "rejected",
+}
+
+type archiveService struct {
+	artSvc     ArticleService
+	artRepo    repository.ArticleRepository
+	moderation ModerationService
+	exportRepo repository.ExportRepository
+	l          logger.Logger
+
+	maxFiles    int
+	maxFileSize int64
+	batchSize   int
+	// syncLimit 帖子数量超过这个值就走后台任务
+	syncLimit int
+	// exportTimeout 单个后台任务的最长时间
+	exportTimeout time.Duration
+	// exportRetention 压缩包保留的时间，和任务状态的过期时间一致
+	exportRetention time.Duration
+	// sem 限制同时运行的后台任务数量，导出比较耗数据库
+	sem chan struct{}
+}
+
+func NewArchiveService(artSvc ArticleService, artRepo repository.ArticleRepository,
+	moderation ModerationService, exportRepo repository.ExportRepository, l logger.Logger) ArchiveService {
+	return &archiveService{
+		artSvc:          artSvc,
+		artRepo:         artRepo,
+		moderation:      moderation,
+		exportRepo:      exportRepo,
+		l:               l,
+		maxFiles:        500,
+		maxFileSize:     1 << 20,
+		batchSize:       100,
+		syncLimit:       200,
+		exportTimeout:   time.Minute * 30,
+		exportRetention: time.Hour * 24,
+		sem:             make(chan struct{}, 2),
+	}
+}
+
+func (s *archiveService) Import(ctx context.Context, uid int64, r io.ReaderAt, size int64) ([]domain.ImportItem, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, ErrInvalidArchive
+	}
+	files := make([]*zip.File, 0, len(zr.File))
+	for _, f := range zr.File {
+		if s.isMarkdown(f) {
+			files = append(files, f)
+		}
+	}
+	if len(files) > s.maxFiles {
+		return nil, ErrTooManyFiles
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	res := make([]domain.ImportItem, 0, len(files))
+	for _, f := range files {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+		res = append(res, s.importFile(ctx, uid, f))
+	}
+	return res, nil
+}
+
+func (s *archiveService) isMarkdown(f *zip.File) bool {
+	if f.FileInfo().IsDir() {
+		return false
+	}
+	// macOS 打包的时候会带上 __MACOSX 和 ._ 开头的元数据文件
+	base := path.Base(f.Name)
+	if strings.HasPrefix(f.Name, "__MACOSX/") || strings.HasPrefix(base, ".") {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(base))
+	return ext == ".md" || ext == ".markdown"
+}
+
+func (s *archiveService) importFile(ctx context.Context, uid int64, f *zip.File) domain.ImportItem {
+	item := domain.ImportItem{File: f.Name}
+	data, err := s.readFile(f)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	var meta articleMeta
+	body, err := frontmatter.Parse(data, &meta)
+	if err != nil {
+		item.Error = "front-matter 格式不对"
+		return item
+	}
+	tags, ok := domain.NormalizeTags(meta.Tags)
+	if !ok {
+		item.Error = "标签不对"
+		return item
+	}
+	status, ok := s.parseStatus(meta.Status)
+	if !ok {
+		item.Error = "状态不对"
+		return item
+	}
+	item.Title = meta.Title
+	if item.Title == "" {
+		item.Title = s.guessTitle(f.Name, body)
+	}
+	id, status, err := s.importArticle(ctx, domain.Article{
+		Title:   item.Title,
+		Content: string(body),
+		Tags:    tags,
+		Author: domain.Author{
+			Id: uid,
+		},
+		Ctime: meta.Created,
+	}, status)
+	if err != nil {
+		s.l.Error("导入帖子失败", logger.Error(err), logger.Int64("uid", uid),
+			logger.String("file", f.Name))
+		item.Error = "系统错误"
+		return item
+	}
+	item.Id = id
+	item.Status = articleStatusNames[status]
+	return item
+}
+
+// parseStatus 没有写状态的当作草稿
+func (s *archiveService) parseStatus(name string) (domain.ArticleStatus, bool) {
+	if name == "" {
+		return domain.ArticleStatusUnPublished, true
+	}
+	for status, n := range articleStatusNames {
+		if n == name {
+			return status, true
+		}
+	}
+	return domain.ArticleStatusUnknown, false
+}
+
+// importArticle 按照导出时候的状态导入，返回导入之后的状态。
+// 发表要重新过一遍敏感词，命中了就和正常发表一样等审核；
+// 审核中和审核没通过的不能直接导入审核结果，作为草稿让作者重新提交
+func (s *archiveService) importArticle(ctx context.Context, art domain.Article,
+	status domain.ArticleStatus) (int64, domain.ArticleStatus, error) {
+	switch status {
+	case domain.ArticleStatusPublished, domain.ArticleStatusPrivate:
+		id, err := s.artSvc.Publish(ctx, art)
+		if err == ErrArticleUnderReview {
+			return id, domain.ArticleStatusReviewing, nil
+		}
+		if err != nil || status == domain.ArticleStatusPublished {
+			return id, status, err
+		}
+		art.Id = id
+		return id, status, s.artSvc.WithDraw(ctx, art)
+	case domain.ArticleStatusDeleted:
+		id, err := s.artSvc.Save(ctx, art)
+		if err != nil {
+			return 0, status, err
+		}
+		art.Id = id
+		return id, status, s.artSvc.Delete(ctx, art)
+	default:
+		id, err := s.artSvc.Save(ctx, art)
+		return id, domain.ArticleStatusUnPublished, err
+	}
+}
+
+func (s *archiveService) readFile(f *zip.File) ([]byte, error) {
+	// 压缩包里面声明的大小不可信，读的时候还要再限制一次，防止 zip 炸弹
+	if f.UncompressedSize64 > uint64(s.maxFileSize) {
+		return nil, errors.New("文件太大")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, errors.New("文件损坏")
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, s.maxFileSize+1))
+	if err != nil {
+		return nil, errors.New("文件损坏")
+	}
+	if int64(len(data)) > s.maxFileSize {
+		return nil, errors.New("文件太大")
+	}
+	if !utf8.Valid(data) {
+		return nil, errors.New("文件不是 UTF-8 编码")
+	}
+	return data, nil
+}
+
+// guessTitle 没有 front-matter 的时候，用第一个一级标题，再没有就用文件名
+func (s *archiveService) guessTitle(name string, body []byte) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if title, ok := strings.CutPrefix(line, "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	base := path.Base(name)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func (s *archiveService) Export(ctx context.Context, uid int64, w io.Writer) (int, error) {
+	reviews, err := s.reviewsByArticle(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+	zw := zip.NewWriter(w)
+	manifest := exportManifest{
+		AuthorId:   uid,
+		ExportedAt: time.Now(),
+		Articles:   []manifestArticle{},
+	}
+	var startId int64
+	for {
+		arts, err := s.artRepo.ListAllByAuthor(ctx, uid, startId, s.batchSize)
+		if err != nil {
+			return 0, err
+		}
+		for _, art := range arts {
+			item, err := s.writeArticle(zw, art)
+			if err != nil {
+				return 0, err
+			}
+			item.Reviews = reviews[art.Id]
+			manifest.Articles = append(manifest.Articles, item)
+		}
+		if len(arts) < s.batchSize {
+			break
+		}
+		startId = arts[len(arts)-1].Id
+	}
+	fw, err := zw.Create("manifest.json")
+	if err != nil {
+		return 0, err
+	}
+	enc := json.NewEncoder(fw)
+	enc.SetIndent("", "  ")
+	if err = enc.Encode(manifest); err != nil {
+		return 0, err
+	}
+	return len(manifest.Articles), zw.Close()
+}
+
+func (s *archiveService) writeArticle(zw *zip.Writer, art domain.Article) (manifestArticle, error) {
+	status := articleStatusNames[art.Status]
+	item := manifestArticle{
+		Id:         art.Id,
+		File:       fmt.Sprintf("articles/%d-%s.md", art.Id, s.slug(art.Title)),
+		Title:      art.Title,
+		Status:     status,
+		Visibility: art.Visibility.ToUint8(),
+		Tags:       art.Tags,
+		Ctime:      art.Ctime,
+		Utime:      art.Utime,
+	}
+	if !art.Dtime.IsZero() {
+		item.Dtime = &art.Dtime
+	}
+	data, err := frontmatter.Render(articleMeta{
+		Title:   art.Title,
+		Tags:    art.Tags,
+		Status:  status,
+		Created: art.Ctime,
+		Updated: art.Utime,
+	}, []byte(art.Content))
+	if err != nil {
+		return manifestArticle{}, err
+	}
+	fw, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     item.File,
+		Method:   zip.Deflate,
+		Modified: art.Utime,
+	})
+	if err != nil {
+		return manifestArticle{}, err
+	}
+	_, err = fw.Write(data)
+	return item, err
+}
+
+func (s *archiveService) reviewsByArticle(ctx context.Context, uid int64) (map[int64][]manifestReview, error) {
+	res := make(map[int64][]manifestReview)
+	for offset := 0; ; offset += s.batchSize {
+		reviews, err := s.moderation.ListByAuthor(ctx, uid, offset, s.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reviews {
+			res[r.ArticleId] = append(res[r.ArticleId], manifestReview{
+				Id:     r.Id,
+				Hits:   r.Hits,
+				Status: r.Status.ToUint8(),
+				Reason: r.Reason,
+				Ctime:  r.Ctime,
+				Utime:  r.Utime,
+			})
+		}
+		if len(reviews) < s.batchSize {
+			return res, nil
+		}
+	}
+}
+
+// slug 文件名里面只保留字母、数字和汉字，太长的截断
+func (s *archiveService) slug(title string) string {
+	var sb strings.Builder
+	cnt := 0
+	lastDash := true
+	for _, r := range title {
+		if cnt >= 50 {
+			break
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			lastDash = false
+			cnt++
+			continue
+		}
+		if !lastDash {
+			sb.WriteByte('-')
+			lastDash = true
+			cnt++
+		}
+	}
+	res := strings.Trim(sb.String(), "-")
+	if res == "" {
+		return "untitled"
+	}
+	return res
+}
+
+func (s *archiveService) NeedAsyncExport(ctx context.Context, uid int64) (bool, error) {
+	cnt, err := s.artRepo.CountByAuthor(ctx, uid)
+	if err != nil {
+		return false, err
+	}
+	return cnt > int64(s.syncLimit), nil
+}
+
+func (s *archiveService) StartExport(ctx context.Context, uid int64) (domain.ExportTask, error) {
+	now := time.Now()
+	task := domain.ExportTask{
+		Id:     uuid.New().String(),
+		Uid:    uid,
+		Status: domain.ExportTaskStatusRunning,
+		Ctime:  now,
+		Utime:  now,
+	}
+	err := s.exportRepo.SaveTask(ctx, task)
+	if err != nil {
+		return domain.ExportTask{}, err
+	}
+	go s.runExport(task)
+	return task, nil
+}
+
+func (s *archiveService) runExport(task domain.ExportTask) {
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+	ctx, cancel := context.WithTimeout(context.Background(), s.exportTimeout)
+	defer cancel()
+
+	// 顺手清理过期的压缩包
+	if _, err := s.exportRepo.CleanFiles(ctx, time.Now().Add(-s.exportRetention)); err != nil {
+		s.l.Error("清理过期的导出文件失败", logger.Error(err))
+	}
+	cnt, err := s.exportToFile(ctx, task)
+	task.Utime = time.Now()
+	if err != nil {
+		s.l.Error("导出帖子失败", logger.Error(err), logger.Int64("uid", task.Uid),
+			logger.String("task", task.Id))
+		task.Status = domain.ExportTaskStatusFailed
+		task.Error = "导出失败，请重试"
+	} else {
+		task.Status = domain.ExportTaskStatusDone
+		task.Cnt = cnt
+	}
+	if err = s.exportRepo.SaveTask(ctx, task); err != nil {
+		s.l.Error("更新导出任务失败", logger.Error(err), logger.String("task", task.Id))
+	}
+}
+
+func (s *archiveService) exportToFile(ctx context.Context, task domain.ExportTask) (int, error) {
+	f, err := s.exportRepo.CreateFile(ctx, task.Id)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := s.Export(ctx, task.Uid, f)
+	if er := f.Close(); err == nil {
+		err = er
+	}
+	return cnt, err
+}
+
+func (s *archiveService) GetExportTask(ctx context.Context, uid int64, taskId string) (domain.ExportTask, error) {
+	task, err := s.exportRepo.GetTask(ctx, taskId)
+	if err == repository.ErrExportTaskNotFound {
+		return domain.ExportTask{}, ErrExportTaskNotFound
+	}
+	if err != nil {
+		return domain.ExportTask{}, err
+	}
+	// 不能看别人的导出任务
+	if task.Uid != uid {
+		return domain.ExportTask{}, ErrExportTaskNotFound
+	}
+	return task, nil
+}
+
+func (s *archiveService) OpenExport(ctx context.Context, uid int64, taskId string) (io.ReadCloser, error) {
+	task, err := s.GetExportTask(ctx, uid, taskId)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != domain.ExportTaskStatusDone {
+		return nil, ErrExportNotReady
+	}
+	return s.exportRepo.OpenFile(ctx, taskId)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"red-feed/internal/domain"
+	repomocks "red-feed/internal/repository/mocks"
+	svcmocks "red-feed/internal/service/mocks"
+	"red-feed/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveService_Import(t *testing.T) {
+	created := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	files := map[string]string{
+		"posts/a.md":          "---\ntitle: 第一篇\ntags: [Go, Go]\nstatus: published\ncreated: 2024-01-02T15:04:05Z\n---\n正文一\n",
+		"posts/b.markdown":    "# 从标题来的\n正文二",
+		"posts/c.md":          "没有标题",
+		"posts/d.md":          "---\ntitle: 没结束\n",
+		"posts/e.md":          "---\ntags: [\"a,b\"]\n---\n标签带逗号",
+		"posts/f.md":          "---\ntitle: 保存失败\n---\n",
+		"posts/g.md":          "---\ntitle: 状态不对\nstatus: hidden\n---\n",
+		"posts/h.md":          "---\ntitle: 仅自己可见\nstatus: private\n---\n正文八",
+		"posts/readme.txt":    "不是 Markdown",
+		"__MACOSX/posts/a.md": "元数据",
+		"posts/._a.md":        "元数据",
+	}
+	testCases := []struct {
+		name    string
+		data    []byte
+		mock    func(ctrl *gomock.Controller) ArticleService
+		want    []domain.ImportItem
+		wantErr error
+	}{
+		{
+			name: "逐个导入，单个失败不影响其它文件",
+			data: buildZip(t, files),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), domain.Article{
+					Title:   "第一篇",
+					Content: "正文一\n",
+					Tags:    []string{"Go"},
+					Author:  domain.Author{Id: 100},
+					Ctime:   created,
+				}).Return(int64(1), nil)
+				svc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "从标题来的",
+					Content: "# 从标题来的\n正文二",
+					Tags:    []string{},
+					Author:  domain.Author{Id: 100},
+				}).Return(int64(2), nil)
+				svc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "c",
+					Content: "没有标题",
+					Tags:    []string{},
+					Author:  domain.Author{Id: 100},
+				}).Return(int64(3), nil)
+				svc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:  "保存失败",
+					Tags:   []string{},
+					Author: domain.Author{Id: 100},
+				}).Return(int64(0), errors.New("db错误"))
+				svc.EXPECT().Publish(gomock.Any(), domain.Article{
+					Title:   "仅自己可见",
+					Content: "正文八",
+					Tags:    []string{},
+					Author:  domain.Author{Id: 100},
+				}).Return(int64(8), nil)
+				svc.EXPECT().WithDraw(gomock.Any(), domain.Article{
+					Id:      8,
+					Title:   "仅自己可见",
+					Content: "正文八",
+					Tags:    []string{},
+					Author:  domain.Author{Id: 100},
+				}).Return(nil)
+				return svc
+			},
+			want: []domain.ImportItem{
+				{File: "posts/a.md", Id: 1, Title: "第一篇", Status: "published"},
+				{File: "posts/b.markdown", Id: 2, Title: "从标题来的", Status: "draft"},
+				{File: "posts/c.md", Id: 3, Title: "c", Status: "draft"},
+				{File: "posts/d.md", Error: "front-matter 格式不对"},
+				{File: "posts/e.md", Error: "标签不对"},
+				{File: "posts/f.md", Title: "保存失败", Error: "系统错误"},
+				{File: "posts/g.md", Error: "状态不对"},
+				{File: "posts/h.md", Id: 8, Title: "仅自己可见", Status: "private"},
+			},
+		},
+		{
+			name: "不是 zip",
+			data: []byte("hello"),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: ErrInvalidArchive,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewArchiveService(tc.mock(ctrl), nil, nil, nil, &logger.NopLogger{})
+			res, err := svc.Import(context.Background(), 100, bytes.NewReader(tc.data), int64(len(tc.data)))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestArchiveService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	artRepo := repomocks.NewMockArticleRepository(ctrl)
+	moderation := svcmocks.NewMockModerationService(ctrl)
+	moderation.EXPECT().ListByAuthor(gomock.Any(), int64(100), 0, 2).Return([]domain.ArticleReview{
+		{Id: 7, ArticleId: 2, Hits: []string{"赌博"}, Status: domain.ReviewStatusRejected, Reason: "涉及赌博"},
+	}, nil)
+	artRepo.EXPECT().ListAllByAuthor(gomock.Any(), int64(100), int64(0), 2).Return([]domain.Article{
+		{Id: 1, Title: "Hello, 世界!", Content: "正文一", Tags: []string{"Go"},
+			Status: domain.ArticleStatusPublished, Ctime: now, Utime: now},
+		{Id: 2, Title: "", Content: "正文二", Status: domain.ArticleStatusRejected, Ctime: now, Utime: now},
+	}, nil)
+	artRepo.EXPECT().ListAllByAuthor(gomock.Any(), int64(100), int64(2), 2).Return([]domain.Article{
+		{Id: 3, Title: "删掉的", Content: "正文三", Status: domain.ArticleStatusDeleted,
+			Ctime: now, Utime: now, Dtime: now},
+	}, nil)
+	svc := NewArchiveService(nil, artRepo, moderation, nil, &logger.NopLogger{}).(*archiveService)
+	svc.batchSize = 2
+
+	var buf bytes.Buffer
+	cnt, err := svc.Export(context.Background(), 100, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 3, cnt)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	contents := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+		contents[f.Name] = string(data)
+	}
+	assert.Contains(t, contents, "articles/1-Hello-世界.md")
+	assert.Contains(t, contents, "articles/2-untitled.md")
+	assert.Contains(t, contents, "articles/3-删掉的.md")
+	assert.True(t, strings.HasSuffix(contents["articles/1-Hello-世界.md"], "---\n\n正文一"))
+	assert.Contains(t, contents["articles/3-删掉的.md"], "status: deleted")
+
+	var manifest exportManifest
+	require.NoError(t, json.Unmarshal([]byte(contents["manifest.json"]), &manifest))
+	require.Len(t, manifest.Articles, 3)
+	assert.Equal(t, "rejected", manifest.Articles[1].Status)
+	assert.Equal(t, []manifestReview{
+		{Id: 7, Hits: []string{"赌博"}, Status: domain.ReviewStatusRejected.ToUint8(), Reason: "涉及赌博"},
+	}, manifest.Articles[1].Reviews)
+	assert.NotNil(t, manifest.Articles[2].Dtime)
+
+	// 导出的文件可以原样导入回来
+	artSvc := svcmocks.NewMockArticleService(ctrl)
+	artSvc.EXPECT().Publish(gomock.Any(), domain.Article{
+		Title:   "Hello, 世界!",
+		Content: "正文一",
+		Tags:    []string{"Go"},
+		Author:  domain.Author{Id: 200},
+		Ctime:   now,
+	}).Return(int64(11), nil)
+	// 审核没通过的重新作为草稿，删掉的导入之后放回回收站
+	artSvc.EXPECT().Save(gomock.Any(), gomock.Any()).Return(int64(12), nil)
+	artSvc.EXPECT().Save(gomock.Any(), gomock.Any()).Return(int64(13), nil)
+	artSvc.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	svc.artSvc = artSvc
+	items, err := svc.Import(context.Background(), 200, bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	statuses := make(map[string]string, len(items))
+	for _, item := range items {
+		statuses[item.File] = item.Status
+	}
+	assert.Equal(t, map[string]string{
+		"articles/1-Hello-世界.md": "published",
+		"articles/2-untitled.md": "draft",
+		"articles/3-删掉的.md":      "deleted",
+	}, statuses)
+}
+
+func TestArchiveService_NeedAsyncExport(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cnt     int64
+		err     error
+		want    bool
+		wantErr error
+	}{
+		{name: "没超过同步上限", cnt: 2, want: false},
+		{name: "超过同步上限", cnt: 3, want: true},
+		{name: "数据库错误", err: errors.New("db错误"), wantErr: errors.New("db错误")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artRepo := repomocks.NewMockArticleRepository(ctrl)
+			artRepo.EXPECT().CountByAuthor(gomock.Any(), int64(100)).Return(tc.cnt, tc.err)
+			svc := NewArchiveService(nil, artRepo, nil, nil, &logger.NopLogger{}).(*archiveService)
+			svc.syncLimit = 2
+			res, err := svc.NeedAsyncExport(context.Background(), 100)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func buildZip(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
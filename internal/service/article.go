@@ -9,6 +9,8 @@ import (
 	"time"
 )
 
+var ErrArticleNotFound = repository.ErrArticleNotFound
+
 //go:generate mockgen -source=article.go -package=svcmocks -destination=mocks/article.mock.go ArticleService
 type ArticleService interface {
 	Save(ctx context.Context, article domain.Article) (id int64, err error)
@@ -16,15 +18,78 @@ type ArticleService interface {
 	WithDraw(ctx context.Context, article domain.Article) error
 	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
 	ListPub(ctx context.Context, offset int, limit int) ([]domain.Article, error)
+	ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
 	ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) // 线上库列表只取7天内的，用于热榜计算
 	GetById(ctx context.Context, id int64) (domain.Article, error)
-	GetPublishedById(ctx context.Context, id, uId int64) (domain.Article, error)
+	// GetPubByIds 批量查询公开的帖子，不公开的和不存在的都不返回
+	GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// GetPublishedById 读者查看帖子，token 是分享链接里面的签名，没有的时候传空字符串。
+	// 匿名读者 Uid 为 0，阅读数按照 DeviceId 来算
+	GetPublishedById(ctx context.Context, id int64, reader domain.Reader, token string) (domain.Article, error)
+	// GenerateShareToken 作者生成预览链接的签名，草稿和仅链接可见的帖子都可以通过它分享出去
+	GenerateShareToken(ctx context.Context, article domain.Article, ttl time.Duration) (string, error)
+
+	Delete(ctx context.Context, article domain.Article) error                                  // 放入回收站
+	Restore(ctx context.Context, article domain.Article) error                                 // 从回收站恢复为草稿
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) // 回收站列表
+	PermanentDelete(ctx context.Context, article domain.Article) error                         // 从回收站彻底删除
+	// PurgeTrash 清理 id 在 startId 之后的一批过期帖子，返回清理的数量和下一批的 startId，清理完了 nextId 为 0
+	PurgeTrash(ctx context.Context, startId int64, batchSize int) (cnt int, nextId int64, err error)
 }
 
 type articleService struct {
 	repo     repository.ArticleRepository
 	producer article.Producer
-	l        logger.Logger
+	tokenSvc PreviewTokenService
+	follow   FollowChecker
+	// moderation 发表之前先过一遍敏感词
+	moderation ModerationService
+	l          logger.Logger
+	// trashRetention 回收站保留时间，超过之后不能恢复，会被清理任务彻底删除
+	trashRetention time.Duration
+}
+
+func (s *articleService) Delete(ctx context.Context, article domain.Article) error {
+	err := s.repo.Delete(ctx, article.Id, article.Author.Id)
+	if err != nil {
+		return err
+	}
+	s.producePublishEvent(ctx, article.Id, article.Author.Id, false)
+	return nil
+}
+
+func (s *articleService) Restore(ctx context.Context, article domain.Article) error {
+	return s.repo.Restore(ctx, article.Id, article.Author.Id, time.Now().Add(-s.trashRetention))
+}
+
+func (s *articleService) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return s.repo.ListTrash(ctx, uid, offset, limit)
+}
+
+func (s *articleService) PermanentDelete(ctx context.Context, article domain.Article) error {
+	// 删除事件在同一个事务里面写进了 outbox，由 ArticleOutboxRelayJob 发出去
+	return s.repo.HardDelete(ctx, article.Id, article.Author.Id)
+}
+
+func (s *articleService) PurgeTrash(ctx context.Context, startId int64, batchSize int) (int, int64, error) {
+	arts, err := s.repo.ListExpiredTrash(ctx, time.Now().Add(-s.trashRetention), startId, batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	cnt := 0
+	for _, art := range arts {
+		err = s.repo.HardDelete(ctx, art.Id, art.Author.Id)
+		if err != nil {
+			// 单篇失败不影响别的，跳过去，下一次调度再重试
+			s.l.Error("清理回收站帖子失败", logger.Error(err), logger.Int64("artId", art.Id))
+			continue
+		}
+		cnt++
+	}
+	if len(arts) < batchSize {
+		return cnt, 0, nil
+	}
+	return cnt, arts[len(arts)-1].Id, nil
 }
 
 func (s *articleService) ListPubForRanking(ctx context.Context, start time.Time, offset int, limit int) ([]domain.Article, error) {
@@ -35,19 +100,48 @@ func (s *articleService) ListPub(ctx context.Context, offset int, limit int) ([]
 	return s.repo.ListPub(ctx, offset, limit)
 }
 
+func (s *articleService) ListPubByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	return s.repo.ListPubByAuthor(ctx, uid, offset, limit)
+}
+
+func (s *articleService) GetPubByIds(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return s.repo.GetPubByIds(ctx, ids)
+}
+
 func (s *articleService) GetById(ctx context.Context, id int64) (domain.Article, error) {
 	return s.repo.GetById(ctx, id)
 }
 
-func (s *articleService) GetPublishedById(ctx context.Context, id, uId int64) (domain.Article, error) {
+func (s *articleService) GetPublishedById(ctx context.Context, id int64, reader domain.Reader, token string) (domain.Article, error) {
 	art, err := s.repo.GetPubById(ctx, id)
-	if err != nil {
+	switch {
+	case err == nil && art.Status == domain.ArticleStatusPublished:
+		err = s.checkVisible(ctx, art, reader.Uid, token)
+		if err != nil {
+			return domain.Article{}, err
+		}
+	case token != "" && (err == nil || err == ErrArticleNotFound):
+		// 没有发表过，或者已经撤回了，只能通过预览链接看制作库里的版本
+		// 预览不算阅读数
+		return s.preview(ctx, id, token)
+	case err != nil:
 		return domain.Article{}, err
+	default:
+		return domain.Article{}, ErrArticleNotFound
 	}
+	readTime := time.Now()
 	go func() {
+		// 是不是重复阅读、刷量，交给消费者去判断
 		er := s.producer.ProduceReadEvent(ctx, article.ReadEvent{
-			Uid: uId,
-			Aid: art.Id,
+			Uid:       reader.Uid,
+			Aid:       art.Id,
+			DeviceId:  reader.DeviceId,
+			Ip:        reader.Ip,
+			UserAgent: reader.UserAgent,
+			Ctime:     readTime.UnixMilli(),
 		})
 		if er != nil {
 			s.l.Error("发送阅读事件失败", logger.Error(er))
@@ -56,17 +150,119 @@ func (s *articleService) GetPublishedById(ctx context.Context, id, uId int64) (d
 	return art, nil
 }
 
+// checkVisible 看不到的时候统一返回 ErrArticleNotFound，不告诉对方帖子是否存在
+func (s *articleService) checkVisible(ctx context.Context, art domain.Article, uId int64, token string) error {
+	if art.Visibility == domain.ArticleVisibilityPublic || (uId > 0 && uId == art.Author.Id) {
+		return nil
+	}
+	switch art.Visibility {
+	case domain.ArticleVisibilityFollowers:
+		if uId <= 0 {
+			return ErrArticleNotFound
+		}
+		ok, err := s.follow.IsFollower(ctx, art.Author.Id, uId)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrArticleNotFound
+		}
+		return nil
+	case domain.ArticleVisibilityLink:
+		if s.tokenSvc.Verify(token, art.Id) != nil {
+			return ErrArticleNotFound
+		}
+		return nil
+	default:
+		return ErrArticleNotFound
+	}
+}
+
+func (s *articleService) preview(ctx context.Context, id int64, token string) (domain.Article, error) {
+	err := s.tokenSvc.Verify(token, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	art, err := s.repo.GetById(ctx, id)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if art.Status == domain.ArticleStatusDeleted {
+		return domain.Article{}, ErrArticleNotFound
+	}
+	return art, nil
+}
+
+func (s *articleService) GenerateShareToken(ctx context.Context, art domain.Article, ttl time.Duration) (string, error) {
+	src, err := s.repo.GetById(ctx, art.Id)
+	if err != nil {
+		return "", err
+	}
+	// 只有作者本人能分享
+	if src.Author.Id != art.Author.Id || src.Status == domain.ArticleStatusDeleted {
+		return "", ErrArticleNotFound
+	}
+	return s.tokenSvc.Generate(src.Id, src.Author.Id, ttl)
+}
+
 func (s *articleService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
 	return s.repo.List(ctx, uid, offset, limit)
 }
 
 func (s *articleService) WithDraw(ctx context.Context, article domain.Article) error {
-	return s.repo.SyncStatus(ctx, article.Id, article.Author.Id, domain.ArticleStatusPrivate)
+	err := s.repo.SyncStatus(ctx, article.Id, article.Author.Id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	s.producePublishEvent(ctx, article.Id, article.Author.Id, false)
+	return nil
 }
 
+// Publish 命中敏感词的时候只保存到制作库，返回帖子 id 和 ErrArticleUnderReview
 func (s *articleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
+	if hits := s.moderation.Check(ctx, article); len(hits) > 0 {
+		return s.submitReview(ctx, article, hits)
+	}
 	article.Status = domain.ArticleStatusPublished
-	return s.repo.Sync(ctx, article)
+	id, err := s.repo.Sync(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	s.producePublishEvent(ctx, id, article.Author.Id, true)
+	return id, nil
+}
+
+func (s *articleService) submitReview(ctx context.Context, article domain.Article, hits []string) (int64, error) {
+	article.Status = domain.ArticleStatusReviewing
+	if article.Id != 0 {
+		err := s.repo.Update(ctx, article)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		id, err := s.repo.Create(ctx, article)
+		if err != nil {
+			return 0, err
+		}
+		article.Id = id
+	}
+	_, err := s.moderation.Submit(ctx, article, hits)
+	if err != nil {
+		return 0, err
+	}
+	return article.Id, ErrArticleUnderReview
+}
+
+func (s *articleService) producePublishEvent(ctx context.Context, artId int64, authorId int64, published bool) {
+	// 发表已经成功了，事件丢了只影响搜索之类的下游，它们会在重建的时候修正过来
+	er := s.producer.ProducePublishEvent(ctx, article.PublishEvent{
+		Aid:       artId,
+		Uid:       authorId,
+		Published: published,
+	})
+	if er != nil {
+		s.l.Error("发送发表事件失败", logger.Error(er), logger.Int64("artId", artId))
+	}
 }
 
 func (s *articleService) Save(ctx context.Context, article domain.Article) (id int64, err error) {
@@ -77,10 +273,15 @@ func (s *articleService) Save(ctx context.Context, article domain.Article) (id i
 	return s.repo.Create(ctx, article)
 }
 
-func NewArticleService(repo repository.ArticleRepository, producer article.Producer, l logger.Logger) ArticleService {
+func NewArticleService(repo repository.ArticleRepository, producer article.Producer,
+	tokenSvc PreviewTokenService, follow FollowChecker, moderation ModerationService, l logger.Logger) ArticleService {
 	return &articleService{
-		repo:     repo,
-		producer: producer,
-		l:        l,
+		repo:           repo,
+		producer:       producer,
+		tokenSvc:       tokenSvc,
+		follow:         follow,
+		moderation:     moderation,
+		l:              l,
+		trashRetention: time.Hour * 24 * 30,
 	}
 }
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/internal/domain"
+	"red-feed/internal/events/article"
+	evtmocks "red-feed/internal/events/article/mocks"
+	"red-feed/internal/repository"
+	repomocks "red-feed/internal/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestArticleService_PurgeTrash(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer)
+
+		wantCnt    int
+		wantNextId int64
+		wantErr    error
+	}{
+		{
+			name: "全部清理成功",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), int64(0), 10).
+					Return([]domain.Article{
+						{Id: 1, Author: domain.Author{Id: 11}},
+						{Id: 2, Author: domain.Author{Id: 12}},
+					}, nil)
+				repo.EXPECT().HardDelete(gomock.Any(), int64(1), int64(11)).Return(nil)
+				repo.EXPECT().HardDelete(gomock.Any(), int64(2), int64(12)).Return(nil)
+				return repo, producer
+			},
+			wantCnt: 2,
+		},
+		{
+			name: "部分删除失败，不发送事件",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), int64(0), 10).
+					Return([]domain.Article{
+						{Id: 1, Author: domain.Author{Id: 11}},
+						{Id: 2, Author: domain.Author{Id: 12}},
+					}, nil)
+				repo.EXPECT().HardDelete(gomock.Any(), int64(1), int64(11)).Return(errors.New("db错误"))
+				repo.EXPECT().HardDelete(gomock.Any(), int64(2), int64(12)).Return(nil)
+				return repo, producer
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "一整批都删不掉，跳到下一批",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				arts := make([]domain.Article, 0, 10)
+				for i := int64(1); i <= 10; i++ {
+					arts = append(arts, domain.Article{Id: i, Author: domain.Author{Id: 11}})
+				}
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), int64(0), 10).Return(arts, nil)
+				repo.EXPECT().HardDelete(gomock.Any(), gomock.Any(), int64(11)).
+					Return(errors.New("db错误")).Times(10)
+				return repo, producer
+			},
+			wantNextId: 10,
+		},
+		{
+			name: "查询过期帖子失败",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().ListExpiredTrash(gomock.Any(), gomock.Any(), int64(0), 10).
+					Return(nil, errors.New("db错误"))
+				return repo, producer
+			},
+			wantErr: errors.New("db错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, producer, nil, NewNopFollowChecker(), nil, &logger.NopLogger{})
+			cnt, nextId, err := svc.PurgeTrash(context.Background(), 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+			assert.Equal(t, tc.wantNextId, nextId)
+		})
+	}
+}
+
+func TestArticleService_GetPublishedById(t *testing.T) {
+	tokenSvc := NewJWTPreviewTokenService([]byte("test key"))
+	validToken, err := tokenSvc.Generate(1, 11, time.Minute)
+	assert.NoError(t, err)
+	otherToken, err := tokenSvc.Generate(2, 11, time.Minute)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		mock  func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer)
+		uid   int64
+		token string
+
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "公开的帖子",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+				}, nil)
+				producer.EXPECT().ProduceReadEvent(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				return repo, producer
+			},
+			uid: 123,
+			wantArt: domain.Article{
+				Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+			},
+		},
+		{
+			name: "仅关注者可见，不是关注者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+					Visibility: domain.ArticleVisibilityFollowers,
+				}, nil)
+				return repo, producer
+			},
+			uid:     123,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "仅关注者可见，匿名读者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+					Visibility: domain.ArticleVisibilityFollowers,
+				}, nil)
+				return repo, producer
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "仅关注者可见，作者本人",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+					Visibility: domain.ArticleVisibilityFollowers,
+				}, nil)
+				producer.EXPECT().ProduceReadEvent(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				return repo, producer
+			},
+			uid: 11,
+			wantArt: domain.Article{
+				Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+				Visibility: domain.ArticleVisibilityFollowers,
+			},
+		},
+		{
+			name: "仅链接可见，别的帖子的链接",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPublished, Author: domain.Author{Id: 11},
+					Visibility: domain.ArticleVisibilityLink,
+				}, nil)
+				return repo, producer
+			},
+			token:   otherToken,
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "草稿，通过预览链接查看",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{}, ErrArticleNotFound)
+				repo.EXPECT().GetById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusUnPublished, Author: domain.Author{Id: 11},
+				}, nil)
+				return repo, producer
+			},
+			token: validToken,
+			wantArt: domain.Article{
+				Id: 1, Status: domain.ArticleStatusUnPublished, Author: domain.Author{Id: 11},
+			},
+		},
+		{
+			name: "撤回的帖子，没有预览链接",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{
+					Id: 1, Status: domain.ArticleStatusPrivate, Author: domain.Author{Id: 11},
+				}, nil)
+				return repo, producer
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer := tc.mock(ctrl)
+			svc := NewArticleService(repo, producer, tokenSvc, NewNopFollowChecker(), nil, &logger.NopLogger{})
+			art, err := svc.GetPublishedById(context.Background(), 1, domain.Reader{Uid: tc.uid}, tc.token)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository"
+	"red-feed/pkg/feedx"
+	"red-feed/pkg/logger"
+	"strings"
+	"time"
+)
+
+var ErrFeedAuthorNotFound = errors.New("作者不存在")
+
+//go:generate mockgen -source=feed.go -package=svcmocks -destination=mocks/feed.mock.go FeedService
+type FeedService interface {
+	// Feed 生成订阅源，authorId 为 0 的时候是全站的
+	Feed(ctx context.Context, format domain.FeedFormat, authorId int64) (domain.FeedDocument, error)
+}
+
+type feedService struct {
+	artSvc  ArticleService
+	userSvc UserService
+	repo    repository.FeedRepository
+	l       logger.Logger
+	// site 前端站点的地址，帖子链接和订阅地址都基于它
+	site  string
+	title string
+	// limit 订阅源里面最多的帖子数量
+	limit int
+}
+
+func NewFeedService(artSvc ArticleService, userSvc UserService, repo repository.FeedRepository,
+	site string, title string, l logger.Logger) FeedService {
+	return &feedService{
+		artSvc:  artSvc,
+		userSvc: userSvc,
+		repo:    repo,
+		l:       l,
+		site:    strings.TrimSuffix(site, "/"),
+		title:   title,
+		limit:   20,
+	}
+}
+
+func (s *feedService) Feed(ctx context.Context, format domain.FeedFormat, authorId int64) (domain.FeedDocument, error) {
+	key := fmt.Sprintf("%s:%d", format, authorId)
+	doc, err := s.repo.Get(ctx, key)
+	if err == nil {
+		return doc, nil
+	}
+	if err != repository.ErrFeedNotFound {
+		// 缓存出问题了就直接生成，订阅源的请求不多
+		s.l.Error("读取订阅源缓存失败", logger.Error(err), logger.String("key", key))
+	}
+	feed, err := s.build(ctx, format, authorId)
+	if err != nil {
+		return domain.FeedDocument{}, err
+	}
+	doc, err = s.render(format, feed)
+	if err != nil {
+		return domain.FeedDocument{}, err
+	}
+	if er := s.repo.Set(ctx, key, doc); er != nil {
+		s.l.Error("缓存订阅源失败", logger.Error(er), logger.String("key", key))
+	}
+	return doc, nil
+}
+
+func (s *feedService) build(ctx context.Context, format domain.FeedFormat, authorId int64) (feedx.Feed, error) {
+	feed := feedx.Feed{
+		Title:       s.title,
+		Link:        s.site,
+		Description: s.title + "的最新帖子",
+		Self:        fmt.Sprintf("%s/feeds/%s.xml", s.site, format),
+	}
+	var (
+		names map[int64]string
+		arts  []domain.Article
+		err   error
+	)
+	if authorId == 0 {
+		arts, err = s.artSvc.ListPub(ctx, 0, s.limit)
+	} else {
+		author, er := s.userSvc.Profile(ctx, authorId)
+		if er == repository.ErrUserNotFound {
+			return feedx.Feed{}, ErrFeedAuthorNotFound
+		}
+		if er != nil {
+			return feedx.Feed{}, er
+		}
+		names = map[int64]string{authorId: author.Nickname}
+		feed.Title = fmt.Sprintf("%s - %s", author.Nickname, s.title)
+		feed.Description = author.Nickname + "的最新帖子"
+		feed.Self = fmt.Sprintf("%s?author=%d", feed.Self, authorId)
+		arts, err = s.artSvc.ListPubByAuthor(ctx, authorId, 0, s.limit)
+	}
+	if err != nil {
+		return feedx.Feed{}, err
+	}
+	if authorId == 0 {
+		names = s.authorNames(ctx, arts)
+	}
+	feed.Items = make([]feedx.Item, 0, len(arts))
+	for _, art := range arts {
+		link := fmt.Sprintf("%s/articles/%d", s.site, art.Id)
+		feed.Items = append(feed.Items, feedx.Item{
+			Id:        link,
+			Title:     art.Title,
+			Link:      link,
+			Author:    names[art.Author.Id],
+			Summary:   art.Abstract(),
+			Published: art.Ctime,
+			Updated:   art.Utime,
+		})
+		if art.Utime.After(feed.Updated) {
+			feed.Updated = art.Utime
+		}
+	}
+	return feed, nil
+}
+
+// authorNames 一次查出所有作者，查不到就不展示作者
+func (s *feedService) authorNames(ctx context.Context, arts []domain.Article) map[int64]string {
+	uids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		uids = append(uids, art.Author.Id)
+	}
+	users, err := s.userSvc.FindByIds(ctx, uids)
+	if err != nil {
+		s.l.Error("查询作者失败", logger.Error(err))
+	}
+	names := make(map[int64]string, len(users))
+	for id, u := range users {
+		names[id] = u.Nickname
+	}
+	return names
+}
+
+func (s *feedService) render(format domain.FeedFormat, feed feedx.Feed) (domain.FeedDocument, error) {
+	var (
+		content []byte
+		err     error
+	)
+	switch format {
+	case domain.FeedFormatAtom:
+		content, err = feedx.Atom(feed)
+	default:
+		content, err = feedx.RSS(feed)
+	}
+	if err != nil {
+		return domain.FeedDocument{}, err
+	}
+	// Last-Modified 只精确到秒，这里也截断，方便比较 If-Modified-Since
+	return domain.FeedDocument{
+		Content:  content,
+		ETag:     fmt.Sprintf(`"%x"`, sha1.Sum(content)),
+		Modified: feed.Updated.Truncate(time.Second),
+	}, nil
+}
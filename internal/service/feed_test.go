@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository"
+	repomocks "red-feed/internal/repository/mocks"
+	svcmocks "red-feed/internal/service/mocks"
+	"red-feed/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedService_Feed(t *testing.T) {
+	utime := time.Date(2024, 1, 2, 15, 4, 5, 500, time.UTC)
+	arts := []domain.Article{
+		{Id: 2, Title: "第二篇", Content: "内容二", Author: domain.Author{Id: 100}, Ctime: utime, Utime: utime},
+		{Id: 1, Title: "第一篇", Content: "内容一", Author: domain.Author{Id: 100},
+			Ctime: utime.Add(-time.Hour), Utime: utime.Add(-time.Hour)},
+	}
+	cached := domain.FeedDocument{Content: []byte("<rss/>"), ETag: `"abc"`}
+	testCases := []struct {
+		name     string
+		format   domain.FeedFormat
+		authorId int64
+		mock     func(ctrl *gomock.Controller) (ArticleService, UserService, repository.FeedRepository)
+
+		wantErr      error
+		wantCached   bool
+		wantContains []string
+	}{
+		{
+			name:   "命中缓存",
+			format: domain.FeedFormatRSS,
+			mock: func(ctrl *gomock.Controller) (ArticleService, UserService, repository.FeedRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "rss:0").Return(cached, nil)
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockUserService(ctrl), repo
+			},
+			wantCached: true,
+		},
+		{
+			name:   "全站的订阅源，批量查询作者",
+			format: domain.FeedFormatRSS,
+			mock: func(ctrl *gomock.Controller) (ArticleService, UserService, repository.FeedRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "rss:0").Return(domain.FeedDocument{}, repository.ErrFeedNotFound)
+				artSvc.EXPECT().ListPub(gomock.Any(), 0, 20).Return(arts, nil)
+				userSvc.EXPECT().FindByIds(gomock.Any(), []int64{100, 100}).
+					Return(map[int64]domain.User{100: {Id: 100, Nickname: "大明"}}, nil)
+				repo.EXPECT().Set(gomock.Any(), "rss:0", gomock.Any()).Return(nil)
+				return artSvc, userSvc, repo
+			},
+			wantContains: []string{"<title>第二篇</title>", "<dc:creator>大明</dc:creator>",
+				"https://example.com/articles/1", "https://example.com/feeds/rss.xml"},
+		},
+		{
+			name:     "作者的订阅源，缓存出错也能生成",
+			format:   domain.FeedFormatAtom,
+			authorId: 100,
+			mock: func(ctrl *gomock.Controller) (ArticleService, UserService, repository.FeedRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "atom:100").Return(domain.FeedDocument{}, errors.New("redis错误"))
+				userSvc.EXPECT().Profile(gomock.Any(), int64(100)).Return(domain.User{Id: 100, Nickname: "大明"}, nil)
+				artSvc.EXPECT().ListPubByAuthor(gomock.Any(), int64(100), 0, 20).Return(arts, nil)
+				repo.EXPECT().Set(gomock.Any(), "atom:100", gomock.Any()).Return(errors.New("redis错误"))
+				return artSvc, userSvc, repo
+			},
+			wantContains: []string{"<title>大明 - 红书</title>", "<name>大明</name>",
+				"https://example.com/feeds/atom.xml?author=100"},
+		},
+		{
+			name:     "作者不存在",
+			format:   domain.FeedFormatRSS,
+			authorId: 100,
+			mock: func(ctrl *gomock.Controller) (ArticleService, UserService, repository.FeedRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				repo.EXPECT().Get(gomock.Any(), "rss:100").Return(domain.FeedDocument{}, repository.ErrFeedNotFound)
+				userSvc.EXPECT().Profile(gomock.Any(), int64(100)).Return(domain.User{}, repository.ErrUserNotFound)
+				return svcmocks.NewMockArticleService(ctrl), userSvc, repo
+			},
+			wantErr: ErrFeedAuthorNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			artSvc, userSvc, repo := tc.mock(ctrl)
+			svc := NewFeedService(artSvc, userSvc, repo, "https://example.com/", "红书", &logger.NopLogger{})
+			doc, err := svc.Feed(context.Background(), tc.format, tc.authorId)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			if tc.wantCached {
+				assert.Equal(t, cached, doc)
+				return
+			}
+			for _, s := range tc.wantContains {
+				assert.True(t, strings.Contains(string(doc.Content), s), s)
+			}
+			assert.NotEmpty(t, doc.ETag)
+			// 取最新一篇的更新时间，截断到秒
+			assert.Equal(t, utime.Truncate(time.Second), doc.Modified)
+		})
+	}
+}
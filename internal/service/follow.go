@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrFollowerCntUnavailable 还统计不了关注者，调用方不要当成 0 展示
+var ErrFollowerCntUnavailable = errors.New("关注者统计还没有上线")
+
+// FollowChecker 判断关注关系，仅关注者可见的帖子依赖它
+type FollowChecker interface {
+	// IsFollower follower 是否关注了 followee
+	IsFollower(ctx context.Context, followee int64, follower int64) (bool, error)
+}
+
+// NopFollowChecker 关注模块还没有上线之前的兜底实现，
+// 认为谁都没有关注谁，也就是仅关注者可见的帖子只有作者本人能看到
+type NopFollowChecker struct {
+}
+
+func NewNopFollowChecker() FollowChecker {
+	return &NopFollowChecker{}
+}
+
+func (n *NopFollowChecker) IsFollower(ctx context.Context, followee int64, follower int64) (bool, error) {
+	return false, nil
+}
+
+// FollowerCounter 统计关注者的变化，创作者看板用
+type FollowerCounter interface {
+	// CountNewFollowers [start, end) 之间新增的关注者，取消关注的要减掉
+	CountNewFollowers(ctx context.Context, followee int64, start, end time.Time) (int64, error)
+}
+
+// NopFollowerCounter 关注模块还没有上线之前的兜底实现，一直返回 ErrFollowerCntUnavailable
+type NopFollowerCounter struct {
+}
+
+func NewNopFollowerCounter() FollowerCounter {
+	return &NopFollowerCounter{}
+}
+
+func (n *NopFollowerCounter) CountNewFollowers(ctx context.Context, followee int64, start, end time.Time) (int64, error) {
+	return 0, ErrFollowerCntUnavailable
+}
@@ -8,7 +8,11 @@ import (
 	"time"
 )
 
+var ErrNoJob = repository.ErrNoJob
+
 type JobService interface {
+	// AddJob 注册任务，已经有了就更新 cron 表达式和配置，多个实例启动的时候都注册也没关系
+	AddJob(ctx context.Context, j domain.Job) error
 	Preempt(ctx context.Context) (domain.Job, error) // Preempt 抢占
 	ResetNextTime(ctx context.Context, j domain.Job) error
 }
@@ -19,6 +23,19 @@ type cronJobService struct {
 	l               logger.Logger
 }
 
+func NewCronJobService(repo repository.JobRepository, l logger.Logger) JobService {
+	return &cronJobService{
+		repo: repo,
+		// 要比 dao 里面判断续约超时的时间短很多
+		refreshInterval: time.Second * 10,
+		l:               l,
+	}
+}
+
+func (js *cronJobService) AddJob(ctx context.Context, j domain.Job) error {
+	return js.repo.Upsert(ctx, j)
+}
+
 func (js *cronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	// 试图抢占一个任务
 	j, err := js.repo.Preempt(ctx)
@@ -27,14 +44,21 @@ func (js *cronJobService) Preempt(ctx context.Context) (domain.Job, error) {
 	}
 	// 抢占后，一直刷新 任务的utime, 证明任务还活着
 	ticker := time.NewTicker(js.refreshInterval)
+	ch := make(chan struct{})
 	go func() {
-		for range ticker.C {
-			js.refresh(j.Id)
+		for {
+			select {
+			case <-ticker.C:
+				js.refresh(j.Id)
+			case <-ch:
+				// ticker.Stop 不会关闭 ticker.C，要靠 ch 退出
+				return
+			}
 		}
 	}()
 	// 定义该任务的cancel func
 	j.CancelFunc = func() error {
-		//close(ch)
+		close(ch)
 		// 自己在这里释放掉
 		ticker.Stop()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
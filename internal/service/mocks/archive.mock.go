@@ -0,0 +1,133 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=archive.go -package=svcmocks -destination=mocks/archive.mock.go ArchiveService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	io "io"
+	domain "red-feed/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArchiveService is a mock of ArchiveService interface.
+type MockArchiveService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveServiceMockRecorder is the mock recorder for MockArchiveService.
+type MockArchiveServiceMockRecorder struct {
+	mock *MockArchiveService
+}
+
+// NewMockArchiveService creates a new mock instance.
+func NewMockArchiveService(ctrl *gomock.Controller) *MockArchiveService {
+	mock := &MockArchiveService{ctrl: ctrl}
+	mock.recorder = &MockArchiveServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveService) EXPECT() *MockArchiveServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockArchiveService) Export(ctx context.Context, uid int64, w io.Writer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, uid, w)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArchiveServiceMockRecorder) Export(ctx, uid, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArchiveService)(nil).Export), ctx, uid, w)
+}
+
+// GetExportTask mocks base method.
+func (m *MockArchiveService) GetExportTask(ctx context.Context, uid int64, taskId string) (domain.ExportTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportTask", ctx, uid, taskId)
+	ret0, _ := ret[0].(domain.ExportTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExportTask indicates an expected call of GetExportTask.
+func (mr *MockArchiveServiceMockRecorder) GetExportTask(ctx, uid, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportTask", reflect.TypeOf((*MockArchiveService)(nil).GetExportTask), ctx, uid, taskId)
+}
+
+// Import mocks base method.
+func (m *MockArchiveService) Import(ctx context.Context, uid int64, r io.ReaderAt, size int64) ([]domain.ImportItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, uid, r, size)
+	ret0, _ := ret[0].([]domain.ImportItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockArchiveServiceMockRecorder) Import(ctx, uid, r, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockArchiveService)(nil).Import), ctx, uid, r, size)
+}
+
+// NeedAsyncExport mocks base method.
+func (m *MockArchiveService) NeedAsyncExport(ctx context.Context, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedAsyncExport", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NeedAsyncExport indicates an expected call of NeedAsyncExport.
+func (mr *MockArchiveServiceMockRecorder) NeedAsyncExport(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedAsyncExport", reflect.TypeOf((*MockArchiveService)(nil).NeedAsyncExport), ctx, uid)
+}
+
+// OpenExport mocks base method.
+func (m *MockArchiveService) OpenExport(ctx context.Context, uid int64, taskId string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenExport", ctx, uid, taskId)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenExport indicates an expected call of OpenExport.
+func (mr *MockArchiveServiceMockRecorder) OpenExport(ctx, uid, taskId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenExport", reflect.TypeOf((*MockArchiveService)(nil).OpenExport), ctx, uid, taskId)
+}
+
+// StartExport mocks base method.
+func (m *MockArchiveService) StartExport(ctx context.Context, uid int64) (domain.ExportTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartExport", ctx, uid)
+	ret0, _ := ret[0].(domain.ExportTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartExport indicates an expected call of StartExport.
+func (mr *MockArchiveServiceMockRecorder) StartExport(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartExport", reflect.TypeOf((*MockArchiveService)(nil).StartExport), ctx, uid)
+}
@@ -11,8 +11,9 @@ package svcmocks
 
 import (
 	context "context"
-	"red-feed/interactive/domain"
+	domain "red-feed/interactive/domain"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -69,6 +70,20 @@ func (mr *MockInteractiveServiceMockRecorder) CancelLike(ctx, biz, bizId, uId an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockInteractiveService)(nil).CancelLike), ctx, biz, bizId, uId)
 }
 
+// CancelReaction mocks base method.
+func (m *MockInteractiveService) CancelReaction(ctx context.Context, biz string, bizId, uId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReaction", ctx, biz, bizId, uId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReaction indicates an expected call of CancelReaction.
+func (mr *MockInteractiveServiceMockRecorder) CancelReaction(ctx, biz, bizId, uId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReaction", reflect.TypeOf((*MockInteractiveService)(nil).CancelReaction), ctx, biz, bizId, uId)
+}
+
 // Collect mocks base method.
 func (m *MockInteractiveService) Collect(ctx context.Context, biz string, bizId, uId, cId int64) error {
 	m.ctrl.T.Helper()
@@ -113,6 +128,51 @@ func (mr *MockInteractiveServiceMockRecorder) GetByIds(ctx, biz, bizIds any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetByIds), ctx, biz, bizIds)
 }
 
+// GetStats mocks base method.
+func (m *MockInteractiveService) GetStats(ctx context.Context, biz string, bizIds []int64, start, end time.Time, granularity domain.StatsGranularity) ([]domain.StatsPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, biz, bizIds, start, end, granularity)
+	ret0, _ := ret[0].([]domain.StatsPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockInteractiveServiceMockRecorder) GetStats(ctx, biz, bizIds, start, end, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockInteractiveService)(nil).GetStats), ctx, biz, bizIds, start, end, granularity)
+}
+
+// GetStatsByIds mocks base method.
+func (m *MockInteractiveService) GetStatsByIds(ctx context.Context, biz string, bizIds []int64, start, end time.Time) (map[int64]domain.StatsCnt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatsByIds", ctx, biz, bizIds, start, end)
+	ret0, _ := ret[0].(map[int64]domain.StatsCnt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatsByIds indicates an expected call of GetStatsByIds.
+func (mr *MockInteractiveServiceMockRecorder) GetStatsByIds(ctx, biz, bizIds, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetStatsByIds), ctx, biz, bizIds, start, end)
+}
+
+// GetUserStateByIds mocks base method.
+func (m *MockInteractiveService) GetUserStateByIds(ctx context.Context, biz string, uId int64, bizIds []int64) (map[int64]domain.UserState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStateByIds", ctx, biz, uId, bizIds)
+	ret0, _ := ret[0].(map[int64]domain.UserState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStateByIds indicates an expected call of GetUserStateByIds.
+func (mr *MockInteractiveServiceMockRecorder) GetUserStateByIds(ctx, biz, uId, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStateByIds", reflect.TypeOf((*MockInteractiveService)(nil).GetUserStateByIds), ctx, biz, uId, bizIds)
+}
+
 // IncrReadCnt mocks base method.
 func (m *MockInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
 	m.ctrl.T.Helper()
@@ -140,3 +200,62 @@ func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, bizId, uId any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, bizId, uId)
 }
+
+// ListLikedByUser mocks base method.
+func (m *MockInteractiveService) ListLikedByUser(ctx context.Context, biz string, uId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikedByUser", ctx, biz, uId, cursor, limit)
+	ret0, _ := ret[0].([]domain.LikeRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikedByUser indicates an expected call of ListLikedByUser.
+func (mr *MockInteractiveServiceMockRecorder) ListLikedByUser(ctx, biz, uId, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikedByUser", reflect.TypeOf((*MockInteractiveService)(nil).ListLikedByUser), ctx, biz, uId, cursor, limit)
+}
+
+// ListLikers mocks base method.
+func (m *MockInteractiveService) ListLikers(ctx context.Context, biz string, bizId int64, cursor domain.LikeCursor, limit int) ([]domain.LikeRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikers", ctx, biz, bizId, cursor, limit)
+	ret0, _ := ret[0].([]domain.LikeRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikers indicates an expected call of ListLikers.
+func (mr *MockInteractiveServiceMockRecorder) ListLikers(ctx, biz, bizId, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikers", reflect.TypeOf((*MockInteractiveService)(nil).ListLikers), ctx, biz, bizId, cursor, limit)
+}
+
+// ListReactions mocks base method.
+func (m *MockInteractiveService) ListReactions(ctx context.Context) ([]domain.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReactions", ctx)
+	ret0, _ := ret[0].([]domain.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReactions indicates an expected call of ListReactions.
+func (mr *MockInteractiveServiceMockRecorder) ListReactions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReactions", reflect.TypeOf((*MockInteractiveService)(nil).ListReactions), ctx)
+}
+
+// React mocks base method.
+func (m *MockInteractiveService) React(ctx context.Context, biz string, bizId, uId int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", ctx, biz, bizId, uId, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockInteractiveServiceMockRecorder) React(ctx, biz, bizId, uId, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockInteractiveService)(nil).React), ctx, biz, bizId, uId, reaction)
+}
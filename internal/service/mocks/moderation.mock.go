@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: moderation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=moderation.go -package=svcmocks -destination=mocks/moderation.mock.go ModerationService
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	domain "red-feed/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModerationService is a mock of ModerationService interface.
+type MockModerationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockModerationServiceMockRecorder is the mock recorder for MockModerationService.
+type MockModerationServiceMockRecorder struct {
+	mock *MockModerationService
+}
+
+// NewMockModerationService creates a new mock instance.
+func NewMockModerationService(ctrl *gomock.Controller) *MockModerationService {
+	mock := &MockModerationService{ctrl: ctrl}
+	mock.recorder = &MockModerationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModerationService) EXPECT() *MockModerationServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockModerationService) Approve(ctx context.Context, reviewId, reviewerId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, reviewId, reviewerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockModerationServiceMockRecorder) Approve(ctx, reviewId, reviewerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockModerationService)(nil).Approve), ctx, reviewId, reviewerId)
+}
+
+// Check mocks base method.
+func (m *MockModerationService) Check(ctx context.Context, art domain.Article) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", ctx, art)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockModerationServiceMockRecorder) Check(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockModerationService)(nil).Check), ctx, art)
+}
+
+// ListByAuthor mocks base method.
+func (m *MockModerationService) ListByAuthor(ctx context.Context, uid int64, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAuthor", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAuthor indicates an expected call of ListByAuthor.
+func (mr *MockModerationServiceMockRecorder) ListByAuthor(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockModerationService)(nil).ListByAuthor), ctx, uid, offset, limit)
+}
+
+// ListPending mocks base method.
+func (m *MockModerationService) ListPending(ctx context.Context, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockModerationServiceMockRecorder) ListPending(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockModerationService)(nil).ListPending), ctx, offset, limit)
+}
+
+// Reject mocks base method.
+func (m *MockModerationService) Reject(ctx context.Context, reviewId, reviewerId int64, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, reviewId, reviewerId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockModerationServiceMockRecorder) Reject(ctx, reviewId, reviewerId, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockModerationService)(nil).Reject), ctx, reviewId, reviewerId, reason)
+}
+
+// Submit mocks base method.
+func (m *MockModerationService) Submit(ctx context.Context, art domain.Article, hits []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, art, hits)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockModerationServiceMockRecorder) Submit(ctx, art, hits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockModerationService)(nil).Submit), ctx, art, hits)
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"red-feed/internal/domain"
+	"red-feed/internal/events/article"
+	"red-feed/internal/repository"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/sensitive"
+	"strings"
+)
+
+var (
+	// ErrArticleUnderReview 发表的时候命中了敏感词，帖子已经保存，等人工审核通过之后才会发表
+	ErrArticleUnderReview = errors.New("帖子需要人工审核")
+	ErrReviewNotFound     = repository.ErrReviewNotFound
+	ErrReviewNotPending   = repository.ErrReviewNotPending
+	// ErrReviewStale 审核期间作者修改、撤回或者删除了帖子，审核单作废
+	ErrReviewStale = errors.New("帖子在审核期间被修改过")
+)
+
+//go:generate mockgen -source=moderation.go -package=svcmocks -destination=mocks/moderation.mock.go ModerationService
+type ModerationService interface {
+	// Check 机器审核，返回命中的敏感词，没有命中的时候返回空
+	Check(ctx context.Context, art domain.Article) []string
+	// Submit 创建人工审核单，帖子要先以审核中的状态保存好
+	Submit(ctx context.Context, art domain.Article, hits []string) (int64, error)
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error)
+	ListByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleReview, error)
+	// Approve 审核通过，发表帖子
+	Approve(ctx context.Context, reviewId int64, reviewerId int64) error
+	// Reject 驳回，帖子回到作者手里
+	Reject(ctx context.Context, reviewId int64, reviewerId int64, reason string) error
+}
+
+type moderationService struct {
+	dict     *sensitive.Dictionary
+	repo     repository.ArticleReviewRepository
+	artRepo  repository.ArticleRepository
+	producer article.Producer
+	l        logger.Logger
+}
+
+func NewModerationService(dict *sensitive.Dictionary, repo repository.ArticleReviewRepository,
+	artRepo repository.ArticleRepository, producer article.Producer, l logger.Logger) ModerationService {
+	return &moderationService{
+		dict:     dict,
+		repo:     repo,
+		artRepo:  artRepo,
+		producer: producer,
+		l:        l,
+	}
+}
+
+func (s *moderationService) Check(ctx context.Context, art domain.Article) []string {
+	// 用换行隔开，避免标题结尾和内容开头拼起来误命中
+	text := strings.Join([]string{art.Title, art.Content, strings.Join(art.Tags, "\n")}, "\n")
+	return s.dict.Matcher().Words(text)
+}
+
+func (s *moderationService) Submit(ctx context.Context, art domain.Article, hits []string) (int64, error) {
+	return s.repo.Create(ctx, domain.ArticleReview{
+		ArticleId: art.Id,
+		AuthorId:  art.Author.Id,
+		Title:     art.Title,
+		Hits:      hits,
+		Status:    domain.ReviewStatusPending,
+	})
+}
+
+func (s *moderationService) ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error) {
+	return s.repo.ListPending(ctx, offset, limit)
+}
+
+func (s *moderationService) ListByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleReview, error) {
+	return s.repo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (s *moderationService) Approve(ctx context.Context, reviewId int64, reviewerId int64) error {
+	review, err := s.pending(ctx, reviewId)
+	if err != nil {
+		return err
+	}
+	art, err := s.artRepo.GetById(ctx, review.ArticleId)
+	if err != nil && err != repository.ErrArticleNotFound {
+		return err
+	}
+	if err != nil || art.Status != domain.ArticleStatusReviewing {
+		// 作者已经改过了，再发表的话会重新生成审核单
+		er := s.repo.UpdateStatus(ctx, reviewId, domain.ReviewStatusCanceled, reviewerId, "")
+		if er != nil && er != ErrReviewNotPending {
+			return er
+		}
+		return ErrReviewStale
+	}
+	// 先抢到审核单，避免两个人同时审核
+	err = s.repo.UpdateStatus(ctx, reviewId, domain.ReviewStatusApproved, reviewerId, "")
+	if err != nil {
+		return err
+	}
+	art.Status = domain.ArticleStatusPublished
+	_, err = s.artRepo.Sync(ctx, art)
+	if err != nil {
+		if er := s.repo.Reopen(ctx, reviewId); er != nil {
+			s.l.Error("恢复审核单失败", logger.Error(er), logger.Int64("reviewId", reviewId))
+		}
+		return err
+	}
+	if er := s.producer.ProducePublishEvent(ctx, article.PublishEvent{
+		Aid:       art.Id,
+		Uid:       art.Author.Id,
+		Published: true,
+	}); er != nil {
+		s.l.Error("发送发表事件失败", logger.Error(er), logger.Int64("artId", art.Id))
+	}
+	s.notify(ctx, review, true, "")
+	return nil
+}
+
+func (s *moderationService) Reject(ctx context.Context, reviewId int64, reviewerId int64, reason string) error {
+	review, err := s.pending(ctx, reviewId)
+	if err != nil {
+		return err
+	}
+	err = s.repo.UpdateStatus(ctx, reviewId, domain.ReviewStatusRejected, reviewerId, reason)
+	if err != nil {
+		return err
+	}
+	err = s.artRepo.UpdateDraftStatus(ctx, review.ArticleId, review.AuthorId,
+		domain.ArticleStatusReviewing, domain.ArticleStatusRejected)
+	if err != nil && err != repository.ErrArticleNotFound {
+		return err
+	}
+	// 帖子已经不是审核中了，说明作者已经改过，不需要再改状态，但是驳回的结果还是要告诉作者
+	s.notify(ctx, review, false, reason)
+	return nil
+}
+
+func (s *moderationService) pending(ctx context.Context, reviewId int64) (domain.ArticleReview, error) {
+	review, err := s.repo.FindById(ctx, reviewId)
+	if err != nil {
+		return domain.ArticleReview{}, err
+	}
+	if review.Status != domain.ReviewStatusPending {
+		return domain.ArticleReview{}, ErrReviewNotPending
+	}
+	return review, nil
+}
+
+func (s *moderationService) notify(ctx context.Context, review domain.ArticleReview, approved bool, reason string) {
+	// 审核结果已经落库了，作者在审核记录里面也能看到，通知失败只记录日志
+	er := s.producer.ProduceReviewEvent(ctx, article.ReviewEvent{
+		ReviewId: review.Id,
+		Aid:      review.ArticleId,
+		Uid:      review.AuthorId,
+		Approved: approved,
+		Reason:   reason,
+	})
+	if er != nil {
+		s.l.Error("发送审核结果失败", logger.Error(er), logger.Int64("reviewId", review.Id))
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"red-feed/internal/domain"
+	"red-feed/internal/events/article"
+	evtmocks "red-feed/internal/events/article/mocks"
+	"red-feed/internal/repository"
+	repomocks "red-feed/internal/repository/mocks"
+	svcmocks "red-feed/internal/service/mocks"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/sensitive"
+	"testing"
+)
+
+func TestModerationService_Approve(t *testing.T) {
+	pending := domain.ArticleReview{Id: 1, ArticleId: 10, AuthorId: 100, Status: domain.ReviewStatusPending}
+	reviewing := domain.Article{Id: 10, Title: "标题", Author: domain.Author{Id: 100},
+		Status: domain.ArticleStatusReviewing}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+			repository.ArticleRepository, article.Producer)
+		wantErr error
+	}{
+		{
+			name: "审核通过，发表并通知作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(pending, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).Return(reviewing, nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(1), domain.ReviewStatusApproved, int64(2), "").Return(nil)
+				published := reviewing
+				published.Status = domain.ArticleStatusPublished
+				artRepo.EXPECT().Sync(gomock.Any(), published).Return(int64(10), nil)
+				producer.EXPECT().ProducePublishEvent(gomock.Any(),
+					article.PublishEvent{Aid: 10, Uid: 100, Published: true}).Return(nil)
+				producer.EXPECT().ProduceReviewEvent(gomock.Any(),
+					article.ReviewEvent{ReviewId: 1, Aid: 10, Uid: 100, Approved: true}).Return(nil)
+				return repo, artRepo, producer
+			},
+		},
+		{
+			name: "已经处理过了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				review := pending
+				review.Status = domain.ReviewStatusRejected
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(review, nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: ErrReviewNotPending,
+		},
+		{
+			name: "作者在审核期间修改了帖子，审核单作废",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(pending, nil)
+				art := reviewing
+				art.Status = domain.ArticleStatusUnPublished
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).Return(art, nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(1), domain.ReviewStatusCanceled, int64(2), "").Return(nil)
+				return repo, artRepo, evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: ErrReviewStale,
+		},
+		{
+			name: "发表失败，审核单恢复成待审核",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(pending, nil)
+				artRepo.EXPECT().GetById(gomock.Any(), int64(10)).Return(reviewing, nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(1), domain.ReviewStatusApproved, int64(2), "").Return(nil)
+				artRepo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db错误"))
+				repo.EXPECT().Reopen(gomock.Any(), int64(1)).Return(nil)
+				return repo, artRepo, evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: errors.New("db错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, producer := tc.mock(ctrl)
+			svc := NewModerationService(sensitive.NewDictionary(nil), repo, artRepo, producer, &logger.NopLogger{})
+			err := svc.Approve(context.Background(), 1, 2)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestModerationService_Reject(t *testing.T) {
+	pending := domain.ArticleReview{Id: 1, ArticleId: 10, AuthorId: 100, Status: domain.ReviewStatusPending}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+			repository.ArticleRepository, article.Producer)
+		wantErr error
+	}{
+		{
+			name: "驳回并通知作者",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(1), domain.ReviewStatusRejected, int64(2), "涉及赌博").Return(nil)
+				artRepo.EXPECT().UpdateDraftStatus(gomock.Any(), int64(10), int64(100),
+					domain.ArticleStatusReviewing, domain.ArticleStatusRejected).Return(nil)
+				producer.EXPECT().ProduceReviewEvent(gomock.Any(),
+					article.ReviewEvent{ReviewId: 1, Aid: 10, Uid: 100, Reason: "涉及赌博"}).Return(nil)
+				return repo, artRepo, producer
+			},
+		},
+		{
+			name: "并发审核，别人已经处理了",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleReviewRepository,
+				repository.ArticleRepository, article.Producer) {
+				repo := repomocks.NewMockArticleReviewRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(pending, nil)
+				repo.EXPECT().UpdateStatus(gomock.Any(), int64(1), domain.ReviewStatusRejected, int64(2), "涉及赌博").
+					Return(ErrReviewNotPending)
+				return repo, repomocks.NewMockArticleRepository(ctrl), evtmocks.NewMockProducer(ctrl)
+			},
+			wantErr: ErrReviewNotPending,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo, producer := tc.mock(ctrl)
+			svc := NewModerationService(sensitive.NewDictionary(nil), repo, artRepo, producer, &logger.NopLogger{})
+			err := svc.Reject(context.Background(), 1, 2, "涉及赌博")
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestArticleService_Publish(t *testing.T) {
+	art := domain.Article{Title: "标题", Content: "内容", Author: domain.Author{Id: 100}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer, ModerationService)
+
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "没有命中敏感词，直接发表",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer, ModerationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				moderation := svcmocks.NewMockModerationService(ctrl)
+				moderation.EXPECT().Check(gomock.Any(), art).Return(nil)
+				published := art
+				published.Status = domain.ArticleStatusPublished
+				repo.EXPECT().Sync(gomock.Any(), published).Return(int64(10), nil)
+				producer.EXPECT().ProducePublishEvent(gomock.Any(),
+					article.PublishEvent{Aid: 10, Uid: 100, Published: true}).Return(nil)
+				return repo, producer, moderation
+			},
+			wantId: 10,
+		},
+		{
+			name: "命中敏感词，保存为审核中",
+			mock: func(ctrl *gomock.Controller) (repository.ArticleRepository, article.Producer, ModerationService) {
+				repo := repomocks.NewMockArticleRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+				moderation := svcmocks.NewMockModerationService(ctrl)
+				moderation.EXPECT().Check(gomock.Any(), art).Return([]string{"赌博"})
+				reviewing := art
+				reviewing.Status = domain.ArticleStatusReviewing
+				repo.EXPECT().Create(gomock.Any(), reviewing).Return(int64(10), nil)
+				reviewing.Id = 10
+				moderation.EXPECT().Submit(gomock.Any(), reviewing, []string{"赌博"}).Return(int64(1), nil)
+				return repo, producer, moderation
+			},
+			wantId:  10,
+			wantErr: ErrArticleUnderReview,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, producer, moderation := tc.mock(ctrl)
+			svc := NewArticleService(repo, producer, nil, NewNopFollowChecker(), moderation, &logger.NopLogger{})
+			id, err := svc.Publish(context.Background(), art)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
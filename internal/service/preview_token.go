@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+var ErrInvalidPreviewToken = errors.New("预览链接无效或已过期")
+
+// PreviewTokenService 生成和校验帖子的预览链接签名，
+// 拿到链接的人不需要登录就可以看到草稿或者仅链接可见的帖子
+type PreviewTokenService interface {
+	Generate(artId int64, authorId int64, ttl time.Duration) (string, error)
+	// Verify 校验 token 是否是 artId 这篇帖子的，并且没有过期
+	Verify(token string, artId int64) error
+}
+
+type PreviewClaims struct {
+	jwt.RegisteredClaims
+	ArtId    int64
+	AuthorId int64
+}
+
+type JWTPreviewTokenService struct {
+	key []byte
+}
+
+func NewJWTPreviewTokenService(key []byte) PreviewTokenService {
+	return &JWTPreviewTokenService{key: key}
+}
+
+func (s *JWTPreviewTokenService) Generate(artId int64, authorId int64, ttl time.Duration) (string, error) {
+	claims := PreviewClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		},
+		ArtId:    artId,
+		AuthorId: authorId,
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.key)
+}
+
+func (s *JWTPreviewTokenService) Verify(token string, artId int64) error {
+	claims := &PreviewClaims{}
+	t, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return s.key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !t.Valid {
+		return ErrInvalidPreviewToken
+	}
+	// 防止拿 A 帖子的链接去看 B 帖子
+	if claims.ArtId != artId {
+		return ErrInvalidPreviewToken
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/ecodeclub/ekit/slice"
 	"log"
 	"math"
+	domain2 "red-feed/interactive/domain"
 	service2 "red-feed/interactive/service"
 	"red-feed/internal/domain"
 	"red-feed/internal/repository"
@@ -83,7 +84,7 @@ func (svc *BatchRankingService) topN(ctx context.Context) ([]domain.Article, err
 				return src.Id
 			})
 		// 要去找到对应的点赞数据
-		intrs, err := svc.intrSvc.GetByIds(ctx, "article", ids)
+		intrs, err := svc.intrSvc.GetByIds(ctx, domain2.BizArticle, ids)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/searchx"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	searchFieldTitle    = "title"
+	searchFieldContent  = "content"
+	searchFieldAuthor   = "author"
+	searchFieldTag      = "tag"
+	searchFieldAuthorId = "author_id"
+)
+
+//go:generate mockgen -source=search.go -package=svcmocks -destination=mocks/search.mock.go SearchService
+type SearchService interface {
+	// Search 支持 title:xx tag:xx author:xx 这种限定字段的写法，其余的在标题、内容和作者名里面找。
+	// author 后面是数字的时候按照作者 id 查找。
+	Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error)
+	// Sync 按照线上库的最新数据更新索引，撤回、删除和非公开的帖子会从索引里面删掉
+	Sync(ctx context.Context, artId int64) error
+	// Rebuild 从线上库全量重建索引，重建期间可以正常搜索和更新
+	Rebuild(ctx context.Context) error
+}
+
+// IndexSearchService 进程内的倒排索引，每个实例各自维护一份
+type IndexSearchService struct {
+	repo  repository.ArticleRepository
+	index *searchx.Index
+	l     logger.Logger
+
+	batchSize   int
+	snippetSize int
+}
+
+func NewIndexSearchService(repo repository.ArticleRepository, l logger.Logger) SearchService {
+	return &IndexSearchService{
+		repo: repo,
+		// 标题命中比作者命中重要，作者命中比内容命中重要
+		index: searchx.NewIndex(map[string]float64{
+			searchFieldTitle:   3,
+			searchFieldAuthor:  2,
+			searchFieldContent: 1,
+		}),
+		l:           l,
+		batchSize:   200,
+		snippetSize: 100,
+	}
+}
+
+func (s *IndexSearchService) Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error) {
+	q := s.parseQuery(query)
+	q.Offset, q.Limit = offset, limit
+	hits, total := s.index.Search(q)
+	res := domain.SearchResult{
+		Total:    total,
+		Articles: make([]domain.SearchArticle, 0, len(hits)),
+	}
+	for _, hit := range hits {
+		doc := hit.Doc
+		authorId, _ := strconv.ParseInt(doc.Keywords[searchFieldAuthorId][0], 10, 64)
+		res.Articles = append(res.Articles, domain.SearchArticle{
+			Id:      doc.Id,
+			Title:   searchx.Highlight(doc.Fields[searchFieldTitle], hit.Terms),
+			Snippet: searchx.Snippet(doc.Fields[searchFieldContent], hit.Terms, s.snippetSize),
+			Tags:    doc.Keywords[searchFieldTag],
+			Author: domain.Author{
+				Id:   authorId,
+				Name: doc.Fields[searchFieldAuthor],
+			},
+			Utime: time.UnixMilli(doc.Version),
+			Score: hit.Score,
+		})
+	}
+	return res, nil
+}
+
+func (s *IndexSearchService) parseQuery(query string) searchx.Query {
+	text := make(map[string][]string)
+	filters := make(map[string][]string)
+	for _, word := range strings.Fields(query) {
+		field, val, ok := strings.Cut(word, ":")
+		if !ok || val == "" {
+			text[""] = append(text[""], word)
+			continue
+		}
+		switch field {
+		case searchFieldTitle, searchFieldContent:
+			text[field] = append(text[field], val)
+		case searchFieldTag:
+			filters[searchFieldTag] = append(filters[searchFieldTag], val)
+		case searchFieldAuthor:
+			if _, err := strconv.ParseInt(val, 10, 64); err == nil {
+				filters[searchFieldAuthorId] = append(filters[searchFieldAuthorId], val)
+			} else {
+				text[searchFieldAuthor] = append(text[searchFieldAuthor], val)
+			}
+		default:
+			// 不认识的字段，当成普通的词
+			text[""] = append(text[""], word)
+		}
+	}
+	q := searchx.Query{
+		Text:    make(map[string]string, len(text)),
+		Filters: filters,
+	}
+	for field, words := range text {
+		q.Text[field] = strings.Join(words, " ")
+	}
+	return q
+}
+
+func (s *IndexSearchService) Sync(ctx context.Context, artId int64) error {
+	art, err := s.repo.GetPubById(ctx, artId)
+	if err == repository.ErrArticleNotFound {
+		// 已经彻底删除了，不会再回来
+		s.index.Delete(artId, time.Now().UnixMilli())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	s.put(art)
+	return nil
+}
+
+func (s *IndexSearchService) put(art domain.Article) {
+	version := art.Utime.UnixMilli()
+	if art.Status != domain.ArticleStatusPublished || art.Visibility != domain.ArticleVisibilityPublic {
+		s.index.Delete(art.Id, version)
+		return
+	}
+	s.index.Put(searchx.Document{
+		Id:      art.Id,
+		Version: version,
+		Fields: map[string]string{
+			searchFieldTitle:   art.Title,
+			searchFieldContent: art.Content,
+			searchFieldAuthor:  art.Author.Name,
+		},
+		Keywords: map[string][]string{
+			searchFieldTag:      art.Tags,
+			searchFieldAuthorId: {strconv.FormatInt(art.Author.Id, 10)},
+		},
+	})
+}
+
+func (s *IndexSearchService) Rebuild(ctx context.Context) error {
+	start := time.Now()
+	seen := make(map[int64]struct{}, s.index.Len())
+	var startId int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		arts, err := s.repo.ListPubForIndex(ctx, startId, s.batchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			s.put(art)
+			seen[art.Id] = struct{}{}
+		}
+		if len(arts) < s.batchSize {
+			break
+		}
+		startId = arts[len(arts)-1].Id
+	}
+	// 重建期间没有扫描到，也没有被事件更新过的，说明已经不在线上了，漏掉了撤回的事件
+	removed := s.index.Retain(func(doc searchx.Document) bool {
+		_, ok := seen[doc.Id]
+		return ok || doc.Version >= start.UnixMilli()
+	})
+	s.l.Info("重建搜索索引完成", logger.Int("cnt", len(seen)),
+		logger.Int("removed", removed),
+		logger.String("duration", time.Since(start).String()))
+	return nil
+}
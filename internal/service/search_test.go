@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"red-feed/internal/domain"
+	"red-feed/internal/repository"
+	repomocks "red-feed/internal/repository/mocks"
+	"red-feed/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestIndexSearchService_Search(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	now := time.UnixMilli(time.Now().UnixMilli())
+	repo.EXPECT().ListPubForIndex(gomock.Any(), int64(0), 2).Return([]domain.Article{
+		{Id: 1, Title: "Go 并发编程", Content: "goroutine 和 channel", Tags: []string{"Go"},
+			Author: domain.Author{Id: 11, Name: "张三"}, Status: domain.ArticleStatusPublished, Utime: now},
+		{Id: 2, Title: "MySQL 索引", Content: "B+ 树索引的原理，顺便讲讲 Go 怎么用", Tags: []string{"MySQL"},
+			Author: domain.Author{Id: 12, Name: "李四"}, Status: domain.ArticleStatusPublished, Utime: now},
+	}, nil)
+	repo.EXPECT().ListPubForIndex(gomock.Any(), int64(2), 2).Return([]domain.Article{
+		{Id: 3, Title: "随笔", Content: "今天天气不错", Tags: []string{"生活"},
+			Author: domain.Author{Id: 11, Name: "张三"}, Status: domain.ArticleStatusPublished, Utime: now},
+	}, nil)
+	svc := NewIndexSearchService(repo, &logger.NopLogger{}).(*IndexSearchService)
+	svc.batchSize = 2
+	require.NoError(t, svc.Rebuild(context.Background()))
+
+	testCases := []struct {
+		name    string
+		query   string
+		offset  int
+		limit   int
+		wantIds []int64
+		total   int
+	}{
+		{
+			name:    "普通查询，标题命中的排在前面",
+			query:   "go",
+			limit:   10,
+			wantIds: []int64{1, 2},
+			total:   2,
+		},
+		{
+			name:    "限定标题",
+			query:   "title:索引",
+			limit:   10,
+			wantIds: []int64{2},
+			total:   1,
+		},
+		{
+			name:    "按照标签过滤",
+			query:   "go tag:mysql",
+			limit:   10,
+			wantIds: []int64{2},
+			total:   1,
+		},
+		{
+			name:    "按照作者名字",
+			query:   "author:张三",
+			limit:   10,
+			wantIds: []int64{3, 1},
+			total:   2,
+		},
+		{
+			name:    "按照作者id",
+			query:   "author:12",
+			limit:   10,
+			wantIds: []int64{2},
+			total:   1,
+		},
+		{
+			name:    "分页",
+			query:   "author:11",
+			offset:  1,
+			limit:   1,
+			wantIds: []int64{1},
+			total:   2,
+		},
+		{
+			name:    "没有命中",
+			query:   "Java",
+			limit:   10,
+			wantIds: []int64{},
+			total:   0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := svc.Search(context.Background(), tc.query, tc.offset, tc.limit)
+			require.NoError(t, err)
+			ids := make([]int64, 0, len(res.Articles))
+			for _, art := range res.Articles {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.Equal(t, tc.total, res.Total)
+		})
+	}
+
+	res, err := svc.Search(context.Background(), "并发", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, res.Articles, 1)
+	assert.Equal(t, "Go <em>并发</em>编程", res.Articles[0].Title)
+	assert.Equal(t, domain.Author{Id: 11, Name: "张三"}, res.Articles[0].Author)
+}
+
+func TestIndexSearchService_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockArticleRepository(ctrl)
+	svc := NewIndexSearchService(repo, &logger.NopLogger{})
+	now := time.Now().Add(-time.Minute)
+	art := domain.Article{Id: 1, Title: "搜索引擎", Status: domain.ArticleStatusPublished, Utime: now}
+
+	// 发表
+	repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(art, nil)
+	require.NoError(t, svc.Sync(context.Background(), 1))
+	res, err := svc.Search(context.Background(), "搜索", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Total)
+
+	// 改成仅关注者可见，要从索引里面删掉
+	art.Visibility = domain.ArticleVisibilityFollowers
+	art.Utime = now.Add(time.Second)
+	repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(art, nil)
+	require.NoError(t, svc.Sync(context.Background(), 1))
+	res, err = svc.Search(context.Background(), "搜索", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Total)
+
+	// 重新公开，然后删除
+	art.Visibility = domain.ArticleVisibilityPublic
+	art.Utime = now.Add(time.Second * 2)
+	repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(art, nil)
+	require.NoError(t, svc.Sync(context.Background(), 1))
+	repo.EXPECT().GetPubById(gomock.Any(), int64(1)).Return(domain.Article{}, repository.ErrArticleNotFound)
+	require.NoError(t, svc.Sync(context.Background(), 1))
+	res, err = svc.Search(context.Background(), "搜索", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Total)
+}
@@ -18,6 +18,8 @@ type UserService interface {
 	SignUp(ctx context.Context, u domain.User) error
 	Login(ctx context.Context, email, password string) (domain.User, error)
 	Profile(ctx context.Context, id int64) (domain.User, error)
+	// FindByIds 批量查询，给列表页补充作者信息用，不存在的用户不在结果里
+	FindByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error)
 	FindOrCreate(ctx *gin.Context, phone string) (domain.User, error)
 	FindOrCreateByWechat(ctx *gin.Context, wechatInfo domain.WechatInfo) (domain.User, error)
 }
@@ -62,6 +64,10 @@ func (s *userService) Profile(ctx context.Context, id int64) (domain.User, error
 	return s.repo.FindById(ctx, id)
 }
 
+func (s *userService) FindByIds(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	return s.repo.FindByIds(ctx, ids)
+}
+
 func (s *userService) FindOrCreate(ctx *gin.Context, phone string) (domain.User, error) {
 	u, err := s.repo.FindByPhone(ctx, phone)
 	if err != repository.ErrUserNotFound {
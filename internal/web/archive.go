@@ -0,0 +1,259 @@
+package web
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"red-feed/internal/domain"
+	"red-feed/internal/service"
+	ijwt "red-feed/internal/web/jwt"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+var _ Handler = (*ArchiveHandler)(nil)
+
+// importMaxSize 导入的压缩包最大 20MB
+const importMaxSize = 20 << 20
+
+type ArchiveHandler struct {
+	svc service.ArchiveService
+	l   logger.Logger
+}
+
+func NewArchiveHandler(svc service.ArchiveService, l logger.Logger) *ArchiveHandler {
+	return &ArchiveHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *ArchiveHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	g.POST("/import", h.Import)
+	// 帖子不多的时候直接下载，多的时候转成后台任务
+	g.GET("/export", h.Export)
+	g.POST("/export/tasks", h.StartExport)
+	g.GET("/export/tasks/:id", h.ExportTask)
+	g.GET("/export/download/:id", h.Download)
+}
+
+type ImportItemVO struct {
+	File   string `json:"file"`
+	Id     int64  `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type ExportTaskVO struct {
+	Id string `json:"id"`
+	// Status 1 导出中 2 完成 3 失败
+	Status uint8  `json:"status"`
+	Cnt    int    `json:"cnt"`
+	Error  string `json:"error,omitempty"`
+	// Url 完成之后才有
+	Url   string `json:"url,omitempty"`
+	Ctime string `json:"ctime"`
+	Utime string `json:"utime"`
+}
+
+func (h *ArchiveHandler) Import(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "请上传 zip 文件",
+		})
+		return
+	}
+	if header.Size > importMaxSize {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "文件太大，不能超过 20MB",
+		})
+		return
+	}
+	f, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("打开上传文件失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	defer f.Close()
+	items, err := h.svc.Import(ctx, uc.Uid, f, header.Size)
+	switch err {
+	case nil:
+	case service.ErrInvalidArchive, service.ErrTooManyFiles:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  err.Error(),
+		})
+		return
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("导入帖子失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	vos := make([]ImportItemVO, 0, len(items))
+	for _, item := range items {
+		vos = append(vos, ImportItemVO{
+			File:   item.File,
+			Id:     item.Id,
+			Title:  item.Title,
+			Status: item.Status,
+			Error:  item.Error,
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: vos,
+	})
+}
+
+func (h *ArchiveHandler) Export(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	async, err := h.svc.NeedAsyncExport(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询帖子数量失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	if async {
+		h.startExport(ctx, uc.Uid)
+		return
+	}
+	h.setAttachment(ctx, uc.Uid)
+	// 已经开始写响应了，出错只能记日志，客户端拿到的是不完整的压缩包
+	if _, err = h.svc.Export(ctx, uc.Uid, ctx.Writer); err != nil {
+		h.l.Error("导出帖子失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+	}
+}
+
+func (h *ArchiveHandler) StartExport(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	h.startExport(ctx, uc.Uid)
+}
+
+func (h *ArchiveHandler) startExport(ctx *gin.Context, uid int64) {
+	task, err := h.svc.StartExport(ctx, uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("创建导出任务失败", logger.Error(err), logger.Int64("uid", uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Msg:  "帖子比较多，已经转成后台任务，完成之后可以下载",
+		Data: h.toTaskVO(task),
+	})
+}
+
+func (h *ArchiveHandler) ExportTask(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	task, err := h.svc.GetExportTask(ctx, uc.Uid, ctx.Param("id"))
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusOK, Result{
+			Data: h.toTaskVO(task),
+		})
+	case service.ErrExportTaskNotFound:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  err.Error(),
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询导出任务失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+	}
+}
+
+func (h *ArchiveHandler) Download(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	rc, err := h.svc.OpenExport(ctx, uc.Uid, ctx.Param("id"))
+	switch err {
+	case nil:
+	case service.ErrExportTaskNotFound, service.ErrExportNotReady:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  err.Error(),
+		})
+		return
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("打开导出文件失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	defer rc.Close()
+	h.setAttachment(ctx, uc.Uid)
+	if _, err = io.Copy(ctx.Writer, rc); err != nil {
+		h.l.Error("下载导出文件失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+	}
+}
+
+func (h *ArchiveHandler) setAttachment(ctx *gin.Context, uid int64) {
+	name := fmt.Sprintf("articles-%d-%s.zip", uid, time.Now().Format("20060102"))
+	ctx.Header("Content-Type", "application/zip")
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+	ctx.Status(http.StatusOK)
+}
+
+func (h *ArchiveHandler) claims(ctx *gin.Context) (*ijwt.UserClaims, bool) {
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("获得用户会话信息失败")
+	}
+	return uc, ok
+}
+
+func (h *ArchiveHandler) toTaskVO(task domain.ExportTask) ExportTaskVO {
+	vo := ExportTaskVO{
+		Id:     task.Id,
+		Status: task.Status.ToUint8(),
+		Cnt:    task.Cnt,
+		Error:  task.Error,
+		Ctime:  task.Ctime.Format(time.DateTime),
+		Utime:  task.Utime.Format(time.DateTime),
+	}
+	if task.Status == domain.ExportTaskStatusDone {
+		vo.Url = "/articles/export/download/" + task.Id
+	}
+	return vo
+}
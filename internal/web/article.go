@@ -1,6 +1,7 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
@@ -21,16 +22,19 @@ var _ Handler = (*ArticleHandler)(nil)
 type ArticleHandler struct {
 	svc     service.ArticleService
 	intrSvc service2.InteractiveService
+	userSvc service.UserService
 	l       logger.Logger
 	biz     string
 }
 
-func NewArticleHandler(svc service.ArticleService, l logger.Logger, intrSvc service2.InteractiveService) *ArticleHandler {
+func NewArticleHandler(svc service.ArticleService, l logger.Logger, intrSvc service2.InteractiveService,
+	userSvc service.UserService) *ArticleHandler {
 	return &ArticleHandler{
 		svc:     svc,
 		l:       l,
 		intrSvc: intrSvc,
-		biz:     "article",
+		userSvc: userSvc,
+		biz:     domain2.BizArticle,
 	}
 }
 
@@ -41,25 +45,55 @@ func (a *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	ag.POST("/withdraw", a.WithDraw) // 创作撤销发表的文章
 	ag.POST("/list", a.List)         // 创作者查看自己的文章列表
 	ag.GET("/detail/:id", a.Detail)  // 创作者查看自己的文章详情
+	ag.POST("/share", a.Share)       // 创作者生成预览链接
+	ag.GET("/preview", a.Preview)    // 拿着预览链接查看文章，不需要登录
+	ag.POST("/likers", a.Likers)     // 创作者查看谁点赞了自己的文章
+
+	trash := ag.Group("/trash")
+	trash.POST("/delete", a.Delete)         // 创作者删除文章，放入回收站
+	trash.POST("/restore", a.Restore)       // 创作者从回收站恢复文章
+	trash.POST("/list", a.TrashList)        // 创作者查看回收站
+	trash.POST("/purge", a.PermanentDelete) // 创作者从回收站彻底删除文章
 
 	pub := ag.Group("/pub")
 	//pub.GET("/pub", a.ListPub)
 	pub.GET("/:id", a.PubDetail) // 读者查看文章详情
 	pub.POST("/list", a.PubList) // 读者查看文章列表
 
-	pub.POST("/like", a.Like)       // 读者点赞 or 取消点赞
-	pub.POST("/collect", a.Collect) // 读者收藏 or 取消收藏
+	pub.POST("/like", a.Like)          // 读者点赞 or 取消点赞
+	pub.POST("/collect", a.Collect)    // 读者收藏 or 取消收藏
+	pub.POST("/liked", a.LikedList)    // 读者查看自己点赞过的文章
+	pub.POST("/react", a.React)        // 读者发表情 or 取消表情
+	pub.GET("/reactions", a.Reactions) // 可以用的表情
 }
 
 func (a *ArticleHandler) Edit(ctx *gin.Context) {
 	var req struct {
-		Id      int64  `json:"id"`
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id         int64    `json:"id"`
+		Title      string   `json:"title"`
+		Content    string   `json:"content"`
+		Visibility uint8    `json:"visibility"`
+		Tags       []string `json:"tags"`
 	}
 	if err := ctx.ShouldBind(&req); err != nil {
 		return
 	}
+	visibility := domain.ArticleVisibility(req.Visibility)
+	if !visibility.Valid() {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "可见范围不对",
+		})
+		return
+	}
+	tags, ok := domain.NormalizeTags(req.Tags)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "标签不对",
+		})
+		return
+	}
 	// 获取用户id
 	claims := ctx.MustGet("claims")
 	claimsVal, ok := claims.(*ijwt.UserClaims)
@@ -79,7 +113,9 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 		Author: domain.Author{
 			Id: claimsVal.Uid,
 		},
-		Status: domain.ArticleStatusUnPublished,
+		Status:     domain.ArticleStatusUnPublished,
+		Visibility: visibility,
+		Tags:       tags,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
@@ -97,13 +133,31 @@ func (a *ArticleHandler) Edit(ctx *gin.Context) {
 
 func (a *ArticleHandler) Publish(ctx *gin.Context) {
 	var req struct {
-		Id      int64  `json:"id"`
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id         int64    `json:"id"`
+		Title      string   `json:"title"`
+		Content    string   `json:"content"`
+		Visibility uint8    `json:"visibility"`
+		Tags       []string `json:"tags"`
 	}
 	if err := ctx.ShouldBind(&req); err != nil {
 		return
 	}
+	visibility := domain.ArticleVisibility(req.Visibility)
+	if !visibility.Valid() {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "可见范围不对",
+		})
+		return
+	}
+	tags, ok := domain.NormalizeTags(req.Tags)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "标签不对",
+		})
+		return
+	}
 	// 获取用户id
 	claims := ctx.MustGet("claims")
 	claimsVal, ok := claims.(*ijwt.UserClaims)
@@ -123,7 +177,17 @@ func (a *ArticleHandler) Publish(ctx *gin.Context) {
 		Author: domain.Author{
 			Id: claimsVal.Uid,
 		},
+		Visibility: visibility,
+		Tags:       tags,
 	})
+	if err == service.ErrArticleUnderReview {
+		// 帖子已经保存了，前端根据 id 跳转到详情页，展示审核中
+		ctx.JSON(http.StatusOK, Result{
+			Msg:  "帖子需要人工审核，审核通过之后会自动发表",
+			Data: id,
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -176,6 +240,104 @@ func (a *ArticleHandler) WithDraw(ctx *gin.Context) {
 	})
 }
 
+func (a *ArticleHandler) Delete(ctx *gin.Context) {
+	a.trashOp(ctx, "删除帖子失败", a.svc.Delete)
+}
+
+func (a *ArticleHandler) Restore(ctx *gin.Context) {
+	a.trashOp(ctx, "恢复帖子失败", a.svc.Restore)
+}
+
+func (a *ArticleHandler) PermanentDelete(ctx *gin.Context) {
+	a.trashOp(ctx, "彻底删除帖子失败", a.svc.PermanentDelete)
+}
+
+// trashOp 回收站的几个操作，参数和返回值都是一样的
+func (a *ArticleHandler) trashOp(ctx *gin.Context, errMsg string,
+	op func(ctx context.Context, art domain.Article) error) {
+	var req struct {
+		Id int64 `json:"id"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	claimsVal, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("未发现用户的 session 信息")
+		return
+	}
+	err := op(ctx, domain.Article{
+		Id: req.Id,
+		Author: domain.Author{
+			Id: claimsVal.Uid,
+		},
+	})
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "OK",
+		})
+	case service.ErrArticleNotFound:
+		// 不存在、不是自己的，或者已经过了恢复期限
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error(errMsg, logger.Error(err), logger.Int64("artId", req.Id))
+	}
+}
+
+func (a *ArticleHandler) TrashList(ctx *gin.Context) {
+	var req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	claimsVal, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("未发现用户的 session 信息")
+		return
+	}
+	res, err := a.svc.ListTrash(ctx, claimsVal.Uid, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("查询回收站失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.Article, ArticleVO](res,
+			func(idx int, src domain.Article) ArticleVO {
+				return ArticleVO{
+					Id:       src.Id,
+					Title:    src.Title,
+					Abstract: src.Abstract(),
+					Status:   src.Status.ToUint8(),
+					Ctime:    src.Ctime.Format(time.DateTime),
+					Utime:    src.Utime.Format(time.DateTime),
+					Dtime:    src.Dtime.Format(time.DateTime),
+				}
+			}),
+	})
+}
+
 func (a *ArticleHandler) List(ctx *gin.Context) {
 	var req struct {
 		Offset int `json:"offset"`
@@ -267,10 +429,12 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, Result{
 		Data: ArticleVO{
-			Id:      art.Id,
-			Title:   art.Title,
-			Status:  art.Status.ToUint8(),
-			Content: art.Content,
+			Id:         art.Id,
+			Title:      art.Title,
+			Status:     art.Status.ToUint8(),
+			Visibility: art.Visibility.ToUint8(),
+			Tags:       art.Tags,
+			Content:    art.Content,
 			// 这个是创作者看自己的文章列表，也不需要这个字段
 			Ctime: art.Ctime.Format(time.DateTime),
 			Utime: art.Utime.Format(time.DateTime),
@@ -278,26 +442,125 @@ func (a *ArticleHandler) Detail(ctx *gin.Context) {
 	})
 }
 
-func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+func (a *ArticleHandler) Share(ctx *gin.Context) {
+	var req struct {
+		Id int64 `json:"id"`
+		// Ttl 链接有效期，单位是小时，默认一天，最多七天
+		Ttl int64 `json:"ttl"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	if req.Ttl <= 0 {
+		req.Ttl = 24
+	}
+	if req.Ttl > 24*7 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "有效期最多七天",
+		})
+		return
+	}
+	claimsVal, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("未发现用户的 session 信息")
+		return
+	}
+	token, err := a.svc.GenerateShareToken(ctx, domain.Article{
+		Id: req.Id,
+		Author: domain.Author{
+			Id: claimsVal.Uid,
+		},
+	}, time.Duration(req.Ttl)*time.Hour)
+	if err == service.ErrArticleNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("生成预览链接失败", logger.Error(err), logger.Int64("artId", req.Id))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: gin.H{
+			"token": token,
+			"url":   fmt.Sprintf("/articles/preview?id=%d&token=%s", req.Id, token),
+		},
+	})
+}
+
+// Preview 拿着预览链接的人不一定有账号，所以这里不依赖登录态，也不返回互动数据
+func (a *ArticleHandler) Preview(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Query("id"), 10, 64)
 	if err != nil {
-		a.l.Error("前端输入的 ID 不对", logger.Error(err))
 		ctx.JSON(http.StatusOK, Result{
 			Code: 4,
 			Msg:  "参数错误",
 		})
 		return
 	}
-	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
-	if !ok {
+	token := ctx.Query("token")
+	if token == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	art, err := a.svc.GetPublishedById(ctx, id, readerOf(ctx), token)
+	if err == service.ErrArticleNotFound || err == service.ErrInvalidPreviewToken {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "预览链接无效或已过期",
+		})
+		return
+	}
+	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
 			Msg:  "系统错误",
 		})
-		a.l.Error("获得用户会话信息失败")
+		a.l.Error("预览文章失败", logger.Error(err), logger.Int64("artId", id))
 		return
 	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: ArticleVO{
+			Id:         art.Id,
+			Title:      art.Title,
+			Status:     art.Status.ToUint8(),
+			Visibility: art.Visibility.ToUint8(),
+			Tags:       art.Tags,
+			Content:    art.Content,
+			Author:     art.Author.Name,
+			Ctime:      art.Ctime.Format(time.DateTime),
+			Utime:      art.Utime.Format(time.DateTime),
+		},
+	})
+}
+
+func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
+	idStr := ctx.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		a.l.Error("前端输入的 ID 不对", logger.Error(err))
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	// 没登录也能看，这时候 Liked 和 Collected 都是 false
+	r := readerOf(ctx)
 
 	// 使用 error group 来同时查询数据
 	var (
@@ -307,17 +570,24 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 	)
 	eg.Go(func() error {
 		var er error
-		art, er = a.svc.GetPublishedById(ctx, id, uc.Uid)
+		art, er = a.svc.GetPublishedById(ctx, id, r, ctx.Query("token"))
 		return er
 	})
 
 	eg.Go(func() error {
 		var er error
-		intr, er = a.intrSvc.Get(ctx, a.biz, id, uc.Uid)
+		intr, er = a.intrSvc.Get(ctx, a.biz, id, r.Uid)
 		return er
 	})
 
 	err = eg.Wait()
+	if err == service.ErrArticleNotFound || err == service.ErrInvalidPreviewToken {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
 	if err != nil {
 		a.l.Error("获得文章详情信息失败", logger.Error(err))
 		ctx.JSON(http.StatusOK, Result{
@@ -329,18 +599,23 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, Result{
 		Data: ArticleVO{
-			Id:         art.Id,
-			Title:      art.Title,
-			Status:     art.Status.ToUint8(),
-			Content:    art.Content,
-			Author:     art.Author.Name, // 详情页 要把作者信息带出去
-			CollectCnt: intr.CollectCnt,
-			ReadCnt:    intr.ReadCnt,
-			LikeCnt:    intr.LikeCnt,
-			Collected:  intr.Collected,
-			Liked:      intr.Liked,
-			Ctime:      art.Ctime.Format(time.DateTime),
-			Utime:      art.Utime.Format(time.DateTime),
+			Id:           art.Id,
+			Title:        art.Title,
+			Status:       art.Status.ToUint8(),
+			Content:      art.Content,
+			Visibility:   art.Visibility.ToUint8(),
+			Tags:         art.Tags,
+			Author:       art.Author.Name, // 详情页 要把作者信息带出去
+			CollectCnt:   intr.CollectCnt,
+			ReadCnt:      intr.ReadCnt,
+			UvCnt:        intr.UvCnt,
+			ReactionCnts: intr.ReactionCnts,
+			LikeCnt:      intr.LikeCnt,
+			Collected:    intr.Collected,
+			Liked:        intr.Liked,
+			Reaction:     intr.Reaction,
+			Ctime:        art.Ctime.Format(time.DateTime),
+			Utime:        art.Utime.Format(time.DateTime),
 		},
 	})
 }
@@ -361,9 +636,18 @@ func (a *ArticleHandler) PubList(ctx *gin.Context) {
 		})
 		return
 	}
+	// 列表页允许不登录，登录了才有点赞和收藏的状态
+	var uid int64
+	if uc, ok := ctx.Get("claims"); ok {
+		if claims, ok := uc.(*ijwt.UserClaims); ok {
+			uid = claims.Uid
+		}
+	}
+	authors, intrs, states := a.enrich(ctx, uid, res)
 	ctx.JSON(http.StatusOK, Result{
 		Data: slice.Map[domain.Article, ArticleVO](res,
 			func(idx int, src domain.Article) ArticleVO {
+				intr := intrs[src.Id]
 				return ArticleVO{
 					Id:       src.Id,
 					Title:    src.Title,
@@ -371,15 +655,72 @@ func (a *ArticleHandler) PubList(ctx *gin.Context) {
 					Status:   src.Status.ToUint8(),
 					// 这个列表请求，不需要返回内容
 					//Content: src.Content,
-					// 这个是创作者看自己的文章列表，也不需要这个字段
-					Author: src.Author.Name,
-					Ctime:  src.Ctime.Format(time.DateTime),
-					Utime:  src.Utime.Format(time.DateTime),
+					Tags:       src.Tags,
+					Author:     authors[src.Author.Id].Nickname,
+					LikeCnt:    intr.LikeCnt,
+					CollectCnt: intr.CollectCnt,
+					ReadCnt:    intr.ReadCnt,
+					UvCnt:      intr.UvCnt,
+					Liked:      states[src.Id].Liked,
+					Collected:  states[src.Id].Collected,
+					Ctime:      src.Ctime.Format(time.DateTime),
+					Utime:      src.Utime.Format(time.DateTime),
 				}
 			}),
 	})
 }
 
+// enrich 列表页一次性补充作者、计数和读者的点赞收藏状态，几个查询并行。
+// 查询失败只记录日志，列表照样返回，只是缺了这部分数据
+func (a *ArticleHandler) enrich(ctx context.Context, uid int64, arts []domain.Article) (
+	map[int64]domain.User, map[int64]domain2.Interactive, map[int64]domain2.UserState) {
+	if len(arts) == 0 {
+		return nil, nil, nil
+	}
+	ids := make([]int64, 0, len(arts))
+	uids := make([]int64, 0, len(arts))
+	seen := make(map[int64]struct{}, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+		if _, ok := seen[art.Author.Id]; !ok {
+			seen[art.Author.Id] = struct{}{}
+			uids = append(uids, art.Author.Id)
+		}
+	}
+	var (
+		eg      errgroup.Group
+		authors map[int64]domain.User
+		intrs   map[int64]domain2.Interactive
+		states  map[int64]domain2.UserState
+	)
+	eg.Go(func() error {
+		var err error
+		authors, err = a.userSvc.FindByIds(ctx, uids)
+		if err != nil {
+			a.l.Error("批量查询作者失败", logger.Error(err))
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var err error
+		intrs, err = a.intrSvc.GetByIds(ctx, a.biz, ids)
+		if err != nil {
+			a.l.Error("批量查询互动数据失败", logger.Error(err))
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		var err error
+		states, err = a.intrSvc.GetUserStateByIds(ctx, a.biz, uid, ids)
+		if err != nil {
+			a.l.Error("批量查询点赞收藏状态失败", logger.Error(err), logger.Int64("uid", uid))
+		}
+		return nil
+	})
+	_ = eg.Wait()
+	return authors, intrs, states
+}
+
 func (a *ArticleHandler) Like(ctx *gin.Context) {
 	var req struct {
 		Id   int64 `json:"id"`
@@ -403,17 +744,273 @@ func (a *ArticleHandler) Like(ctx *gin.Context) {
 	} else {
 		err = a.intrSvc.CancelLike(ctx, a.biz, req.Id, uc.Uid)
 	}
+	if err == domain2.ErrBizNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
 			Msg:  "系统错误",
 		})
+		return
 	}
 	ctx.JSON(http.StatusOK, Result{
 		Msg: "OK",
 	})
 }
 
+// React 一篇帖子一个读者只能有一个表情，Reaction 为空就是取消
+func (a *ArticleHandler) React(ctx *gin.Context) {
+	var req struct {
+		Id       int64  `json:"id"`
+		Reaction string `json:"reaction"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("获得用户会话信息失败")
+		return
+	}
+	var err error
+	if req.Reaction == "" {
+		err = a.intrSvc.CancelReaction(ctx, a.biz, req.Id, uc.Uid)
+	} else {
+		err = a.intrSvc.React(ctx, a.biz, req.Id, uc.Uid, req.Reaction)
+	}
+	if err == domain2.ErrBizNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
+	if err == domain2.ErrInvalidReaction {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "不支持这个表情",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("发表情失败", logger.Error(err),
+			logger.Int64("id", req.Id), logger.String("reaction", req.Reaction))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Msg: "OK",
+	})
+}
+
+func (a *ArticleHandler) Reactions(ctx *gin.Context) {
+	reactions, err := a.intrSvc.ListReactions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("查询表情失败", logger.Error(err))
+		return
+	}
+	res := make([]ReactionVO, 0, len(reactions))
+	for _, r := range reactions {
+		res = append(res, ReactionVO{
+			Key:   r.Key,
+			Emoji: r.Emoji,
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: res,
+	})
+}
+
+func (a *ArticleHandler) Likers(ctx *gin.Context) {
+	var req struct {
+		Id     int64  `json:"id"`
+		Cursor string `json:"cursor"`
+		Limit  int    `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("获得用户会话信息失败")
+		return
+	}
+	cursor, ok := a.likeCursor(ctx, req.Cursor, req.Limit)
+	if !ok {
+		return
+	}
+	art, err := a.svc.GetById(ctx, req.Id)
+	if err == service.ErrArticleNotFound || (err == nil && art.Author.Id != uc.Uid) {
+		// 只有作者自己能看
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("获得文章信息失败", logger.Error(err), logger.Int64("id", req.Id))
+		return
+	}
+	records, err := a.intrSvc.ListLikers(ctx, a.biz, req.Id, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("查询点赞列表失败", logger.Error(err), logger.Int64("id", req.Id))
+		return
+	}
+	uids := make([]int64, 0, len(records))
+	for _, r := range records {
+		uids = append(uids, r.Uid)
+	}
+	// 查不到昵称也照样返回
+	users, err := a.userSvc.FindByIds(ctx, uids)
+	if err != nil {
+		a.l.Error("批量查询点赞用户失败", logger.Error(err))
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: LikePageVO[LikerVO]{
+			Items: slice.Map[domain2.LikeRecord, LikerVO](records, func(idx int, src domain2.LikeRecord) LikerVO {
+				return LikerVO{
+					Uid:      src.Uid,
+					Nickname: users[src.Uid].Nickname,
+					Ctime:    src.Utime.Format(time.DateTime),
+				}
+			}),
+			NextCursor: a.nextLikeCursor(records, req.Limit),
+		},
+	})
+}
+
+func (a *ArticleHandler) LikedList(ctx *gin.Context) {
+	var req struct {
+		Cursor string `json:"cursor"`
+		Limit  int    `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("获得用户会话信息失败")
+		return
+	}
+	cursor, ok := a.likeCursor(ctx, req.Cursor, req.Limit)
+	if !ok {
+		return
+	}
+	records, err := a.intrSvc.ListLikedByUser(ctx, a.biz, uc.Uid, cursor, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("查询点赞过的文章失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	ids := make([]int64, 0, len(records))
+	for _, r := range records {
+		ids = append(ids, r.BizId)
+	}
+	arts, err := a.svc.GetPubByIds(ctx, ids)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		a.l.Error("批量查询文章失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	uids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+		uids = append(uids, art.Author.Id)
+	}
+	authors, err := a.userSvc.FindByIds(ctx, uids)
+	if err != nil {
+		a.l.Error("批量查询作者失败", logger.Error(err))
+	}
+	items := make([]LikedArticleVO, 0, len(records))
+	for _, r := range records {
+		// 撤回了或者删掉了的帖子不展示，但是游标照样往后走
+		art, ok := artMap[r.BizId]
+		if !ok {
+			continue
+		}
+		items = append(items, LikedArticleVO{
+			Id:       art.Id,
+			Title:    art.Title,
+			Abstract: art.Abstract(),
+			Author:   authors[art.Author.Id].Nickname,
+			LikedAt:  r.Utime.Format(time.DateTime),
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: LikePageVO[LikedArticleVO]{
+			Items:      items,
+			NextCursor: a.nextLikeCursor(records, req.Limit),
+		},
+	})
+}
+
+func (a *ArticleHandler) likeCursor(ctx *gin.Context, s string, limit int) (domain2.LikeCursor, bool) {
+	if limit <= 0 || limit > 100 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "分页参数不对",
+		})
+		return domain2.LikeCursor{}, false
+	}
+	cursor, err := domain2.ParseLikeCursor(s)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "游标不对",
+		})
+		return domain2.LikeCursor{}, false
+	}
+	return cursor, true
+}
+
+// nextLikeCursor 不满一页说明没有下一页了
+func (a *ArticleHandler) nextLikeCursor(records []domain2.LikeRecord, limit int) string {
+	if len(records) == 0 || len(records) < limit {
+		return ""
+	}
+	return records[len(records)-1].Cursor().String()
+}
+
 func (a *ArticleHandler) Collect(ctx *gin.Context) {
 	var req struct {
 		Id      int64 `json:"id"`
@@ -438,6 +1035,20 @@ func (a *ArticleHandler) Collect(ctx *gin.Context) {
 	} else {
 		err = a.intrSvc.CancelCollect(ctx, a.biz, req.Id, uc.Uid, req.Cid)
 	}
+	if err == domain2.ErrBizNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "帖子不存在",
+		})
+		return
+	}
+	if err == service2.ErrCollectionNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
 	if err != nil {
 		ctx.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -6,14 +6,51 @@ type ArticleVO struct {
 	Abstract string `json:"abstract"`
 	Content  string `json:"content"`
 	Status   uint8  `json:"status"`
-	Author   string `json:"author"`
-	Ctime    string `json:"ctime"`
-	Utime    string `json:"utime"`
+	// Visibility 0 所有人可见 1 仅关注者可见 2 仅链接可见
+	Visibility uint8    `json:"visibility"`
+	Tags       []string `json:"tags"`
+	Author     string   `json:"author"`
+	Ctime      string   `json:"ctime"`
+	Utime      string   `json:"utime"`
+	Dtime      string   `json:"dtime,omitempty"` // 放入回收站的时间
 
 	LikeCnt    int64 `json:"likeCnt"`    // 点赞数
 	CollectCnt int64 `json:"collectCnt"` // 收藏数
 	ReadCnt    int64 `json:"readCnt"`    // 阅读数
+	UvCnt      int64 `json:"uvCnt"`      // 阅读人数
+	// ReactionCnts 每个表情的数量，点赞的和 LikeCnt 一样
+	ReactionCnts map[string]int64 `json:"reactionCnts,omitempty"`
 
 	Liked     bool `json:"liked"`     // 个人是否点赞
 	Collected bool `json:"collected"` // 个人是否收藏
+	// Reaction 个人的表情，没有就是空的
+	Reaction string `json:"reaction,omitempty"`
+}
+
+// ReactionVO 可以用的表情
+type ReactionVO struct {
+	Key   string `json:"key"`
+	Emoji string `json:"emoji"`
+}
+
+// LikerVO 点赞了帖子的读者
+type LikerVO struct {
+	Uid      int64  `json:"uid"`
+	Nickname string `json:"nickname"`
+	Ctime    string `json:"ctime"` // 点赞时间
+}
+
+// LikedArticleVO 读者点赞过的帖子
+type LikedArticleVO struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	Author   string `json:"author"`
+	LikedAt  string `json:"likedAt"` // 点赞时间
+}
+
+// LikePageVO 按照游标翻页，NextCursor 为空说明没有下一页了
+type LikePageVO[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"nextCursor"`
 }
@@ -0,0 +1,332 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"red-feed/interactive/domain"
+	service2 "red-feed/interactive/service"
+	ijwt "red-feed/internal/web/jwt"
+	"red-feed/pkg/logger"
+	"time"
+	"unicode/utf8"
+)
+
+var _ Handler = (*CollectionHandler)(nil)
+
+const (
+	collectionNameMaxLen = 64
+	collectionDescMaxLen = 512
+	collectionItemsLimit = 100
+)
+
+// CollectionHandler 收藏夹管理，收藏夹里面目前只有帖子
+type CollectionHandler struct {
+	svc service2.CollectionService
+	l   logger.Logger
+	biz string
+}
+
+func NewCollectionHandler(svc service2.CollectionService, l logger.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		svc: svc,
+		l:   l,
+		biz: domain.BizArticle,
+	}
+}
+
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("/create", h.Create) // 新建收藏夹
+	g.POST("/edit", h.Edit)     // 修改收藏夹
+	g.POST("/delete", h.Delete) // 删除收藏夹，里面的收藏一起取消
+	g.POST("/list", h.List)     // 自己的收藏夹
+	g.POST("/move", h.Move)     // 把收藏挪到另一个收藏夹
+
+	g.POST("/user/list", h.UserList) // 别人公开的收藏夹，不需要登录
+	g.POST("/items", h.Items)        // 收藏夹里面的收藏，公开的不需要登录
+}
+
+type CollectionVO struct {
+	Id          int64  `json:"id"`
+	Uid         int64  `json:"uid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	IsDefault   bool   `json:"is_default"`
+	Ctime       string `json:"ctime"`
+	Utime       string `json:"utime"`
+}
+
+type CollectionItemVO struct {
+	Cid   int64  `json:"cid"`
+	BizId int64  `json:"biz_id"`
+	Ctime string `json:"ctime"`
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context) {
+	var req struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Public      bool   `json:"public"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	if !h.validate(ctx, req.Name, req.Description) {
+		return
+	}
+	id, err := h.svc.Create(ctx, domain.Collection{
+		Uid:         uc.Uid,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("创建收藏夹失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: id,
+	})
+}
+
+func (h *CollectionHandler) Edit(ctx *gin.Context) {
+	var req struct {
+		Id          int64  `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Public      bool   `json:"public"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	if !h.validate(ctx, req.Name, req.Description) {
+		return
+	}
+	err := h.svc.Update(ctx, domain.Collection{
+		Id:          req.Id,
+		Uid:         uc.Uid,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	h.result(ctx, err, "修改收藏夹失败", uc.Uid)
+}
+
+func (h *CollectionHandler) Delete(ctx *gin.Context) {
+	var req struct {
+		Id int64 `json:"id"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	err := h.svc.Delete(ctx, uc.Uid, req.Id)
+	h.result(ctx, err, "删除收藏夹失败", uc.Uid)
+}
+
+func (h *CollectionHandler) List(ctx *gin.Context) {
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	cs, err := h.svc.List(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询收藏夹失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.Collection, CollectionVO](cs, h.toVO),
+	})
+}
+
+func (h *CollectionHandler) UserList(ctx *gin.Context) {
+	var req struct {
+		Uid int64 `json:"uid"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	cs, err := h.svc.ListPublic(ctx, req.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询公开的收藏夹失败", logger.Error(err), logger.Int64("uid", req.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.Collection, CollectionVO](cs, h.toVO),
+	})
+}
+
+func (h *CollectionHandler) Items(ctx *gin.Context) {
+	var req struct {
+		Cid    int64 `json:"cid"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	if req.Offset < 0 || req.Limit <= 0 || req.Limit > collectionItemsLimit {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "分页参数不对",
+		})
+		return
+	}
+	// 没登录只能看公开的收藏夹
+	var uid int64
+	if uc, ok := ctx.Get("claims"); ok {
+		if claims, ok := uc.(*ijwt.UserClaims); ok {
+			uid = claims.Uid
+		}
+	}
+	items, err := h.svc.ListItems(ctx, uid, req.Cid, req.Offset, req.Limit)
+	if err == service2.ErrCollectionNotFound {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询收藏夹内容失败", logger.Error(err), logger.Int64("cid", req.Cid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: slice.Map[domain.CollectionItem, CollectionItemVO](items,
+			func(idx int, src domain.CollectionItem) CollectionItemVO {
+				return CollectionItemVO{
+					Cid:   src.Cid,
+					BizId: src.BizId,
+					Ctime: src.Ctime.Format(time.DateTime),
+				}
+			}),
+	})
+}
+
+func (h *CollectionHandler) Move(ctx *gin.Context) {
+	var req struct {
+		From int64   `json:"from"`
+		To   int64   `json:"to"`
+		Ids  []int64 `json:"ids"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	if len(req.Ids) > collectionItemsLimit {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "一次最多挪动 100 个收藏",
+		})
+		return
+	}
+	cnt, err := h.svc.Move(ctx, uc.Uid, req.From, req.To, h.biz, req.Ids)
+	if err == nil {
+		ctx.JSON(http.StatusOK, Result{
+			Data: cnt,
+		})
+		return
+	}
+	h.result(ctx, err, "挪动收藏失败", uc.Uid)
+}
+
+func (h *CollectionHandler) validate(ctx *gin.Context, name string, desc string) bool {
+	if name == "" || utf8.RuneCountInString(name) > collectionNameMaxLen {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹名字不能为空，也不能超过 64 个字",
+		})
+		return false
+	}
+	if utf8.RuneCountInString(desc) > collectionDescMaxLen {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹描述不能超过 512 个字",
+		})
+		return false
+	}
+	return true
+}
+
+// result 没有数据要返回的接口统一处理错误
+func (h *CollectionHandler) result(ctx *gin.Context, err error, msg string, uid int64) {
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "OK",
+		})
+	case service2.ErrCollectionNotFound:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "收藏夹不存在",
+		})
+	case service2.ErrDefaultCollection:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "默认收藏夹不能删除",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error(msg, logger.Error(err), logger.Int64("uid", uid))
+	}
+}
+
+func (h *CollectionHandler) claims(ctx *gin.Context) (*ijwt.UserClaims, bool) {
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("获得用户会话信息失败")
+	}
+	return uc, ok
+}
+
+func (h *CollectionHandler) toVO(idx int, src domain.Collection) CollectionVO {
+	return CollectionVO{
+		Id:          src.Id,
+		Uid:         src.Uid,
+		Name:        src.Name,
+		Description: src.Description,
+		Public:      src.Public,
+		IsDefault:   src.IsDefault,
+		Ctime:       src.Ctime.Format(time.DateTime),
+		Utime:       src.Utime.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,330 @@
+package web
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	domain2 "red-feed/interactive/domain"
+	service2 "red-feed/interactive/service"
+	"red-feed/internal/domain"
+	"red-feed/internal/service"
+	ijwt "red-feed/internal/web/jwt"
+	"red-feed/pkg/logger"
+	"sort"
+	"time"
+)
+
+var _ Handler = (*CreatorHandler)(nil)
+
+const (
+	// creatorArticlesLimit 统计全部帖子的时候最多统计最近发表的多少篇
+	creatorArticlesLimit = 1000
+	creatorArticlesBatch = 100
+	creatorTopDefault    = 10
+	creatorTopMax        = 50
+	// creatorStatsDays 没有传时间范围的时候看最近几天
+	creatorStatsDays = 7
+)
+
+// CreatorHandler 创作者看自己的帖子表现怎么样
+type CreatorHandler struct {
+	artSvc   service.ArticleService
+	intrSvc  service2.InteractiveService
+	follower service.FollowerCounter
+	l        logger.Logger
+	biz      string
+}
+
+func NewCreatorHandler(artSvc service.ArticleService, intrSvc service2.InteractiveService,
+	follower service.FollowerCounter, l logger.Logger) *CreatorHandler {
+	return &CreatorHandler{
+		artSvc:   artSvc,
+		intrSvc:  intrSvc,
+		follower: follower,
+		l:        l,
+		biz:      domain2.BizArticle,
+	}
+}
+
+func (h *CreatorHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/creator")
+	g.POST("/stats", h.Stats)         // 一篇或者全部帖子每个小时、每天新增的阅读、点赞、收藏
+	g.POST("/dashboard", h.Dashboard) // 一段时间里面的汇总、表现最好的帖子和新增的关注者
+}
+
+type StatsPointVO struct {
+	// Time 按小时的是 2006-01-02 15:00，按天的是 2006-01-02
+	Time       string `json:"time"`
+	ReadCnt    int64  `json:"readCnt"`
+	LikeCnt    int64  `json:"likeCnt"`
+	CollectCnt int64  `json:"collectCnt"`
+}
+
+type CreatorArticleVO struct {
+	Id         int64  `json:"id"`
+	Title      string `json:"title"`
+	ReadCnt    int64  `json:"readCnt"`
+	LikeCnt    int64  `json:"likeCnt"`
+	CollectCnt int64  `json:"collectCnt"`
+}
+
+type DashboardVO struct {
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	ReadCnt    int64  `json:"readCnt"`
+	LikeCnt    int64  `json:"likeCnt"`
+	CollectCnt int64  `json:"collectCnt"`
+	// NewFollowerCnt 新增的关注者，取消关注的减掉了。统计不了的时候没有这个字段
+	NewFollowerCnt *int64             `json:"newFollowerCnt,omitempty"`
+	TopArticles    []CreatorArticleVO `json:"topArticles"`
+}
+
+// Stats 日期是 2006-01-02，包括 end 那一天，都不传就是最近七天
+func (h *CreatorHandler) Stats(ctx *gin.Context) {
+	var req struct {
+		// Id 为 0 的时候统计自己全部发表了的帖子
+		Id          int64  `json:"id"`
+		Start       string `json:"start"`
+		End         string `json:"end"`
+		Granularity string `json:"granularity"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	start, end, ok := h.parseRange(ctx, req.Start, req.End)
+	if !ok {
+		return
+	}
+	granularity := domain2.StatsGranularity(req.Granularity)
+	if granularity == "" {
+		granularity = domain2.StatsGranularityDay
+	}
+	var ids []int64
+	if req.Id > 0 {
+		art, err := h.artSvc.GetById(ctx, req.Id)
+		if err == service.ErrArticleNotFound || (err == nil && art.Author.Id != uc.Uid) {
+			// 只有作者自己能看
+			ctx.JSON(http.StatusOK, Result{
+				Code: 4,
+				Msg:  "帖子不存在",
+			})
+			return
+		}
+		if err != nil {
+			ctx.JSON(http.StatusOK, Result{
+				Code: 5,
+				Msg:  "系统错误",
+			})
+			h.l.Error("获得文章信息失败", logger.Error(err), logger.Int64("id", req.Id))
+			return
+		}
+		ids = []int64{req.Id}
+	} else {
+		arts, err := h.authorArticles(ctx, uc.Uid)
+		if err != nil {
+			ctx.JSON(http.StatusOK, Result{
+				Code: 5,
+				Msg:  "系统错误",
+			})
+			h.l.Error("查询作者的帖子失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+			return
+		}
+		ids = make([]int64, 0, len(arts))
+		for _, art := range arts {
+			ids = append(ids, art.Id)
+		}
+	}
+	points, err := h.intrSvc.GetStats(ctx, h.biz, ids, start, end, granularity)
+	if err == domain2.ErrInvalidStatsRange {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "时间范围不对，按小时的最多看 7 天，按天的最多看 90 天",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询分时计数失败", logger.Error(err), logger.Int64("id", req.Id))
+		return
+	}
+	layout := time.DateOnly
+	if granularity == domain2.StatsGranularityHour {
+		layout = "2006-01-02 15:00"
+	}
+	res := make([]StatsPointVO, 0, len(points))
+	for _, p := range points {
+		res = append(res, StatsPointVO{
+			Time:       p.Time.Format(layout),
+			ReadCnt:    p.ReadCnt,
+			LikeCnt:    p.LikeCnt,
+			CollectCnt: p.CollectCnt,
+		})
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: res,
+	})
+}
+
+// Dashboard 时间范围和 Stats 一样
+func (h *CreatorHandler) Dashboard(ctx *gin.Context) {
+	var req struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+		// Limit 表现最好的帖子要几篇
+		Limit int `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := h.claims(ctx)
+	if !ok {
+		return
+	}
+	start, end, ok := h.parseRange(ctx, req.Start, req.End)
+	if !ok {
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = creatorTopDefault
+	}
+	req.Limit = min(req.Limit, creatorTopMax)
+	arts, err := h.authorArticles(ctx, uc.Uid)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询作者的帖子失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	ids := make([]int64, 0, len(arts))
+	titles := make(map[int64]string, len(arts))
+	for _, art := range arts {
+		ids = append(ids, art.Id)
+		titles[art.Id] = art.Title
+	}
+	cnts, err := h.intrSvc.GetStatsByIds(ctx, h.biz, ids, start, end)
+	if err == domain2.ErrInvalidStatsRange {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "时间范围不对，最多看 90 天",
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询帖子的计数失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	res := DashboardVO{
+		Start:       start.Format(time.DateOnly),
+		End:         end.AddDate(0, 0, -1).Format(time.DateOnly),
+		TopArticles: make([]CreatorArticleVO, 0, len(cnts)),
+	}
+	// 关注者查不到也照样返回，只是不带这个字段，不能当成 0
+	newFollowers, err := h.follower.CountNewFollowers(ctx, uc.Uid, start, end)
+	switch err {
+	case nil:
+		res.NewFollowerCnt = &newFollowers
+	case service.ErrFollowerCntUnavailable:
+	default:
+		h.l.Error("查询新增关注者失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+	}
+	for id, cnt := range cnts {
+		res.ReadCnt += cnt.ReadCnt
+		res.LikeCnt += cnt.LikeCnt
+		res.CollectCnt += cnt.CollectCnt
+		res.TopArticles = append(res.TopArticles, CreatorArticleVO{
+			Id:         id,
+			Title:      titles[id],
+			ReadCnt:    cnt.ReadCnt,
+			LikeCnt:    cnt.LikeCnt,
+			CollectCnt: cnt.CollectCnt,
+		})
+	}
+	// 阅读多的在前面，一样的看点赞，再一样的新发的在前面
+	sort.Slice(res.TopArticles, func(i, j int) bool {
+		a, b := res.TopArticles[i], res.TopArticles[j]
+		if a.ReadCnt != b.ReadCnt {
+			return a.ReadCnt > b.ReadCnt
+		}
+		if a.LikeCnt != b.LikeCnt {
+			return a.LikeCnt > b.LikeCnt
+		}
+		return a.Id > b.Id
+	})
+	if len(res.TopArticles) > req.Limit {
+		res.TopArticles = res.TopArticles[:req.Limit]
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: res,
+	})
+}
+
+// authorArticles 作者最近发表的帖子，最多 creatorArticlesLimit 篇
+func (h *CreatorHandler) authorArticles(ctx context.Context, uid int64) ([]domain.Article, error) {
+	var res []domain.Article
+	for offset := 0; offset < creatorArticlesLimit; offset += creatorArticlesBatch {
+		arts, err := h.artSvc.ListPubByAuthor(ctx, uid, offset, creatorArticlesBatch)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, arts...)
+		if len(arts) < creatorArticlesBatch {
+			break
+		}
+	}
+	return res, nil
+}
+
+// parseRange 返回 [start, end)，end 是结束那一天的第二天零点
+func (h *CreatorHandler) parseRange(ctx *gin.Context, startStr, endStr string) (time.Time, time.Time, bool) {
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if endStr != "" {
+		var err error
+		end, err = time.ParseInLocation(time.DateOnly, endStr, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusOK, Result{
+				Code: 4,
+				Msg:  "日期格式不对",
+			})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	start := end.AddDate(0, 0, 1-creatorStatsDays)
+	if startStr != "" {
+		var err error
+		start, err = time.ParseInLocation(time.DateOnly, startStr, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusOK, Result{
+				Code: 4,
+				Msg:  "日期格式不对",
+			})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return start, end.AddDate(0, 0, 1), true
+}
+
+func (h *CreatorHandler) claims(ctx *gin.Context) (*ijwt.UserClaims, bool) {
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("获得用户会话信息失败")
+	}
+	return uc, ok
+}
@@ -0,0 +1,96 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"red-feed/internal/domain"
+	"red-feed/internal/service"
+	"red-feed/pkg/logger"
+	"strconv"
+	"strings"
+)
+
+var _ Handler = (*FeedHandler)(nil)
+
+// FeedHandler 订阅源，不需要登录。
+// 加上 ?author=作者id 就是这个作者的订阅源
+type FeedHandler struct {
+	svc service.FeedService
+	l   logger.Logger
+}
+
+func NewFeedHandler(svc service.FeedService, l logger.Logger) *FeedHandler {
+	return &FeedHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/feeds")
+	g.GET("/rss.xml", h.RSS)
+	g.GET("/atom.xml", h.Atom)
+}
+
+func (h *FeedHandler) RSS(ctx *gin.Context) {
+	h.serve(ctx, domain.FeedFormatRSS, "application/rss+xml; charset=utf-8")
+}
+
+func (h *FeedHandler) Atom(ctx *gin.Context) {
+	h.serve(ctx, domain.FeedFormatAtom, "application/atom+xml; charset=utf-8")
+}
+
+func (h *FeedHandler) serve(ctx *gin.Context, format domain.FeedFormat, contentType string) {
+	var authorId int64
+	if author := ctx.Query("author"); author != "" {
+		id, err := strconv.ParseInt(author, 10, 64)
+		if err != nil || id <= 0 {
+			ctx.Status(http.StatusBadRequest)
+			return
+		}
+		authorId = id
+	}
+	doc, err := h.svc.Feed(ctx, format, authorId)
+	switch err {
+	case nil:
+	case service.ErrFeedAuthorNotFound:
+		ctx.Status(http.StatusNotFound)
+		return
+	default:
+		ctx.Status(http.StatusInternalServerError)
+		h.l.Error("生成订阅源失败", logger.Error(err), logger.Int64("authorId", authorId))
+		return
+	}
+	ctx.Header("ETag", doc.ETag)
+	ctx.Header("Cache-Control", "public, max-age=300")
+	if !doc.Modified.IsZero() {
+		ctx.Header("Last-Modified", doc.Modified.UTC().Format(http.TimeFormat))
+	}
+	if h.notModified(ctx, doc) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, doc.Content)
+}
+
+// notModified 优先看 If-None-Match，没有的时候才看 If-Modified-Since
+func (h *FeedHandler) notModified(ctx *gin.Context, doc domain.FeedDocument) bool {
+	if inm := ctx.GetHeader("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == doc.ETag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	ims := ctx.GetHeader("If-Modified-Since")
+	if ims == "" || doc.Modified.IsZero() {
+		return false
+	}
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	return !doc.Modified.After(t)
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	ijwt "red-feed/internal/web/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminMiddlewareBuilder 只允许配置好的管理员访问，要放在登录校验之后
+type AdminMiddlewareBuilder struct {
+	admins map[int64]struct{}
+}
+
+func NewAdminMiddlewareBuilder(admins []int64) *AdminMiddlewareBuilder {
+	b := &AdminMiddlewareBuilder{
+		admins: make(map[int64]struct{}, len(admins)),
+	}
+	for _, uid := range admins {
+		b.admins[uid] = struct{}{}
+	}
+	return b
+}
+
+func (b *AdminMiddlewareBuilder) Build() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+		if !ok {
+			ctx.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		if _, ok = b.admins[uc.Uid]; !ok {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type LoginJWTMiddlewareBuilder struct {
-	paths []string
+	ignored  []routePattern
+	optional []routePattern
 	ijwt.Handler
 }
 
@@ -19,21 +20,32 @@ func NewLoginJWTMiddlewareBuilder(jwtHdl ijwt.Handler) *LoginJWTMiddlewareBuilde
 	}
 }
 
+// IgnorePaths 不需要登录校验的路由。
+// 写法和 gin 的路由一致，支持 :id 和末尾的 *path，前面可以加上 HTTP 方法，比如 "GET /articles/pub/:id"
 func (l *LoginJWTMiddlewareBuilder) IgnorePaths(path string) *LoginJWTMiddlewareBuilder {
-	l.paths = append(l.paths, path)
+	l.ignored = append(l.ignored, newRoutePattern(path))
+	return l
+}
+
+// OptionalPaths 登录了就解析出用户信息，没登录也放行，这时候 ctx 里面没有 claims。
+// 带了 token 但是 token 无效的，还是返回 401，让前端去刷新 token
+func (l *LoginJWTMiddlewareBuilder) OptionalPaths(path string) *LoginJWTMiddlewareBuilder {
+	l.optional = append(l.optional, newRoutePattern(path))
 	return l
 }
 
 func (l *LoginJWTMiddlewareBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		method, path := ctx.Request.Method, ctx.Request.URL.Path
 		// 不需要登录校验的
-		for _, path := range l.paths {
-			if ctx.Request.URL.Path == path {
-				return
-			}
+		if matchAny(l.ignored, method, path) {
+			return
 		}
 		// 校验是否带有jwt token, 从请求头的authorization中解析
 		tokenStr := l.ExtractToken(ctx)
+		if tokenStr == "" && matchAny(l.optional, method, path) {
+			return
+		}
 		uc := &ijwt.UserClaims{}
 		token, err := jwt.ParseWithClaims(tokenStr, uc, func(token *jwt.Token) (interface{}, error) {
 			return ijwt.AtKey, nil
@@ -0,0 +1,59 @@
+package middleware
+
+import "strings"
+
+// routePattern 和 gin 的路由写法一致，:name 匹配一段，末尾的 *name 匹配剩下的所有部分。
+// method 为空的时候匹配所有方法
+type routePattern struct {
+	method   string
+	segments []string
+}
+
+func newRoutePattern(pattern string) routePattern {
+	var method string
+	if m, path, ok := strings.Cut(pattern, " "); ok {
+		method, pattern = strings.ToUpper(m), strings.TrimSpace(path)
+	}
+	return routePattern{
+		method:   method,
+		segments: splitPath(pattern),
+	}
+}
+
+func (p routePattern) match(method, path string) bool {
+	if p.method != "" && p.method != method {
+		return false
+	}
+	segs := splitPath(path)
+	for i, seg := range p.segments {
+		if strings.HasPrefix(seg, "*") {
+			return true
+		}
+		if i >= len(segs) {
+			return false
+		}
+		if strings.HasPrefix(seg, ":") {
+			if segs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != segs[i] {
+			return false
+		}
+	}
+	return len(segs) == len(p.segments)
+}
+
+func matchAny(patterns []routePattern, method, path string) bool {
+	for _, p := range patterns {
+		if p.match(method, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
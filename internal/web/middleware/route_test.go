@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoutePattern_Match(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		method  string
+		path    string
+		want    bool
+	}{
+		{name: "完全一样", pattern: "/users/login", method: "POST", path: "/users/login", want: true},
+		{name: "末尾多了斜杠", pattern: "/search", method: "GET", path: "/search/", want: true},
+		{name: "前缀不算", pattern: "/users/login", method: "POST", path: "/users/login_sms", want: false},
+		{name: "路径参数", pattern: "/articles/pub/:id", method: "GET", path: "/articles/pub/12", want: true},
+		{name: "路径参数不能为空", pattern: "/articles/pub/:id", method: "GET", path: "/articles/pub/", want: false},
+		{name: "段数不一样", pattern: "/articles/pub/:id", method: "GET", path: "/articles/pub/12/like", want: false},
+		{name: "方法一样", pattern: "GET /articles/pub/:id", method: "GET", path: "/articles/pub/like", want: true},
+		{name: "方法不一样", pattern: "GET /articles/pub/:id", method: "POST", path: "/articles/pub/like", want: false},
+		{name: "通配符", pattern: "/feeds/*path", method: "GET", path: "/feeds/authors/1/rss.xml", want: true},
+		{name: "通配符匹配空", pattern: "/feeds/*path", method: "GET", path: "/feeds", want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, newRoutePattern(tc.pattern).match(tc.method, tc.path))
+		})
+	}
+}
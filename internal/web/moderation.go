@@ -0,0 +1,180 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"red-feed/internal/domain"
+	"red-feed/internal/service"
+	ijwt "red-feed/internal/web/jwt"
+	"red-feed/pkg/logger"
+	"strings"
+	"time"
+)
+
+var _ Handler = (*ModerationHandler)(nil)
+
+type ModerationHandler struct {
+	svc   service.ModerationService
+	admin gin.HandlerFunc
+	l     logger.Logger
+}
+
+// NewModerationHandler admin 是管理员校验，审核相关的接口只有管理员能访问
+func NewModerationHandler(svc service.ModerationService, admin gin.HandlerFunc, l logger.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		svc:   svc,
+		admin: admin,
+		l:     l,
+	}
+}
+
+func (h *ModerationHandler) RegisterRoutes(server *gin.Engine) {
+	// 作者查看自己的审核记录，驳回的原因在这里
+	server.POST("/articles/reviews", h.AuthorList)
+
+	ag := server.Group("/admin/reviews", h.admin)
+	ag.POST("/list", h.PendingList) // 待审核队列
+	ag.POST("/approve", h.Approve)  // 审核通过，帖子自动发表
+	ag.POST("/reject", h.Reject)    // 驳回，需要填写原因
+}
+
+type ArticleReviewVO struct {
+	Id        int64    `json:"id"`
+	ArticleId int64    `json:"articleId"`
+	AuthorId  int64    `json:"authorId"`
+	Title     string   `json:"title"`
+	Hits      []string `json:"hits"`
+	// Status 1 待审核 2 通过 3 驳回 4 作废
+	Status uint8  `json:"status"`
+	Reason string `json:"reason"`
+	Ctime  string `json:"ctime"`
+	Utime  string `json:"utime"`
+}
+
+func (h *ModerationHandler) AuthorList(ctx *gin.Context) {
+	var req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc, ok := ctx.MustGet("claims").(*ijwt.UserClaims)
+	if !ok {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("获得用户会话信息失败")
+		return
+	}
+	res, err := h.svc.ListByAuthor(ctx, uc.Uid, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询审核记录失败", logger.Error(err), logger.Int64("uid", uc.Uid))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: h.toVOs(res),
+	})
+}
+
+func (h *ModerationHandler) PendingList(ctx *gin.Context) {
+	var req struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	res, err := h.svc.ListPending(ctx, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("查询待审核列表失败", logger.Error(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: h.toVOs(res),
+	})
+}
+
+func (h *ModerationHandler) Approve(ctx *gin.Context) {
+	var req struct {
+		Id int64 `json:"id"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	uc := ctx.MustGet("claims").(*ijwt.UserClaims)
+	err := h.svc.Approve(ctx, req.Id, uc.Uid)
+	h.reviewResult(ctx, err, req.Id)
+}
+
+func (h *ModerationHandler) Reject(ctx *gin.Context) {
+	var req struct {
+		Id     int64  `json:"id"`
+		Reason string `json:"reason"`
+	}
+	if err := ctx.ShouldBind(&req); err != nil {
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "请填写驳回原因",
+		})
+		return
+	}
+	uc := ctx.MustGet("claims").(*ijwt.UserClaims)
+	err := h.svc.Reject(ctx, req.Id, uc.Uid, req.Reason)
+	h.reviewResult(ctx, err, req.Id)
+}
+
+func (h *ModerationHandler) reviewResult(ctx *gin.Context, err error, id int64) {
+	switch err {
+	case nil:
+		ctx.JSON(http.StatusOK, Result{
+			Msg: "OK",
+		})
+	case service.ErrReviewNotFound, service.ErrReviewNotPending:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "审核单不存在或者已经处理",
+		})
+	case service.ErrReviewStale:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "作者已经修改了帖子，审核单作废",
+		})
+	default:
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("处理审核单失败", logger.Error(err), logger.Int64("reviewId", id))
+	}
+}
+
+func (h *ModerationHandler) toVOs(reviews []domain.ArticleReview) []ArticleReviewVO {
+	return slice.Map(reviews, func(idx int, src domain.ArticleReview) ArticleReviewVO {
+		return ArticleReviewVO{
+			Id:        src.Id,
+			ArticleId: src.ArticleId,
+			AuthorId:  src.AuthorId,
+			Title:     src.Title,
+			Hits:      src.Hits,
+			Status:    src.Status.ToUint8(),
+			Reason:    src.Reason,
+			Ctime:     src.Ctime.Format(time.DateTime),
+			Utime:     src.Utime.Format(time.DateTime),
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"red-feed/internal/domain"
+	ijwt "red-feed/internal/web/jwt"
+)
+
+const (
+	deviceIdHeader = "X-Device-Id"
+	deviceIdCookie = "did"
+)
+
+// readerOf 读者端的接口允许匿名访问，没登录的时候 Uid 为 0。
+// DeviceId 用来区分匿名读者，App 放在请求头里，浏览器放在 cookie 里
+func readerOf(ctx *gin.Context) domain.Reader {
+	r := domain.Reader{
+		Ip:        ctx.ClientIP(),
+		UserAgent: ctx.Request.UserAgent(),
+	}
+	if uc, ok := ctx.Get("claims"); ok {
+		if claims, ok := uc.(*ijwt.UserClaims); ok {
+			r.Uid = claims.Uid
+		}
+	}
+	r.DeviceId = ctx.GetHeader(deviceIdHeader)
+	if r.DeviceId != "" {
+		return r
+	}
+	did, err := ctx.Cookie(deviceIdCookie)
+	if err == nil && did != "" {
+		r.DeviceId = did
+		return r
+	}
+	// 第一次来的浏览器，种一个一年有效的 cookie
+	r.DeviceId = uuid.New().String()
+	ctx.SetCookie(deviceIdCookie, r.DeviceId, 365*24*3600, "/", "", false, true)
+	return r
+}
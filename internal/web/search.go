@@ -0,0 +1,92 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"red-feed/internal/domain"
+	"red-feed/internal/service"
+	"red-feed/pkg/logger"
+	"strings"
+	"time"
+)
+
+var _ Handler = (*SearchHandler)(nil)
+
+type SearchHandler struct {
+	svc service.SearchService
+	l   logger.Logger
+}
+
+func NewSearchHandler(svc service.SearchService, l logger.Logger) *SearchHandler {
+	return &SearchHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/search", h.Search) // 搜索公开发表的帖子，不需要登录
+}
+
+type SearchArticleVO struct {
+	Id int64 `json:"id"`
+	// Title 和 Snippet 里面命中的词用 <em> 包起来了
+	Title   string   `json:"title"`
+	Snippet string   `json:"snippet"`
+	Tags    []string `json:"tags"`
+	Author  string   `json:"author"`
+	Utime   string   `json:"utime"`
+}
+
+type SearchVO struct {
+	Total    int               `json:"total"`
+	Articles []SearchArticleVO `json:"articles"`
+}
+
+func (h *SearchHandler) Search(ctx *gin.Context) {
+	var req struct {
+		Q      string `form:"q"`
+		Offset int    `form:"offset"`
+		Limit  int    `form:"limit"`
+	}
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		return
+	}
+	req.Q = strings.TrimSpace(req.Q)
+	if req.Q == "" || req.Offset < 0 {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "参数错误",
+		})
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	res, err := h.svc.Search(ctx, req.Q, req.Offset, req.Limit)
+	if err != nil {
+		ctx.JSON(http.StatusOK, Result{
+			Code: 5,
+			Msg:  "系统错误",
+		})
+		h.l.Error("搜索失败", logger.Error(err), logger.String("q", req.Q))
+		return
+	}
+	ctx.JSON(http.StatusOK, Result{
+		Data: SearchVO{
+			Total: res.Total,
+			Articles: slice.Map[domain.SearchArticle, SearchArticleVO](res.Articles,
+				func(idx int, src domain.SearchArticle) SearchArticleVO {
+					return SearchArticleVO{
+						Id:      src.Id,
+						Title:   src.Title,
+						Snippet: src.Snippet,
+						Tags:    src.Tags,
+						Author:  src.Author.Name,
+						Utime:   src.Utime.Format(time.DateTime),
+					}
+				}),
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"os"
+	"red-feed/internal/repository"
+	"red-feed/internal/repository/cache"
+	"red-feed/internal/service"
+	"red-feed/pkg/logger"
+)
+
+// InitPreviewTokenService 密钥从环境变量 RED_FEED_PREVIEW_KEY 读。
+// 只有 env 是 dev 的时候才允许用配置文件里面的，线上没有配置环境变量就启动失败
+func InitPreviewTokenService() service.PreviewTokenService {
+	key := os.Getenv("RED_FEED_PREVIEW_KEY")
+	if key == "" && viper.GetString("env") == "dev" {
+		key = viper.GetString("article.preview.key")
+	}
+	if key == "" {
+		panic("没有配置预览链接的密钥 RED_FEED_PREVIEW_KEY")
+	}
+	return service.NewJWTPreviewTokenService([]byte(key))
+}
+
+// InitExportRepository 后台导出任务生成的压缩包放在本地目录
+func InitExportRepository(c cache.ExportTaskCache) repository.ExportRepository {
+	type Config struct {
+		Dir string `yaml:"dir"`
+	}
+	var cfg = Config{
+		Dir: "data/export",
+	}
+	err := viper.UnmarshalKey("article.export", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return repository.NewLocalExportRepository(c, cfg.Dir)
+}
+
+func InitFeedService(artSvc service.ArticleService, userSvc service.UserService,
+	repo repository.FeedRepository, l logger.Logger) service.FeedService {
+	type Config struct {
+		// Site 前端站点的地址，订阅源里面的链接都指向这里
+		Site  string `yaml:"site"`
+		Title string `yaml:"title"`
+	}
+	var cfg = Config{
+		Site:  "http://localhost:3000",
+		Title: "红书",
+	}
+	err := viper.UnmarshalKey("feed", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return service.NewFeedService(artSvc, userSvc, repo, cfg.Site, cfg.Title, l)
+}
@@ -0,0 +1,39 @@
+package ioc
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	domain2 "red-feed/interactive/domain"
+	"red-feed/internal/service"
+	"red-feed/pkg/saramax"
+)
+
+// InitReadBatchConfig 阅读事件批量消费一批的大小和等待时间
+func InitReadBatchConfig() saramax.BatchConfig {
+	cfg := saramax.DefaultBatchConfig
+	err := viper.UnmarshalKey("interactive.readBatch", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// InitBizRegistry 和 interactive 服务一样，没有配置 interactive.bizs 就只有 article。
+// 这里可以直接查帖子，所以点赞、收藏之前检查帖子是不是公开的
+func InitBizRegistry(artSvc service.ArticleService) *domain2.BizRegistry {
+	cfgs := []domain2.BizConfig{{Biz: domain2.BizArticle}}
+	if viper.IsSet("interactive.bizs") {
+		cfgs = nil
+		err := viper.UnmarshalKey("interactive.bizs", &cfgs)
+		if err != nil {
+			panic(err)
+		}
+	}
+	bizs := domain2.NewBizRegistry(cfgs...)
+	// 没有配置 article 的时候忽略
+	_ = bizs.SetExistChecker(domain2.BizArticle, func(ctx context.Context, bizId int64) (bool, error) {
+		arts, err := artSvc.GetPubByIds(ctx, []int64{bizId})
+		return len(arts) > 0, err
+	})
+	return bizs
+}
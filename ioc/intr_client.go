@@ -0,0 +1,81 @@
+package ioc
+
+import (
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	intrv1 "red-feed/api/proto/gen/intr/v1"
+	domain2 "red-feed/interactive/domain"
+	repository2 "red-feed/interactive/repository"
+	service2 "red-feed/interactive/service"
+	"red-feed/internal/client"
+	"red-feed/pkg/grpcx/balancer/wrr"
+	"red-feed/pkg/grpcx/interceptors/trace"
+	"red-feed/pkg/grpcx/registry"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+type intrClientConfig struct {
+	// Addr 没有配置 registry 的时候直接连这个地址
+	Addr string `yaml:"addr"`
+	// Threshold 走 gRPC 的流量百分比，0 全部走本地，100 全部走 interactive 服务
+	Threshold int32 `yaml:"threshold"`
+}
+
+func loadIntrClientConfig() (intrClientConfig, error) {
+	cfg := intrClientConfig{
+		Addr: "localhost:8090",
+	}
+	err := viper.UnmarshalKey("grpc.client.intr", &cfg)
+	return cfg, err
+}
+
+// InitIntrGRPCClient 不会马上建立连接，threshold 为 0 的时候 interactive 服务没有起来也没关系。
+// 配置了 registry 的时候从注册中心找 interactive 的实例，按照权重轮询
+func InitIntrGRPCClient(reg registry.Registry) intrv1.InteractiveServiceClient {
+	cfg, err := loadIntrClientConfig()
+	if err != nil {
+		panic(err)
+	}
+	target := cfg.Addr
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(trace.NewInterceptorBuilder().BuildClientUnaryInterceptor()),
+	}
+	if reg != nil {
+		target = registry.Scheme + ":///interactive"
+		opts = append(opts,
+			grpc.WithResolvers(registry.NewResolverBuilder(reg, time.Second)),
+			grpc.WithDefaultServiceConfig(wrr.ServiceConfig))
+	}
+	cc, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return intrv1.NewInteractiveServiceClient(cc)
+}
+
+// InitInteractiveService 本地的和 interactive 服务按照 grpc.client.intr.threshold 分流，改了配置不用重启
+func InitInteractiveService(repo repository2.InteractiveRepository, collection repository2.CollectionRepository,
+	stats repository2.StatsRepository, reactions domain2.Reactions, bizs *domain2.BizRegistry,
+	intrClient intrv1.InteractiveServiceClient, l logger.Logger) service2.InteractiveService {
+	cfg, err := loadIntrClientConfig()
+	if err != nil {
+		panic(err)
+	}
+	local := service2.NewInteractiveService(repo, collection, stats, reactions, bizs, l)
+	svc := client.NewSwitchInteractiveService(local, client.NewRemoteInteractiveService(intrClient), bizs, cfg.Threshold)
+	viper.OnConfigChange(func(in fsnotify.Event) {
+		cfg, err := loadIntrClientConfig()
+		if err != nil {
+			// 配置写错了就保持原来的
+			l.Error("读取 interactive 分流配置失败", logger.Error(err))
+			return
+		}
+		svc.UpdateThreshold(cfg.Threshold)
+		l.Info("interactive 分流配置更新", logger.Int32("threshold", cfg.Threshold))
+	})
+	return svc
+}
@@ -3,7 +3,9 @@ package ioc
 import (
 	"github.com/IBM/sarama"
 	"github.com/spf13/viper"
+	events2 "red-feed/interactive/events"
 	"red-feed/internal/events"
+	"red-feed/internal/events/article"
 )
 
 func InitKafka() sarama.Client {
@@ -33,6 +35,7 @@ func NewSyncProducer(client sarama.Client) sarama.SyncProducer {
 }
 
 // NewConsumers 面临的问题依旧是所有的 Consumer 在这里注册一下
-func NewConsumers(c1 events.Consumer) []events.Consumer {
-	return []events.Consumer{c1}
+func NewConsumers(c1 events.Consumer, deleteConsumer *events2.InteractiveDeleteEventConsumer,
+	searchConsumer *article.SearchConsumer, statsConsumer *events2.InteractiveStatsConsumer) []events.Consumer {
+	return []events.Consumer{c1, deleteConsumer, searchConsumer, statsConsumer}
 }
@@ -0,0 +1,48 @@
+package ioc
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"red-feed/internal/service"
+	"red-feed/internal/web"
+	"red-feed/internal/web/middleware"
+	"red-feed/pkg/logger"
+	"red-feed/pkg/sensitive"
+	"time"
+)
+
+// InitSensitiveDictionary 敏感词库放在文件里，运营改了文件之后不需要重启
+func InitSensitiveDictionary(l logger.Logger) *sensitive.Dictionary {
+	type Config struct {
+		Path string `yaml:"path"`
+		// Interval 检查文件是否变化的间隔，单位秒
+		Interval int `yaml:"interval"`
+	}
+	var cfg = Config{
+		Path:     "config/sensitive.txt",
+		Interval: 30,
+	}
+	err := viper.UnmarshalKey("moderation.dict", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	words, err := sensitive.LoadFile(cfg.Path)
+	if err != nil {
+		// 没有词库就相当于没有审核，不能启动
+		panic(err)
+	}
+	dict := sensitive.NewDictionary(words)
+	go dict.WatchFile(context.Background(), cfg.Path, time.Duration(cfg.Interval)*time.Second, func(err error) {
+		l.Error("加载敏感词库失败", logger.Error(err), logger.String("path", cfg.Path))
+	})
+	return dict
+}
+
+func InitModerationHandler(svc service.ModerationService, l logger.Logger) *web.ModerationHandler {
+	var admins []int64
+	err := viper.UnmarshalKey("moderation.admins", &admins)
+	if err != nil {
+		panic(err)
+	}
+	return web.NewModerationHandler(svc, middleware.NewAdminMiddlewareBuilder(admins).Build(), l)
+}
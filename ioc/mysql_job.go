@@ -9,15 +9,28 @@ import (
 	"time"
 )
 
+// InitScheduler 清理回收站只在这里调度，多个实例抢占同一条任务记录，只会有一个实例在跑
 func InitScheduler(l logger.Logger,
 	local *job.LocalFuncExecutor,
-	svc service.JobService) *job.Scheduler {
+	svc service.JobService,
+	purgeJob *job.ArticlePurgeJob) *job.Scheduler {
 	res := job.NewScheduler(svc, l)
 	res.RegisterExecutor(local)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	// 每天凌晨三点清理回收站
+	err := svc.AddJob(ctx, domain.Job{
+		Name:     purgeJob.Name(),
+		Executor: local.Name(),
+		Cron:     "0 3 * * *",
+	})
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
 
-func InitLocalFuncExecutor(svc service.RankingService) *job.LocalFuncExecutor {
+func InitLocalFuncExecutor(svc service.RankingService, purgeJob *job.ArticlePurgeJob) *job.LocalFuncExecutor {
 	res := job.NewLocalFuncExecutor()
 	// 要在数据库里面插入一条记录。
 	// ranking job 的记录，通过管理任务接口来插入
@@ -26,5 +39,10 @@ func InitLocalFuncExecutor(svc service.RankingService) *job.LocalFuncExecutor {
 		defer cancel()
 		return svc.TopN(ctx)
 	})
+	res.RegisterFunc(purgeJob.Name(), func(ctx context.Context, j domain.Job) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute*10)
+		defer cancel()
+		return purgeJob.Purge(ctx)
+	})
 	return res
 }
@@ -3,7 +3,9 @@ package ioc
 import (
 	rlock "github.com/gotomicro/redis-lock"
 	"github.com/robfig/cron/v3"
+	"red-feed/internal/events/article"
 	"red-feed/internal/job"
+	"red-feed/internal/repository"
 	"red-feed/internal/service"
 	"red-feed/pkg/logger"
 	"time"
@@ -15,7 +17,19 @@ func InitRankingJob(svc service.RankingService,
 	return job.NewRankingJob(svc, rlockClient, l, time.Second*30)
 }
 
-func InitJobs(l logger.Logger, rankingJob *job.RankingJob) *cron.Cron {
+func InitArticlePurgeJob(svc service.ArticleService, l logger.Logger) *job.ArticlePurgeJob {
+	return job.NewArticlePurgeJob(svc, l, time.Minute*10)
+}
+
+func InitArticleOutboxRelayJob(repo repository.ArticleEventRepository,
+	producer article.Producer) *job.ArticleOutboxRelayJob {
+	return job.NewArticleOutboxRelayJob(repo, producer, 100, time.Second*10)
+}
+
+// InitJobs 点赞、收藏、UV 这些 interactive 的定时任务只在 interactive 服务里面跑，
+// 这边再跑一遍会和它同时写同一批表。清理回收站在 InitScheduler 里面调度
+func InitJobs(l logger.Logger, rankingJob *job.RankingJob,
+	articleRelayJob *job.ArticleOutboxRelayJob) *cron.Cron {
 	res := cron.New(cron.WithSeconds())
 	cbd := job.NewCronJobBuilder(l)
 	// 这里每三分钟一次
@@ -23,5 +37,10 @@ func InitJobs(l logger.Logger, rankingJob *job.RankingJob) *cron.Cron {
 	if err != nil {
 		panic(err)
 	}
+	// 每五秒把帖子彻底删除的事件发出去
+	_, err = res.AddJob("*/5 * * * * ?", cbd.Build(articleRelayJob))
+	if err != nil {
+		panic(err)
+	}
 	return res
 }
@@ -0,0 +1,21 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"red-feed/pkg/grpcx/registry"
+	"red-feed/pkg/grpcx/registry/factory"
+)
+
+// InitRegistry 没有配置 registry 的时候返回 nil，interactive 的客户端直接连 grpc.client.intr.addr
+func InitRegistry() registry.Registry {
+	var cfg factory.Config
+	err := viper.UnmarshalKey("registry", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	r, err := factory.NewFromConfig(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
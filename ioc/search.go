@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/spf13/viper"
+	"red-feed/internal/events/article"
+	"red-feed/internal/repository"
+	"red-feed/internal/service"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// InitSearchService 启动的时候在后台从线上库重建索引，重建完成之前搜索结果是不全的
+func InitSearchService(repo repository.ArticleRepository, l logger.Logger) service.SearchService {
+	svc := service.NewIndexSearchService(repo, l)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*10)
+		defer cancel()
+		if err := svc.Rebuild(ctx); err != nil {
+			l.Error("重建搜索索引失败", logger.Error(err))
+		}
+	}()
+	return svc
+}
+
+// InitSearchConsumer 消费者组是固定的 search_index。
+// 索引在进程内，每个实例都要收到全部的消息，起多个实例的时候每个实例配置一个不同的 search.instance
+func InitSearchConsumer(client sarama.Client, svc service.SearchService, l logger.Logger) *article.SearchConsumer {
+	groupId := "search_index"
+	if instance := viper.GetString("search.instance"); instance != "" {
+		groupId = groupId + "_" + instance
+	}
+	return article.NewSearchConsumer(client, svc, groupId, l)
+}
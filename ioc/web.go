@@ -16,18 +16,36 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 func InitWebServer(mdls []gin.HandlerFunc,
 	userHdl *web.UserHandler,
 	oauth2WechatHdl *web.OAuth2WechatHandler,
-	artHdl *web.ArticleHandler) *gin.Engine {
+	artHdl *web.ArticleHandler,
+	searchHdl *web.SearchHandler,
+	moderationHdl *web.ModerationHandler,
+	archiveHdl *web.ArchiveHandler,
+	feedHdl *web.FeedHandler,
+	collectionHdl *web.CollectionHandler,
+	creatorHdl *web.CreatorHandler) *gin.Engine {
 	server := gin.Default()
+	// gin 默认信任所有代理，谁都能用 X-Forwarded-For 伪造 ClientIP 绕过限流和阅读防刷
+	err := server.SetTrustedProxies(viper.GetStringSlice("web.trustedProxies"))
+	if err != nil {
+		panic(err)
+	}
 	server.Use(mdls...)
 	userHdl.RegisterRoutes(server)
 	oauth2WechatHdl.RegisterRoutes(server)
 	artHdl.RegisterRoutes(server)
+	searchHdl.RegisterRoutes(server)
+	moderationHdl.RegisterRoutes(server)
+	archiveHdl.RegisterRoutes(server)
+	feedHdl.RegisterRoutes(server)
+	collectionHdl.RegisterRoutes(server)
+	creatorHdl.RegisterRoutes(server)
 	return server
 }
 
@@ -64,7 +82,14 @@ func InitMiddlewares(redisClient redis.Cmdable, jwtHdl ijwt.Handler, l ilogger.L
 			IgnorePaths("/oauth2/wechat/authurl").
 			IgnorePaths("/oauth2/wechat/callback").
 			IgnorePaths("/users/login_sms/code/send").
-			IgnorePaths("/users/login_sms").Build(),
+			IgnorePaths("/users/login_sms").
+			IgnorePaths("/articles/preview").
+			OptionalPaths("GET /articles/pub/:id").
+			OptionalPaths("POST /articles/pub/list").
+			OptionalPaths("POST /collections/user/list").
+			OptionalPaths("POST /collections/items").
+			IgnorePaths("/search").
+			IgnorePaths("/feeds/*path").Build(),
 	}
 }
 
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/viper"
@@ -41,6 +42,13 @@ func main() {
 
 	// 开启定时任务
 	app.cron.Start()
+	schedCtx, cancelSched := context.WithCancel(context.Background())
+	go func() {
+		err := app.scheduler.Schedule(schedCtx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			zap.L().Error("任务调度退出", zap.Error(err))
+		}
+	}()
 
 	server := app.web
 	server.GET("/", func(ctx *gin.Context) {
@@ -76,8 +84,9 @@ func main() {
 	}
 	zap.L().Info("Server exiting")
 
-	// 关闭定时任务
+	// 关闭定时任务，正在执行的数据库任务会收到 ctx 取消，下一次再接着跑
 	zap.L().Info("Cron shutting down...")
+	cancelSched()
 	ctx = app.cron.Stop()
 	// 这边可以考虑超时强制退出，防止有些任务，执行特别长的时间
 	tm := time.NewTimer(time.Minute * 10)
@@ -104,6 +113,8 @@ func initViper() {
 	if err != nil {
 		panic(err)
 	}
+	// grpc.client.intr.threshold 这种分流配置改了不用重启
+	viper.WatchConfig()
 }
 
 func initLogger() {
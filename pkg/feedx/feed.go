@@ -0,0 +1,187 @@
+// Package feedx 生成 RSS 2.0 和 Atom 1.0 格式的订阅源
+package feedx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	// Self 订阅源自己的地址，Atom 要求有，RSS 阅读器也会用来去重
+	Self    string
+	Updated time.Time
+	Items   []Item
+}
+
+type Item struct {
+	// Id 全局唯一并且不会变化，一般直接用帖子的链接
+	Id        string
+	Title     string
+	Link      string
+	Author    string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	AtomLink      rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title string  `xml:"title"`
+	Link  string  `xml:"link"`
+	Guid  rssGuid `xml:"guid"`
+	// RSS 的 author 要求是邮箱，作者名字放在 dc:creator 里面
+	Creator     string `xml:"dc:creator,omitempty"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+}
+
+type rssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS 生成 RSS 2.0
+func RSS(f Feed) ([]byte, error) {
+	doc := rss{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			AtomLink: rssAtomLink{
+				Href: f.Self,
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			LastBuildDate: rssTime(f.Updated),
+			Items:         make([]rssItem, 0, len(f.Items)),
+		},
+	}
+	for _, item := range f.Items {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title: item.Title,
+			Link:  item.Link,
+			Guid: rssGuid{
+				IsPermaLink: item.Id == item.Link,
+				Value:       item.Id,
+			},
+			Creator:     item.Author,
+			Description: item.Summary,
+			PubDate:     rssTime(item.Published),
+		})
+	}
+	return marshal(doc)
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Id       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	Id        string     `xml:"id"`
+	Link      atomLink   `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Author    atomPerson `xml:"author"`
+	Summary   atomText   `xml:"summary"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom 生成 Atom 1.0
+func Atom(f Feed) ([]byte, error) {
+	doc := atomFeed{
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Id:       f.Self,
+		Updated:  atomTime(f.Updated),
+		Links: []atomLink{
+			{Href: f.Link},
+			{Href: f.Self, Rel: "self", Type: "application/atom+xml"},
+		},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+	for _, item := range f.Items {
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:     item.Title,
+			Id:        item.Id,
+			Link:      atomLink{Href: item.Link},
+			Published: atomTime(item.Published),
+			Updated:   atomTime(item.Updated),
+			Author:    atomPerson{Name: item.Author},
+			Summary:   atomText{Type: "text", Value: item.Summary},
+		})
+	}
+	return marshal(doc)
+}
+
+func marshal(doc any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func rssTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
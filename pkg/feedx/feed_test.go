@@ -0,0 +1,79 @@
+package feedx
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeed = Feed{
+	Title:       "红书 & 朋友",
+	Link:        "https://example.com",
+	Description: "最新帖子",
+	Self:        "https://example.com/feeds/rss.xml",
+	Updated:     time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+	Items: []Item{
+		{
+			Id:        "https://example.com/articles/1",
+			Title:     "<Go> 并发",
+			Link:      "https://example.com/articles/1",
+			Author:    "大明",
+			Summary:   "摘要 a < b",
+			Published: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Updated:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+	},
+}
+
+func TestRSS(t *testing.T) {
+	data, err := RSS(testFeed)
+	require.NoError(t, err)
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title   string `xml:"title"`
+				Guid    string `xml:"guid"`
+				Creator string `xml:"http://purl.org/dc/elements/1.1/ creator"`
+				PubDate string `xml:"pubDate"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "红书 & 朋友", doc.Channel.Title)
+	assert.Equal(t, "Tue, 02 Jan 2024 15:04:05 +0000", doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 1)
+	assert.Equal(t, "<Go> 并发", doc.Channel.Items[0].Title)
+	assert.Equal(t, "https://example.com/articles/1", doc.Channel.Items[0].Guid)
+	assert.Equal(t, "大明", doc.Channel.Items[0].Creator)
+	assert.Equal(t, "Mon, 01 Jan 2024 00:00:00 +0000", doc.Channel.Items[0].PubDate)
+}
+
+func TestAtom(t *testing.T) {
+	data, err := Atom(testFeed)
+	require.NoError(t, err)
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		Id      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Entries []struct {
+			Title  string `xml:"title"`
+			Author struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Summary string `xml:"summary"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(data, &doc))
+	assert.Equal(t, "https://example.com/feeds/rss.xml", doc.Id)
+	assert.Equal(t, "2024-01-02T15:04:05Z", doc.Updated)
+	require.Len(t, doc.Entries, 1)
+	assert.Equal(t, "大明", doc.Entries[0].Author.Name)
+	assert.Equal(t, "摘要 a < b", doc.Entries[0].Summary)
+}
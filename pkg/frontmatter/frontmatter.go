@@ -0,0 +1,58 @@
+// Package frontmatter 处理 Markdown 文件开头用 --- 包起来的 YAML 元数据
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"gopkg.in/yaml.v3"
+)
+
+var delimiter = []byte("---")
+
+var ErrUnclosed = errors.New("front-matter 没有结束标记")
+
+// Parse 把元数据解析到 meta 里面，返回去掉元数据之后的正文。
+// 没有元数据的时候 meta 保持不变，正文就是原文。
+func Parse(data []byte, meta any) ([]byte, error) {
+	// 兼容 Windows 换行和 BOM
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	first, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok || !bytes.Equal(bytes.TrimSpace(first), delimiter) {
+		return data, nil
+	}
+	var head []byte
+	for {
+		var line []byte
+		line, rest, ok = bytes.Cut(rest, []byte("\n"))
+		if bytes.Equal(bytes.TrimSpace(line), delimiter) {
+			break
+		}
+		if !ok {
+			return nil, ErrUnclosed
+		}
+		head = append(head, line...)
+		head = append(head, '\n')
+	}
+	if err := yaml.Unmarshal(head, meta); err != nil {
+		return nil, err
+	}
+	return bytes.TrimLeft(rest, "\n"), nil
+}
+
+// Render 把元数据和正文拼成一个 Markdown 文件
+func Render(meta any, body []byte) ([]byte, error) {
+	head, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.Grow(len(head) + len(body) + 16)
+	buf.Write(delimiter)
+	buf.WriteByte('\n')
+	buf.Write(head)
+	buf.Write(delimiter)
+	buf.WriteString("\n\n")
+	buf.Write(body)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,65 @@
+package frontmatter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type meta struct {
+	Title   string    `yaml:"title"`
+	Tags    []string  `yaml:"tags"`
+	Created time.Time `yaml:"created"`
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		wantMeta meta
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name:     "有元数据",
+			data:     "---\r\ntitle: 你好\r\ntags: [Go, 并发]\r\ncreated: 2024-01-02T15:04:05Z\r\n---\r\n\r\n正文\r\n",
+			wantMeta: meta{Title: "你好", Tags: []string{"Go", "并发"}, Created: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+			wantBody: "正文\n",
+		},
+		{
+			name:     "没有元数据",
+			data:     "# 标题\n正文",
+			wantBody: "# 标题\n正文",
+		},
+		{
+			name:    "没有结束标记",
+			data:    "---\ntitle: 你好\n正文",
+			wantErr: ErrUnclosed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var m meta
+			body, err := Parse([]byte(tc.data), &m)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantMeta, m)
+			assert.Equal(t, tc.wantBody, string(body))
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	src := meta{Title: "标题: 带冒号", Tags: []string{"a"}, Created: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	data, err := Render(src, []byte("正文"))
+	require.NoError(t, err)
+	var m meta
+	body, err := Parse(data, &m)
+	require.NoError(t, err)
+	assert.Equal(t, src, m)
+	assert.Equal(t, "正文", string(body))
+}
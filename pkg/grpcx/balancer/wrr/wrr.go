@@ -0,0 +1,84 @@
+package wrr
+
+import (
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+	"sync"
+)
+
+// Name 客户端在 service config 里面用这个名字，
+// 不能用 weighted_round_robin，gRPC 自己的那个已经注册了
+const Name = "custom_weighted_round_robin"
+
+// ServiceConfig 用这个负载均衡的默认 service config
+const ServiceConfig = `{"loadBalancingConfig": [{"` + Name + `": {}}]}`
+
+// defaultWeight 没有带权重的地址按照 1 算，也就是退化成普通的轮询
+const defaultWeight = 1
+
+func init() {
+	balancer.Register(base.NewBalancerBuilder(Name, &PickerBuilder{}, base.Config{HealthCheck: true}))
+}
+
+type weightKey struct{}
+
+// SetWeight 把权重带在地址上面，resolver 解析出地址的时候调用。
+// 权重放在 Attributes 里面，改了权重会重新建连接
+func SetWeight(addr resolver.Address, weight int32) resolver.Address {
+	addr.Attributes = addr.Attributes.WithValue(weightKey{}, weight)
+	return addr
+}
+
+func getWeight(addr resolver.Address) int {
+	weight, ok := addr.Attributes.Value(weightKey{}).(int32)
+	if !ok || weight <= 0 {
+		return defaultWeight
+	}
+	return int(weight)
+}
+
+type PickerBuilder struct {
+}
+
+func (b *PickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+	conns := make([]*weightConn, 0, len(info.ReadySCs))
+	for sc, sci := range info.ReadySCs {
+		conns = append(conns, &weightConn{
+			SubConn: sc,
+			weight:  getWeight(sci.Address),
+		})
+	}
+	return &Picker{conns: conns}
+}
+
+// Picker 平滑的加权轮询，和 nginx 的一样，权重大的不会连续被选中
+type Picker struct {
+	mu    sync.Mutex
+	conns []*weightConn
+}
+
+func (p *Picker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var total int
+	var picked *weightConn
+	for _, c := range p.conns {
+		total += c.weight
+		c.currentWeight += c.weight
+		if picked == nil || c.currentWeight > picked.currentWeight {
+			picked = c
+		}
+	}
+	picked.currentWeight -= total
+	return balancer.PickResult{SubConn: picked.SubConn}, nil
+}
+
+type weightConn struct {
+	balancer.SubConn
+	weight        int
+	currentWeight int
+}
@@ -0,0 +1,81 @@
+package wrr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/resolver"
+	"testing"
+)
+
+type fakeSubConn struct {
+	balancer.SubConn
+	addr string
+}
+
+func TestPicker_Pick(t *testing.T) {
+	testCases := []struct {
+		name    string
+		weights map[string]int32
+		// picks 选多少次
+		picks    int
+		wantCnts map[string]int
+		// wantMaxRun 同一个实例最多连续选中几次
+		wantMaxRun int
+	}{
+		{
+			name:       "按照权重分配",
+			weights:    map[string]int32{"a": 5, "b": 1, "c": 1},
+			picks:      7,
+			wantCnts:   map[string]int{"a": 5, "b": 1, "c": 1},
+			wantMaxRun: 2,
+		},
+		{
+			name:       "没有权重就是轮询",
+			weights:    map[string]int32{"a": 0, "b": 0},
+			picks:      4,
+			wantCnts:   map[string]int{"a": 2, "b": 2},
+			wantMaxRun: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			info := base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{}}
+			for addr, w := range tc.weights {
+				sc := &fakeSubConn{addr: addr}
+				a := resolver.Address{Addr: addr}
+				if w > 0 {
+					a = SetWeight(a, w)
+				}
+				info.ReadySCs[sc] = base.SubConnInfo{Address: a}
+			}
+			picker := (&PickerBuilder{}).Build(info)
+			cnts := make(map[string]int)
+			var last string
+			var run, maxRun int
+			for i := 0; i < tc.picks; i++ {
+				res, err := picker.Pick(balancer.PickInfo{})
+				require.NoError(t, err)
+				addr := res.SubConn.(*fakeSubConn).addr
+				cnts[addr]++
+				if addr == last {
+					run++
+				} else {
+					run = 1
+				}
+				last = addr
+				maxRun = max(maxRun, run)
+			}
+			assert.Equal(t, tc.wantCnts, cnts)
+			// 平滑的加权轮询，权重大的也不会一直连续选中
+			assert.LessOrEqual(t, maxRun, tc.wantMaxRun)
+		})
+	}
+}
+
+func TestPickerBuilder_NoReady(t *testing.T) {
+	picker := (&PickerBuilder{}).Build(base.PickerBuildInfo{})
+	_, err := picker.Pick(balancer.PickInfo{})
+	assert.Equal(t, balancer.ErrNoSubConnAvailable, err)
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"red-feed/pkg/logger"
+	"time"
+)
+
+// InterceptorBuilder 每个请求打一条日志，服务端的错误用 Error，调用方的错误用 Warn
+type InterceptorBuilder struct {
+	l logger.Logger
+}
+
+func NewInterceptorBuilder(l logger.Logger) *InterceptorBuilder {
+	return &InterceptorBuilder{l: l}
+}
+
+func (b *InterceptorBuilder) BuildServerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		st, _ := status.FromError(err)
+		fields := []logger.Field{
+			logger.String("method", info.FullMethod),
+			logger.String("code", st.Code().String()),
+			logger.String("duration", time.Since(start).String()),
+			logger.String("peer", b.peer(ctx)),
+		}
+		switch st.Code() {
+		case codes.OK:
+			b.l.Debug("gRPC 请求", fields...)
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+			b.l.Error("gRPC 请求", append(fields, logger.Error(err))...)
+		default:
+			b.l.Warn("gRPC 请求", append(fields, logger.Error(err))...)
+		}
+		return resp, err
+	}
+}
+
+func (b *InterceptorBuilder) peer(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	return p.Addr.String()
+}
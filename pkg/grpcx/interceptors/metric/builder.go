@@ -0,0 +1,70 @@
+package metric
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"strings"
+	"time"
+)
+
+// InterceptorBuilder 和 ginx 的 metric 一样，统计响应时间和正在处理的请求数
+type InterceptorBuilder struct {
+	Namespace  string
+	Subsystem  string
+	Name       string
+	Help       string
+	InstanceID string
+}
+
+func (b *InterceptorBuilder) BuildServerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	labels := []string{"service", "method", "code"}
+	summary := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: b.Namespace,
+		Subsystem: b.Subsystem,
+		Name:      b.Name + "_resp_time",
+		Help:      b.Help,
+		ConstLabels: map[string]string{
+			"instance_id": b.InstanceID,
+		},
+		Objectives: map[float64]float64{
+			0.5:   0.01,
+			0.9:   0.01,
+			0.99:  0.005,
+			0.999: 0.0001,
+		},
+	}, labels)
+	prometheus.MustRegister(summary)
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: b.Namespace,
+		Subsystem: b.Subsystem,
+		Name:      b.Name + "_active_req",
+		Help:      b.Help,
+		ConstLabels: map[string]string{
+			"instance_id": b.InstanceID,
+		},
+	})
+	prometheus.MustRegister(gauge)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp any, err error) {
+		start := time.Now()
+		gauge.Inc()
+		defer func() {
+			gauge.Dec()
+			service, method := splitMethod(info.FullMethod)
+			summary.WithLabelValues(service, method, status.Code(err).String()).
+				Observe(float64(time.Since(start).Milliseconds()))
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// splitMethod /intr.v1.InteractiveService/Get 拆成 intr.v1.InteractiveService 和 Get
+func splitMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
@@ -0,0 +1,35 @@
+package recovery
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"red-feed/pkg/logger"
+	"runtime/debug"
+)
+
+// InterceptorBuilder 业务里面 panic 了不能把整个服务带崩，转成 Internal 返回
+type InterceptorBuilder struct {
+	l logger.Logger
+}
+
+func NewInterceptorBuilder(l logger.Logger) *InterceptorBuilder {
+	return &InterceptorBuilder{l: l}
+}
+
+func (b *InterceptorBuilder) BuildServerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				b.l.Error("gRPC 请求 panic",
+					logger.String("method", info.FullMethod),
+					logger.Field{Key: "panic", Value: r},
+					logger.String("stack", string(debug.Stack())))
+				err = status.Error(codes.Internal, "系统错误")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,22 @@
+package recovery
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"red-feed/pkg/logger"
+	"testing"
+)
+
+func TestInterceptorBuilder_BuildServerUnaryInterceptor(t *testing.T) {
+	interceptor := NewInterceptorBuilder(&logger.NopLogger{}).BuildServerUnaryInterceptor()
+	resp, err := interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/intr.v1.InteractiveService/Get"},
+		func(ctx context.Context, req any) (any, error) {
+			panic("nil map")
+		})
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,50 @@
+package timeout
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"strings"
+	"time"
+)
+
+// InterceptorBuilder 给每个请求加上超时，调用方给的超时更短的时候用调用方的
+type InterceptorBuilder struct {
+	defaultTimeout time.Duration
+	// methods 方法名，不带服务名，例如 GetStats
+	methods map[string]time.Duration
+}
+
+func NewInterceptorBuilder(defaultTimeout time.Duration) *InterceptorBuilder {
+	return &InterceptorBuilder{
+		defaultTimeout: defaultTimeout,
+		methods:        make(map[string]time.Duration),
+	}
+}
+
+// Method 单独设置某个方法的超时，小于等于 0 就是不限制
+func (b *InterceptorBuilder) Method(method string, timeout time.Duration) *InterceptorBuilder {
+	b.methods[method] = timeout
+	return b
+}
+
+func (b *InterceptorBuilder) BuildServerUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (any, error) {
+		timeout := b.timeout(info.FullMethod)
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+		// 父 ctx 的超时更短的时候 WithTimeout 会用父 ctx 的
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+func (b *InterceptorBuilder) timeout(fullMethod string) time.Duration {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if t, ok := b.methods[method]; ok {
+		return t
+	}
+	return b.defaultTimeout
+}
@@ -0,0 +1,69 @@
+package timeout
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"testing"
+	"time"
+)
+
+func TestInterceptorBuilder_BuildServerUnaryInterceptor(t *testing.T) {
+	testCases := []struct {
+		name   string
+		method string
+		// parent 调用方给的超时，0 就是没有
+		parent      time.Duration
+		wantTimeout time.Duration
+		wantNoLimit bool
+	}{
+		{
+			name:        "默认的超时",
+			method:      "/intr.v1.InteractiveService/Get",
+			wantTimeout: time.Second,
+		},
+		{
+			name:        "单独设置的超时",
+			method:      "/intr.v1.InteractiveService/GetStats",
+			wantTimeout: time.Second * 3,
+		},
+		{
+			name:        "调用方的超时更短",
+			method:      "/intr.v1.InteractiveService/GetStats",
+			parent:      time.Millisecond * 100,
+			wantTimeout: time.Millisecond * 100,
+		},
+		{
+			name:        "不限制",
+			method:      "/intr.v1.InteractiveService/Export",
+			wantNoLimit: true,
+		},
+	}
+	interceptor := NewInterceptorBuilder(time.Second).
+		Method("GetStats", time.Second*3).
+		Method("Export", 0).
+		BuildServerUnaryInterceptor()
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.parent > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.parent)
+				defer cancel()
+			}
+			start := time.Now()
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method},
+				func(ctx context.Context, req any) (any, error) {
+					deadline, ok := ctx.Deadline()
+					if tc.wantNoLimit {
+						assert.False(t, ok)
+						return nil, nil
+					}
+					assert.True(t, ok)
+					assert.InDelta(t, tc.wantTimeout, deadline.Sub(start), float64(time.Millisecond*50))
+					return nil, nil
+				})
+			assert.NoError(t, err)
+		})
+	}
+}
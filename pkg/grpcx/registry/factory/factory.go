@@ -0,0 +1,34 @@
+package factory
+
+import (
+	"fmt"
+	"red-feed/pkg/grpcx/registry"
+	"red-feed/pkg/grpcx/registry/file"
+	"red-feed/pkg/grpcx/registry/memory"
+)
+
+// Config 服务端和客户端用同一份配置，才能找到对方
+type Config struct {
+	// Type file 或者 memory，不填就是不用注册中心
+	Type string `yaml:"type"`
+	// Path file 用的文件，客户端要配置同一个文件
+	Path string `yaml:"path"`
+}
+
+// NewFromConfig Type 为空的时候返回 nil，调用方自己决定不注册或者直连
+func NewFromConfig(cfg Config) (registry.Registry, error) {
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case "memory":
+		return memory.NewRegistry(), nil
+	case "file":
+		r, err := file.NewRegistry(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("不支持的注册中心 %s", cfg.Type)
+	}
+}
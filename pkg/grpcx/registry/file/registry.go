@@ -0,0 +1,170 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/fsnotify/fsnotify"
+	"os"
+	"path/filepath"
+	"red-feed/pkg/grpcx/registry"
+	"sort"
+	"sync"
+)
+
+var _ registry.Registry = (*Registry)(nil)
+
+// Registry 所有实例写在同一个 JSON 文件里面，同一台机器上的多个进程共用一个文件，
+// 本地开发和单机起多个副本的时候用。
+// 只在进程内加锁，多个进程同时注册的时候有可能覆盖掉别人的，上线要换成 etcd 之类的实现
+type Registry struct {
+	path    string
+	mu      sync.Mutex
+	watcher *fsnotify.Watcher
+	subs    *registry.Subscribers
+}
+
+// NewRegistry 监听文件所在的目录，别的进程改了文件也能收到通知
+func NewRegistry(path string) (*Registry, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// 写文件是先写临时文件再改名的，监听文件本身的话改名之后就收不到了
+	err = watcher.Add(filepath.Dir(path))
+	if err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+	r := &Registry{
+		path:    path,
+		watcher: watcher,
+		subs:    registry.NewSubscribers(),
+	}
+	go r.watch()
+	return r, nil
+}
+
+func (r *Registry) Register(ctx context.Context, si registry.ServiceInstance) error {
+	return r.update(func(sis []registry.ServiceInstance) []registry.ServiceInstance {
+		sis = remove(sis, si)
+		return append(sis, si)
+	})
+}
+
+func (r *Registry) UnRegister(ctx context.Context, si registry.ServiceInstance) error {
+	return r.update(func(sis []registry.ServiceInstance) []registry.ServiceInstance {
+		return remove(sis, si)
+	})
+}
+
+func (r *Registry) ListServices(ctx context.Context, name string) ([]registry.ServiceInstance, error) {
+	sis, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]registry.ServiceInstance, 0, len(sis))
+	for _, si := range sis {
+		if si.Name == name {
+			res = append(res, si)
+		}
+	}
+	return res, nil
+}
+
+func (r *Registry) Subscribe(ctx context.Context, name string) (<-chan struct{}, error) {
+	return r.subs.Add(ctx, name)
+}
+
+func (r *Registry) Close() error {
+	r.subs.Close()
+	return r.watcher.Close()
+}
+
+func (r *Registry) update(fn func(sis []registry.ServiceInstance) []registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sis, err := r.load()
+	if err != nil {
+		return err
+	}
+	sis = fn(sis)
+	sort.Slice(sis, func(i, j int) bool {
+		if sis[i].Name != sis[j].Name {
+			return sis[i].Name < sis[j].Name
+		}
+		return sis[i].Addr < sis[j].Addr
+	})
+	data, err := json.MarshalIndent(sis, "", "  ")
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再改名，别人不会读到写了一半的文件
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), filepath.Base(r.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err1 := tmp.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
+
+// load 文件不存在当作没有实例
+func (r *Registry) load() ([]registry.ServiceInstance, error) {
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sis []registry.ServiceInstance
+	if len(data) == 0 {
+		return sis, nil
+	}
+	err = json.Unmarshal(data, &sis)
+	return sis, err
+}
+
+// watch 文件里面没有区分是哪个服务变了，统一通知所有的订阅者
+func (r *Registry) watch() {
+	for {
+		select {
+		case evt, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if evt.Name == r.path {
+				r.subs.NotifyAll()
+			}
+		case _, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func remove(sis []registry.ServiceInstance, si registry.ServiceInstance) []registry.ServiceInstance {
+	res := sis[:0]
+	for _, v := range sis {
+		if v.Name != si.Name || v.Addr != si.Addr {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package file
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"red-feed/pkg/grpcx/registry"
+	"testing"
+	"time"
+)
+
+// TestRegistry 两个 Registry 用同一个文件，相当于两个进程
+func TestRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	server, err := NewRegistry(path)
+	require.NoError(t, err)
+	defer server.Close()
+	client, err := NewRegistry(path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := client.Subscribe(ctx, "interactive")
+	require.NoError(t, err)
+
+	si := registry.ServiceInstance{Name: "interactive", Addr: "localhost:8090", Weight: 10}
+	require.NoError(t, server.Register(context.Background(), si))
+	waitNotify(t, ch)
+	sis, err := client.ListServices(context.Background(), "interactive")
+	require.NoError(t, err)
+	assert.Equal(t, []registry.ServiceInstance{si}, sis)
+
+	// 重复注册就是更新权重
+	si.Weight = 20
+	require.NoError(t, server.Register(context.Background(), si))
+	sis, err = client.ListServices(context.Background(), "interactive")
+	require.NoError(t, err)
+	assert.Equal(t, []registry.ServiceInstance{si}, sis)
+
+	require.NoError(t, server.UnRegister(context.Background(), si))
+	sis, err = client.ListServices(context.Background(), "interactive")
+	require.NoError(t, err)
+	assert.Empty(t, sis)
+
+	// 取消订阅之后 channel 会关掉
+	cancel()
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-ch:
+			return !ok
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond*10)
+}
+
+func waitNotify(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(time.Second * 3):
+		t.Fatal("没有收到通知")
+	}
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"red-feed/pkg/grpcx/registry"
+	"sort"
+	"sync"
+)
+
+var _ registry.Registry = (*Registry)(nil)
+
+// Registry 实例放在内存里面，只有同一个进程里面能发现，测试和单体部署的时候用
+type Registry struct {
+	mu sync.RWMutex
+	// services 服务名 => 地址 => 实例
+	services map[string]map[string]registry.ServiceInstance
+	subs     *registry.Subscribers
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		services: make(map[string]map[string]registry.ServiceInstance),
+		subs:     registry.NewSubscribers(),
+	}
+}
+
+func (r *Registry) Register(ctx context.Context, si registry.ServiceInstance) error {
+	r.mu.Lock()
+	if r.services[si.Name] == nil {
+		r.services[si.Name] = make(map[string]registry.ServiceInstance)
+	}
+	r.services[si.Name][si.Addr] = si
+	r.mu.Unlock()
+	r.subs.Notify(si.Name)
+	return nil
+}
+
+func (r *Registry) UnRegister(ctx context.Context, si registry.ServiceInstance) error {
+	r.mu.Lock()
+	delete(r.services[si.Name], si.Addr)
+	r.mu.Unlock()
+	r.subs.Notify(si.Name)
+	return nil
+}
+
+func (r *Registry) ListServices(ctx context.Context, name string) ([]registry.ServiceInstance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	res := make([]registry.ServiceInstance, 0, len(r.services[name]))
+	for _, si := range r.services[name] {
+		res = append(res, si)
+	}
+	// 顺序固定下来，方便比较
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Addr < res[j].Addr
+	})
+	return res, nil
+}
+
+func (r *Registry) Subscribe(ctx context.Context, name string) (<-chan struct{}, error) {
+	return r.subs.Add(ctx, name)
+}
+
+func (r *Registry) Close() error {
+	r.subs.Close()
+	return nil
+}
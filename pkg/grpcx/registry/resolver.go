@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"context"
+	"google.golang.org/grpc/resolver"
+	"red-feed/pkg/grpcx/balancer/wrr"
+	"sync"
+	"time"
+)
+
+// Scheme 客户端按照 registry:///服务名 连
+const Scheme = "registry"
+
+type ResolverBuilder struct {
+	r Registry
+	// timeout 每次查询注册中心的超时
+	timeout time.Duration
+}
+
+func NewResolverBuilder(r Registry, timeout time.Duration) *ResolverBuilder {
+	return &ResolverBuilder{r: r, timeout: timeout}
+}
+
+func (b *ResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn,
+	opts resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	res := &grpcResolver{
+		name:    target.Endpoint(),
+		r:       b.r,
+		cc:      cc,
+		timeout: b.timeout,
+		cancel:  cancel,
+	}
+	ch, err := b.r.Subscribe(ctx, res.name)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.resolve()
+	go res.watch(ch)
+	return res, nil
+}
+
+func (b *ResolverBuilder) Scheme() string {
+	return Scheme
+}
+
+type grpcResolver struct {
+	name    string
+	r       Registry
+	cc      resolver.ClientConn
+	timeout time.Duration
+	cancel  context.CancelFunc
+	// mu 通知和 ResolveNow 可能同时来，避免旧的结果覆盖新的
+	mu sync.Mutex
+}
+
+func (g *grpcResolver) ResolveNow(options resolver.ResolveNowOptions) {
+	g.resolve()
+}
+
+func (g *grpcResolver) Close() {
+	g.cancel()
+}
+
+func (g *grpcResolver) watch(ch <-chan struct{}) {
+	for range ch {
+		g.resolve()
+	}
+}
+
+func (g *grpcResolver) resolve() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	sis, err := g.r.ListServices(ctx, g.name)
+	if err != nil {
+		g.cc.ReportError(err)
+		return
+	}
+	addrs := make([]resolver.Address, 0, len(sis))
+	for _, si := range sis {
+		weight := si.Weight
+		if weight <= 0 {
+			weight = DefaultWeight
+		}
+		addrs = append(addrs, wrr.SetWeight(resolver.Address{Addr: si.Addr}, weight))
+	}
+	// 没有实例的时候 balancer 会返回错误，等下一次通知就好
+	_ = g.cc.UpdateState(resolver.State{Addresses: addrs})
+}
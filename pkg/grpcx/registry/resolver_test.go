@@ -0,0 +1,65 @@
+package registry_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+	"net/url"
+	"red-feed/pkg/grpcx/registry"
+	"red-feed/pkg/grpcx/registry/memory"
+	"testing"
+	"time"
+)
+
+type fakeClientConn struct {
+	resolver.ClientConn
+	states chan resolver.State
+}
+
+func (f *fakeClientConn) UpdateState(state resolver.State) error {
+	f.states <- state
+	return nil
+}
+
+func (f *fakeClientConn) ReportError(err error) {
+}
+
+func TestResolver(t *testing.T) {
+	reg := memory.NewRegistry()
+	defer reg.Close()
+	ctx := context.Background()
+	require.NoError(t, reg.Register(ctx, registry.ServiceInstance{Name: "interactive", Addr: "localhost:8090", Weight: 10}))
+	// 别的服务的实例不会混进来
+	require.NoError(t, reg.Register(ctx, registry.ServiceInstance{Name: "user", Addr: "localhost:8091"}))
+
+	cc := &fakeClientConn{states: make(chan resolver.State, 10)}
+	res, err := registry.NewResolverBuilder(reg, time.Second).Build(resolver.Target{
+		URL: url.URL{Scheme: registry.Scheme, Path: "/interactive"},
+	}, cc, resolver.BuildOptions{})
+	require.NoError(t, err)
+	defer res.Close()
+	assert.Equal(t, []string{"localhost:8090"}, addrs(t, cc))
+
+	// 新的实例注册上来
+	require.NoError(t, reg.Register(ctx, registry.ServiceInstance{Name: "interactive", Addr: "localhost:8092"}))
+	assert.Equal(t, []string{"localhost:8090", "localhost:8092"}, addrs(t, cc))
+
+	// 实例退出的时候注销
+	require.NoError(t, reg.UnRegister(ctx, registry.ServiceInstance{Name: "interactive", Addr: "localhost:8090"}))
+	assert.Equal(t, []string{"localhost:8092"}, addrs(t, cc))
+}
+
+func addrs(t *testing.T, cc *fakeClientConn) []string {
+	select {
+	case state := <-cc.states:
+		res := make([]string, 0, len(state.Addresses))
+		for _, addr := range state.Addresses {
+			res = append(res, addr.Addr)
+		}
+		return res
+	case <-time.After(time.Second):
+		t.Fatal("没有收到地址的更新")
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"sync"
+)
+
+// Subscribers 按照服务名管理订阅者，给 Registry 的实现复用
+type Subscribers struct {
+	mu     sync.Mutex
+	subs   map[string]map[chan struct{}]struct{}
+	closed bool
+	done   chan struct{}
+}
+
+func NewSubscribers() *Subscribers {
+	return &Subscribers{
+		subs: make(map[string]map[chan struct{}]struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (s *Subscribers) Add(ctx context.Context, name string) (<-chan struct{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	// 缓冲一个就够了，没来得及处理的通知合并成一个
+	ch := make(chan struct{}, 1)
+	if s.subs[name] == nil {
+		s.subs[name] = make(map[chan struct{}]struct{})
+	}
+	s.subs[name][ch] = struct{}{}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-s.done:
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subs[name][ch]; ok {
+			delete(s.subs[name], ch)
+			close(ch)
+		}
+	}()
+	return ch, nil
+}
+
+// Notify 不会阻塞，订阅者还没处理上一个通知的时候直接跳过
+func (s *Subscribers) Notify(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs[name] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// NotifyAll 不知道是哪个服务变了的时候用
+func (s *Subscribers) NotifyAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, chs := range s.subs {
+		for ch := range chs {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (s *Subscribers) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.done)
+	for _, chs := range s.subs {
+		for ch := range chs {
+			close(ch)
+		}
+	}
+	s.subs = nil
+}
@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// DefaultWeight 没有配置权重的实例按照这个算
+const DefaultWeight int32 = 100
+
+var ErrClosed = errors.New("注册中心已经关闭")
+
+// ServiceInstance 一个服务实例
+type ServiceInstance struct {
+	Name string `json:"name"`
+	// Addr 客户端连的地址
+	Addr string `json:"addr"`
+	// Weight 负载均衡按照权重分配流量
+	Weight int32 `json:"weight"`
+}
+
+// Registry 服务注册和发现，etcd、consul 之类的实现这个接口就可以接进来
+type Registry interface {
+	// Register 同一个服务同一个地址重复注册就是更新
+	Register(ctx context.Context, si ServiceInstance) error
+	UnRegister(ctx context.Context, si ServiceInstance) error
+	ListServices(ctx context.Context, name string) ([]ServiceInstance, error)
+	// Subscribe 服务的实例有变化的时候通知一下，具体是什么变化调用方自己 ListServices。
+	// 连续的变化可能只通知一次，ctx 结束或者 Registry 关闭之后 channel 会被关掉
+	Subscribe(ctx context.Context, name string) (<-chan struct{}, error)
+	io.Closer
+}
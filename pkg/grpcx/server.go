@@ -1,20 +1,106 @@
 package grpcx
 
 import (
+	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"net"
+	"red-feed/pkg/grpcx/registry"
+	"time"
 )
 
+// DefaultDrainTimeout 优雅退出的时候最多等正在处理的请求多久
+const DefaultDrainTimeout = time.Second * 10
+
+// DefaultDeregisterDelay 客户端每秒拉一次注册中心，多等一会儿它们才能都看到注销
+const DefaultDeregisterDelay = time.Second * 3
+
 type Server struct {
 	*grpc.Server
 	Addr string
+	// DrainTimeout 优雅退出最多等多久，超时之后直接断开
+	DrainTimeout time.Duration
+	// DeregisterDelay 注销之后先等这么久再停止接收请求，这段时间还能正常处理新请求
+	DeregisterDelay time.Duration
+	// Registry 不为空的时候，开始监听之后把 Instance 注册上去，退出的时候先注销
+	Registry registry.Registry
+	// Instance Addr 不填的话用监听的地址，只适合本机访问
+	Instance registry.ServiceInstance
+	health   *health.Server
+}
+
+// NewServer 顺便注册标准的健康检查和反射服务，grpcurl 之类的工具可以直接用
+func NewServer(server *grpc.Server, addr string, drainTimeout time.Duration) *Server {
+	if drainTimeout <= 0 {
+		drainTimeout = DefaultDrainTimeout
+	}
+	hs := health.NewServer()
+	// 开始监听之前都不接流量
+	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	grpc_health_v1.RegisterHealthServer(server, hs)
+	reflection.Register(server)
+	return &Server{
+		Server:       server,
+		Addr:         addr,
+		DrainTimeout: drainTimeout,
+		health:       hs,
+	}
 }
 
 func (s *Server) Serve() error {
-	l, err := net.Listen("tcp", ":8090")
+	l, err := net.Listen("tcp", s.Addr)
 	if err != nil {
 		return err
 	}
+	return s.ServeListener(l)
+}
+
+// ServeListener 用已经监听好的 l，Addr 不起作用。测试的时候可以监听 :0 用随机端口
+func (s *Server) ServeListener(l net.Listener) error {
+	if s.Registry != nil {
+		if s.Instance.Addr == "" {
+			s.Instance.Addr = l.Addr().String()
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := s.Registry.Register(ctx, s.Instance)
+		cancel()
+		if err != nil {
+			_ = l.Close()
+			return err
+		}
+	}
+	s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 	// 这边会阻塞，类似与 gin.Run
 	return s.Server.Serve(l)
 }
+
+// Shutdown 先从注册中心注销，再把健康检查改成 NOT_SERVING，让负载均衡不再转发新的请求，
+// 等 DeregisterDelay 让客户端和负载均衡都看到之后，再等正在处理的请求处理完，超过 DrainTimeout 就直接断开。
+// 注销失败也会继续关闭，返回的是注销的错误
+func (s *Server) Shutdown() error {
+	var err error
+	if s.Registry != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err = s.Registry.UnRegister(ctx, s.Instance)
+		cancel()
+	}
+	s.health.Shutdown()
+	if s.DeregisterDelay > 0 {
+		time.Sleep(s.DeregisterDelay)
+	}
+	done := make(chan struct{})
+	go func() {
+		s.Server.GracefulStop()
+		close(done)
+	}()
+	timer := time.NewTimer(s.DrainTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		s.Server.Stop()
+	}
+	return err
+}
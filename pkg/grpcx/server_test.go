@@ -0,0 +1,63 @@
+package grpcx
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestServer_Shutdown(t *testing.T) {
+	// 随机端口，不会和别的测试或者本机的服务冲突
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := NewServer(grpc.NewServer(), "", time.Millisecond*200)
+	server.DeregisterDelay = time.Millisecond * 300
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ServeListener(l)
+	}()
+
+	cc, err := grpc.NewClient(l.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer cc.Close()
+	client := grpc_health_v1.NewHealthClient(cc)
+	assert.Eventually(t, func() bool {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		return err == nil && resp.GetStatus() == grpc_health_v1.HealthCheckResponse_SERVING
+	}, time.Second*3, time.Millisecond*50)
+
+	// Watch 是一个一直不结束的流，GracefulStop 会一直等它，只能靠超时强制关闭
+	stream, err := client.Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	start := time.Now()
+	go func() {
+		_ = server.Shutdown()
+	}()
+	// 先通知不要再转发请求过来
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	// 等 DeregisterDelay 的时候还没停，还没看到注销的客户端发过来的请求照样处理
+	checkResp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, checkResp.GetStatus())
+
+	select {
+	case err = <-serveErr:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 3):
+		t.Fatal("超过 DrainTimeout 没有关闭")
+	}
+	assert.True(t, time.Since(start) >= time.Millisecond*500)
+}
@@ -27,3 +27,17 @@ func Int32(key string, value int32) Field {
 		Value: value,
 	}
 }
+
+func Int(key string, value int) Field {
+	return Field{
+		Key:   key,
+		Value: value,
+	}
+}
+
+func Bool(key string, value bool) Field {
+	return Field{
+		Key:   key,
+		Value: value,
+	}
+}
@@ -8,16 +8,37 @@ import (
 	"time"
 )
 
+// BatchConfig 一批最多凑多少条消息，最多等多久
+type BatchConfig struct {
+	Size    int           `yaml:"size"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// DefaultBatchConfig 没有配置的时候用
+var DefaultBatchConfig = BatchConfig{
+	Size:    10,
+	Timeout: time.Second,
+}
+
 type BatchHandler[T any] struct {
-	l  logger.Logger
-	fn func(msgs []*sarama.ConsumerMessage, t []T) error
+	l   logger.Logger
+	fn  func(msgs []*sarama.ConsumerMessage, t []T) error
+	cfg BatchConfig
 }
 
-func NewBatchHandler[T any](l logger.Logger,
+// NewBatchHandler cfg 里面没有设置的值用 DefaultBatchConfig 的
+func NewBatchHandler[T any](l logger.Logger, cfg BatchConfig,
 	fn func(msgs []*sarama.ConsumerMessage, t []T) error) *BatchHandler[T] {
+	if cfg.Size <= 0 {
+		cfg.Size = DefaultBatchConfig.Size
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultBatchConfig.Timeout
+	}
 	return &BatchHandler[T]{
-		l:  l,
-		fn: fn,
+		l:   l,
+		fn:  fn,
+		cfg: cfg,
 	}
 }
 
@@ -33,12 +54,11 @@ func (h *BatchHandler[T]) Cleanup(session sarama.ConsumerGroupSession) error {
 func (h *BatchHandler[T]) ConsumeClaim(session sarama.ConsumerGroupSession,
 	claim sarama.ConsumerGroupClaim) error {
 	msgsCh := claim.Messages()
-	// 这个可以做成参数
-	const batchSize = 10
+	batchSize := h.cfg.Size
 	for {
 		msgs := make([]*sarama.ConsumerMessage, 0, batchSize)
 		ts := make([]T, 0, batchSize)
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), h.cfg.Timeout)
 		done := false
 		for i := 0; i < batchSize && !done; i++ {
 			select {
@@ -72,6 +92,11 @@ func (h *BatchHandler[T]) ConsumeClaim(session sarama.ConsumerGroupSession,
 				ts = append(ts, t)
 			}
 		}
+		if len(msgs) == 0 {
+			// 这段时间一条消息都没有
+			cancel()
+			continue
+		}
 		err := h.fn(msgs, ts)
 		if err == nil {
 			// 这边就要都提交了
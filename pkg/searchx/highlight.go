@@ -0,0 +1,104 @@
+package searchx
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+)
+
+type span struct {
+	start int
+	end   int
+}
+
+// Highlight 把命中的词用 <em> 包起来，其余部分做 HTML 转义，可以直接给前端渲染
+func Highlight(text string, terms []string) string {
+	return render(text, matchSpans(text, terms), 0, len(text))
+}
+
+// Snippet 从 text 里面截取第一个命中位置附近的 size 个字符并高亮，
+// 一个都没有命中的时候取开头
+func Snippet(text string, terms []string, size int) string {
+	spans := matchSpans(text, terms)
+	if utf8.RuneCountInString(text) <= size {
+		return render(text, spans, 0, len(text))
+	}
+	from := 0
+	if len(spans) > 0 {
+		// 命中位置往前留一点上下文
+		from = spans[0].start
+		for back := size / 4; back > 0 && from > 0; back-- {
+			_, n := utf8.DecodeLastRuneInString(text[:from])
+			from -= n
+		}
+	}
+	to := from
+	for cnt := 0; cnt < size && to < len(text); cnt++ {
+		_, n := utf8.DecodeRuneInString(text[to:])
+		to += n
+	}
+	res := render(text, spans, from, to)
+	if from > 0 {
+		res = "..." + res
+	}
+	if to < len(text) {
+		res += "..."
+	}
+	return res
+}
+
+func matchSpans(text string, terms []string) []span {
+	if len(terms) == 0 {
+		return nil
+	}
+	want := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		want[t] = struct{}{}
+	}
+	var spans []span
+	for _, tk := range Tokenize(text) {
+		if _, ok := want[tk.Term]; ok {
+			spans = append(spans, span{start: tk.Start, end: tk.End})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+	// bigram 之间是重叠的，合并成一段
+	merged := spans[:0]
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.start <= merged[n-1].end {
+			if s.end > merged[n-1].end {
+				merged[n-1].end = s.end
+			}
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// render 只输出 [from, to) 这一段，跨越边界的高亮会被截断
+func render(text string, spans []span, from, to int) string {
+	var sb strings.Builder
+	pos := from
+	for _, s := range spans {
+		if s.end <= from || s.start >= to {
+			continue
+		}
+		start, end := max(s.start, from), min(s.end, to)
+		sb.WriteString(html.EscapeString(text[pos:start]))
+		sb.WriteString(highlightPre)
+		sb.WriteString(html.EscapeString(text[start:end]))
+		sb.WriteString(highlightPost)
+		pos = end
+	}
+	sb.WriteString(html.EscapeString(text[pos:to]))
+	return sb.String()
+}
@@ -0,0 +1,293 @@
+package searchx
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Document 被索引的文档
+type Document struct {
+	Id int64
+	// Version 越大越新，旧版本不会覆盖新版本，一般用更新时间
+	Version int64
+	// Fields 全文检索的字段，会切词
+	Fields map[string]string
+	// Keywords 精确匹配的字段，比如标签，不区分大小写
+	Keywords map[string][]string
+}
+
+// Query 查询条件，所有条件之间是与的关系
+type Query struct {
+	// Text 全文检索，key 是字段名，空字符串表示在所有全文字段里面找
+	Text map[string]string
+	// Filters 精确匹配，同一个字段的多个值也是与的关系
+	Filters map[string][]string
+	Offset  int
+	Limit   int
+}
+
+type Hit struct {
+	Doc   Document
+	Score float64
+	// Terms 命中的查询词，用于高亮
+	Terms []string
+}
+
+// Index 内存里面的倒排索引，并发安全
+type Index struct {
+	mu sync.RWMutex
+	// boosts 字段权重，没有配置的字段权重为 1
+	boosts map[string]float64
+	docs   map[int64]Document
+	// postings field -> term -> docId -> 词频
+	postings map[string]map[string]map[int64]int
+	// keywords field -> value -> docIds
+	keywords map[string]map[string]map[int64]struct{}
+	// tombstones 删除时候的版本，防止重建索引时读到的旧数据把已经删除的文档又加回来
+	tombstones map[int64]int64
+}
+
+func NewIndex(boosts map[string]float64) *Index {
+	return &Index{
+		boosts:     boosts,
+		docs:       make(map[int64]Document),
+		postings:   make(map[string]map[string]map[int64]int),
+		keywords:   make(map[string]map[string]map[int64]struct{}),
+		tombstones: make(map[int64]int64),
+	}
+}
+
+// Put 新增或者替换文档，版本比现有的（包括已删除的）旧就忽略，返回是否写入了
+func (i *Index) Put(doc Document) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if v, ok := i.tombstones[doc.Id]; ok {
+		if doc.Version <= v {
+			return false
+		}
+		delete(i.tombstones, doc.Id)
+	}
+	if old, ok := i.docs[doc.Id]; ok {
+		if doc.Version < old.Version {
+			return false
+		}
+		i.unindex(old)
+	}
+	i.docs[doc.Id] = doc
+	for field, text := range doc.Fields {
+		terms := i.postings[field]
+		if terms == nil {
+			terms = make(map[string]map[int64]int)
+			i.postings[field] = terms
+		}
+		for _, tk := range Tokenize(text) {
+			ids := terms[tk.Term]
+			if ids == nil {
+				ids = make(map[int64]int)
+				terms[tk.Term] = ids
+			}
+			ids[doc.Id]++
+		}
+	}
+	for field, vals := range doc.Keywords {
+		kw := i.keywords[field]
+		if kw == nil {
+			kw = make(map[string]map[int64]struct{})
+			i.keywords[field] = kw
+		}
+		for _, val := range vals {
+			val = strings.ToLower(val)
+			ids := kw[val]
+			if ids == nil {
+				ids = make(map[int64]struct{})
+				kw[val] = ids
+			}
+			ids[doc.Id] = struct{}{}
+		}
+	}
+	return true
+}
+
+// Delete 删除文档，version 比现有文档旧的时候忽略
+func (i *Index) Delete(id int64, version int64) bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if v, ok := i.tombstones[id]; ok && version <= v {
+		return false
+	}
+	if old, ok := i.docs[id]; ok {
+		if version < old.Version {
+			return false
+		}
+		i.unindex(old)
+		delete(i.docs, id)
+	}
+	i.tombstones[id] = version
+	return true
+}
+
+// Retain 删掉 keep 返回 false 的文档，重建索引之后用来清理数据库里面已经不存在的文档
+func (i *Index) Retain(keep func(doc Document) bool) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	cnt := 0
+	for id, doc := range i.docs {
+		if keep(doc) {
+			continue
+		}
+		i.unindex(doc)
+		delete(i.docs, id)
+		cnt++
+	}
+	return cnt
+}
+
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.docs)
+}
+
+func (i *Index) unindex(doc Document) {
+	for field, text := range doc.Fields {
+		terms := i.postings[field]
+		for _, tk := range Tokenize(text) {
+			ids := terms[tk.Term]
+			delete(ids, doc.Id)
+			if len(ids) == 0 {
+				delete(terms, tk.Term)
+			}
+		}
+	}
+	for field, vals := range doc.Keywords {
+		kw := i.keywords[field]
+		for _, val := range vals {
+			val = strings.ToLower(val)
+			delete(kw[val], doc.Id)
+			if len(kw[val]) == 0 {
+				delete(kw, val)
+			}
+		}
+	}
+}
+
+// Search 返回当前页的结果和命中的总数。
+// 打分是 tf * idf * 字段权重，分数相同的按照 id 倒序，也就是新的在前面。
+func (i *Index) Search(q Query) ([]Hit, int) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	var (
+		candidates map[int64]float64
+		allTerms   []string
+	)
+	// 先用精确匹配缩小范围
+	for field, vals := range q.Filters {
+		for _, val := range vals {
+			ids := i.keywords[field][strings.ToLower(val)]
+			candidates = intersect(candidates, ids)
+			if len(candidates) == 0 {
+				return nil, 0
+			}
+		}
+	}
+	for field, text := range q.Text {
+		fields := i.textFields(field)
+		for _, term := range QueryTerms(text) {
+			allTerms = append(allTerms, term)
+			scores := i.scoreTerm(fields, term)
+			candidates = intersectScore(candidates, scores)
+			if len(candidates) == 0 {
+				return nil, 0
+			}
+		}
+	}
+	if candidates == nil {
+		// 没有任何条件
+		return nil, 0
+	}
+
+	hits := make([]Hit, 0, len(candidates))
+	for id, score := range candidates {
+		hits = append(hits, Hit{Doc: i.docs[id], Score: score, Terms: allTerms})
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].Doc.Id > hits[b].Doc.Id
+	})
+	total := len(hits)
+	if q.Offset >= total {
+		return []Hit{}, total
+	}
+	end := total
+	if q.Limit > 0 && q.Offset+q.Limit < total {
+		end = q.Offset + q.Limit
+	}
+	return hits[q.Offset:end], total
+}
+
+func (i *Index) textFields(field string) []string {
+	if field != "" {
+		return []string{field}
+	}
+	res := make([]string, 0, len(i.postings))
+	for f := range i.postings {
+		res = append(res, f)
+	}
+	return res
+}
+
+// scoreTerm 一个词在这些字段里面任意一个出现就算命中
+func (i *Index) scoreTerm(fields []string, term string) map[int64]float64 {
+	res := make(map[int64]float64)
+	n := float64(len(i.docs))
+	for _, field := range fields {
+		ids := i.postings[field][term]
+		if len(ids) == 0 {
+			continue
+		}
+		idf := math.Log(1 + n/float64(len(ids)))
+		boost, ok := i.boosts[field]
+		if !ok {
+			boost = 1
+		}
+		for id, tf := range ids {
+			res[id] += float64(tf) * idf * boost
+		}
+	}
+	return res
+}
+
+// intersect candidates 为 nil 表示还没有任何条件
+func intersect(candidates map[int64]float64, ids map[int64]struct{}) map[int64]float64 {
+	res := make(map[int64]float64)
+	if candidates == nil {
+		for id := range ids {
+			res[id] = 0
+		}
+		return res
+	}
+	for id, score := range candidates {
+		if _, ok := ids[id]; ok {
+			res[id] = score
+		}
+	}
+	return res
+}
+
+func intersectScore(candidates map[int64]float64, scores map[int64]float64) map[int64]float64 {
+	if candidates == nil {
+		return scores
+	}
+	res := make(map[int64]float64)
+	for id, score := range candidates {
+		if s, ok := scores[id]; ok {
+			res[id] = score + s
+		}
+	}
+	return res
+}
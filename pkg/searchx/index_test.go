@@ -0,0 +1,134 @@
+package searchx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	testCases := []struct {
+		name  string
+		text  string
+		terms []string
+	}{
+		{
+			name:  "中文单字和bigram",
+			text:  "红书",
+			terms: []string{"红", "红书", "书"},
+		},
+		{
+			name:  "中英混合",
+			text:  "Go语言 gRPC!",
+			terms: []string{"go", "语", "语言", "言", "grpc"},
+		},
+		{
+			name:  "单个汉字",
+			text:  "a 好 b",
+			terms: []string{"a", "好", "b"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens := Tokenize(tc.text)
+			terms := make([]string, 0, len(tokens))
+			for _, tk := range tokens {
+				terms = append(terms, tk.Term)
+				// 偏移量要能还原出原文
+				assert.NotEmpty(t, tc.text[tk.Start:tk.End])
+			}
+			assert.Equal(t, tc.terms, terms)
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	assert.Equal(t, []string{"搜索", "索引", "go"}, QueryTerms("搜索引 Go go"))
+	assert.Equal(t, []string{"好"}, QueryTerms("好"))
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx := NewIndex(map[string]float64{"title": 3})
+	idx.Put(Document{Id: 1, Version: 1,
+		Fields:   map[string]string{"title": "Go 并发编程", "content": "讲讲 channel"},
+		Keywords: map[string][]string{"tag": {"Go"}}})
+	idx.Put(Document{Id: 2, Version: 1,
+		Fields:   map[string]string{"title": "数据库", "content": "Go 访问 MySQL 的并发问题"},
+		Keywords: map[string][]string{"tag": {"MySQL"}}})
+	idx.Put(Document{Id: 3, Version: 1,
+		Fields: map[string]string{"title": "随笔", "content": "今天天气不错"}})
+
+	testCases := []struct {
+		name  string
+		q     Query
+		ids   []int64
+		total int
+	}{
+		{
+			name:  "标题命中排在前面",
+			q:     Query{Text: map[string]string{"": "go 并发"}},
+			ids:   []int64{1, 2},
+			total: 2,
+		},
+		{
+			name:  "指定字段",
+			q:     Query{Text: map[string]string{"title": "并发"}},
+			ids:   []int64{1},
+			total: 1,
+		},
+		{
+			name:  "标签过滤不区分大小写",
+			q:     Query{Text: map[string]string{"": "并发"}, Filters: map[string][]string{"tag": {"mysql"}}},
+			ids:   []int64{2},
+			total: 1,
+		},
+		{
+			name:  "所有词都要命中",
+			q:     Query{Text: map[string]string{"": "并发 天气"}},
+			ids:   []int64{},
+			total: 0,
+		},
+		{
+			name:  "分页",
+			q:     Query{Text: map[string]string{"": "go"}, Offset: 1, Limit: 1},
+			ids:   []int64{2},
+			total: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits, total := idx.Search(tc.q)
+			ids := make([]int64, 0, len(hits))
+			for _, h := range hits {
+				ids = append(ids, h.Doc.Id)
+			}
+			assert.Equal(t, tc.ids, ids)
+			assert.Equal(t, tc.total, total)
+		})
+	}
+}
+
+func TestIndex_Version(t *testing.T) {
+	idx := NewIndex(nil)
+	assert.True(t, idx.Put(Document{Id: 1, Version: 2, Fields: map[string]string{"title": "新标题"}}))
+	// 旧版本不能覆盖
+	assert.False(t, idx.Put(Document{Id: 1, Version: 1, Fields: map[string]string{"title": "旧标题"}}))
+	hits, _ := idx.Search(Query{Text: map[string]string{"": "旧标"}})
+	assert.Empty(t, hits)
+
+	assert.True(t, idx.Delete(1, 3))
+	// 删除之后，重建索引读到的旧数据不能加回来
+	assert.False(t, idx.Put(Document{Id: 1, Version: 2, Fields: map[string]string{"title": "新标题"}}))
+	assert.Equal(t, 0, idx.Len())
+	// 重新发表
+	assert.True(t, idx.Put(Document{Id: 1, Version: 4, Fields: map[string]string{"title": "新标题"}}))
+	hits, _ = idx.Search(Query{Text: map[string]string{"": "标题"}})
+	assert.Len(t, hits, 1)
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t, "<em>Go</em> &lt;并<em>发编程</em>",
+		Highlight("Go <并发编程", []string{"go", "发编", "编程"}))
+	assert.Equal(t, "...<em>天气</em>不...",
+		Snippet("今天天气不错", []string{"天气"}, 3))
+}
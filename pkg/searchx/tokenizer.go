@@ -0,0 +1,114 @@
+package searchx
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token 切出来的词，Start 和 End 是在原文中的字节偏移，用于高亮
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize 建索引用的切词。
+// 中日韩文字没有空格分隔，这里不引入词典，连续的一段同时切成单字和相邻两个字（bigram），
+// 这样查单个字和查词都能命中；其它的字母数字按照单词切分，统一转小写。
+func Tokenize(text string) []Token {
+	return tokenize(text, true)
+}
+
+// QueryTerms 查询用的切词。
+// 中日韩文字只用 bigram 去匹配，不然一个词会被拆成单字，匹配到很多不相关的内容；
+// 只有一个字的时候才用单字。
+func QueryTerms(text string) []string {
+	tokens := tokenize(text, false)
+	res := make([]string, 0, len(tokens))
+	seen := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		if _, ok := seen[t.Term]; ok {
+			continue
+		}
+		seen[t.Term] = struct{}{}
+		res = append(res, t.Term)
+	}
+	return res
+}
+
+type runePos struct {
+	r     rune
+	start int
+	end   int
+}
+
+func tokenize(text string, forIndex bool) []Token {
+	var (
+		tokens []Token
+		cjk    []runePos
+		word   strings.Builder
+		wStart = -1
+	)
+	flushWord := func(end int) {
+		if wStart < 0 {
+			return
+		}
+		tokens = append(tokens, Token{Term: word.String(), Start: wStart, End: end})
+		word.Reset()
+		wStart = -1
+	}
+	flushCJK := func() {
+		if len(cjk) == 0 {
+			return
+		}
+		tokens = append(tokens, cjkTokens(cjk, forIndex)...)
+		cjk = cjk[:0]
+	}
+	for i, r := range text {
+		end := i + len(string(r))
+		switch {
+		case isCJK(r):
+			flushWord(i)
+			cjk = append(cjk, runePos{r: r, start: i, end: end})
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			if wStart < 0 {
+				wStart = i
+			}
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flushWord(i)
+			flushCJK()
+		}
+	}
+	flushWord(len(text))
+	flushCJK()
+	return tokens
+}
+
+func cjkTokens(run []runePos, forIndex bool) []Token {
+	if len(run) == 1 {
+		return []Token{{Term: string(run[0].r), Start: run[0].start, End: run[0].end}}
+	}
+	res := make([]Token, 0, len(run)*2)
+	for i := range run {
+		if forIndex {
+			res = append(res, Token{Term: string(run[i].r), Start: run[i].start, End: run[i].end})
+		}
+		if i+1 < len(run) {
+			res = append(res, Token{
+				Term:  string([]rune{run[i].r, run[i+1].r}),
+				Start: run[i].start,
+				End:   run[i+1].end,
+			})
+		}
+	}
+	return res
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
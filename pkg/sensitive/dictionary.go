@@ -0,0 +1,81 @@
+package sensitive
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Dictionary 可以热更新的敏感词库，更新的时候整体替换 Matcher，匹配过程不需要加锁
+type Dictionary struct {
+	matcher atomic.Pointer[Matcher]
+}
+
+func NewDictionary(words []string) *Dictionary {
+	d := &Dictionary{}
+	d.Reload(words)
+	return d
+}
+
+func (d *Dictionary) Reload(words []string) {
+	d.matcher.Store(NewMatcher(words))
+}
+
+func (d *Dictionary) Matcher() *Matcher {
+	return d.matcher.Load()
+}
+
+// LoadFile 一行一个词，空行和 # 开头的行会被忽略
+func LoadFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var words []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, scanner.Err()
+}
+
+// WatchFile 定时检查文件的修改时间，变了就重新加载，直到 ctx 结束。
+// 加载失败的时候保留原来的词库，并且把错误交给 onErr。
+func (d *Dictionary) WatchFile(ctx context.Context, path string, interval time.Duration, onErr func(err error)) {
+	var modTime time.Time
+	if fi, err := os.Stat(path); err == nil {
+		modTime = fi.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			onErr(err)
+			continue
+		}
+		if !fi.ModTime().After(modTime) {
+			continue
+		}
+		words, err := LoadFile(path)
+		if err != nil {
+			onErr(err)
+			continue
+		}
+		modTime = fi.ModTime()
+		d.Reload(words)
+	}
+}
@@ -0,0 +1,138 @@
+package sensitive
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Matcher 基于 Aho-Corasick 自动机的多模式匹配，扫描一遍文本就能找出所有敏感词。
+// 构建之后是只读的，可以并发使用。匹配不区分大小写。
+type Matcher struct {
+	nodes []node
+}
+
+type node struct {
+	next map[rune]int
+	fail int
+	// word 以这个节点结尾的敏感词，空字符串表示不是结尾
+	word string
+	// depth 从根节点到这里的字符数
+	depth int
+	// output 沿着 fail 链能找到的下一个结尾节点，-1 表示没有
+	output int
+}
+
+type Match struct {
+	Word string
+	// Start End 是在原文中的字节偏移
+	Start int
+	End   int
+}
+
+func NewMatcher(words []string) *Matcher {
+	m := &Matcher{nodes: []node{newNode()}}
+	for _, w := range words {
+		m.insert(w)
+	}
+	m.build()
+	return m
+}
+
+func newNode() node {
+	return node{next: make(map[rune]int), output: -1}
+}
+
+func (m *Matcher) insert(word string) {
+	if word == "" {
+		return
+	}
+	cur := 0
+	for _, r := range word {
+		r = unicode.ToLower(r)
+		nxt, ok := m.nodes[cur].next[r]
+		if !ok {
+			n := newNode()
+			n.depth = m.nodes[cur].depth + 1
+			m.nodes = append(m.nodes, n)
+			nxt = len(m.nodes) - 1
+			m.nodes[cur].next[r] = nxt
+		}
+		cur = nxt
+	}
+	m.nodes[cur].word = word
+}
+
+// build 按照层序计算 fail 指针
+func (m *Matcher) build() {
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[cur].next {
+			f := m.nodes[cur].fail
+			for f != 0 {
+				if _, ok := m.nodes[f].next[r]; ok {
+					break
+				}
+				f = m.nodes[f].fail
+			}
+			if nxt, ok := m.nodes[f].next[r]; ok && nxt != child {
+				m.nodes[child].fail = nxt
+			}
+			fn := m.nodes[child].fail
+			if m.nodes[fn].word != "" {
+				m.nodes[child].output = fn
+			} else {
+				m.nodes[child].output = m.nodes[fn].output
+			}
+			queue = append(queue, child)
+		}
+	}
+}
+
+// FindAll 找出所有命中的位置，同一个词出现多次会返回多次
+func (m *Matcher) FindAll(text string) []Match {
+	var (
+		res []Match
+		// starts 每个字符在原文中的起始位置，大小写转换之后字节数可能会变，不能直接用词的长度倒推
+		starts []int
+	)
+	cur := 0
+	for i, r := range text {
+		starts = append(starts, i)
+		end := i + utf8.RuneLen(r)
+		r = unicode.ToLower(r)
+		for cur != 0 {
+			if _, ok := m.nodes[cur].next[r]; ok {
+				break
+			}
+			cur = m.nodes[cur].fail
+		}
+		if nxt, ok := m.nodes[cur].next[r]; ok {
+			cur = nxt
+		}
+		for o := cur; o > 0; o = m.nodes[o].output {
+			if w := m.nodes[o].word; w != "" {
+				res = append(res, Match{Word: w, Start: starts[len(starts)-m.nodes[o].depth], End: end})
+			}
+		}
+	}
+	return res
+}
+
+// Words 命中的敏感词，去重，按照第一次出现的顺序
+func (m *Matcher) Words(text string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, mt := range m.FindAll(text) {
+		if _, ok := seen[mt.Word]; ok {
+			continue
+		}
+		seen[mt.Word] = struct{}{}
+		res = append(res, mt.Word)
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package sensitive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "his", "hers", "赌博", "网络赌博", "VPN"})
+	testCases := []struct {
+		name string
+		text string
+		want []Match
+	}{
+		{
+			name: "重叠的词",
+			text: "ushers",
+			want: []Match{
+				{Word: "she", Start: 1, End: 4},
+				{Word: "he", Start: 2, End: 4},
+				{Word: "hers", Start: 2, End: 6},
+			},
+		},
+		{
+			name: "中文，长词包含短词",
+			text: "禁止网络赌博",
+			want: []Match{
+				{Word: "网络赌博", Start: 6, End: 18},
+				{Word: "赌博", Start: 12, End: 18},
+			},
+		},
+		{
+			name: "不区分大小写",
+			text: "翻墙用vpn",
+			want: []Match{
+				{Word: "VPN", Start: 9, End: 12},
+			},
+		},
+		{
+			name: "没有命中",
+			text: "今天天气不错",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := m.FindAll(tc.text)
+			assert.Equal(t, tc.want, res)
+			for _, mt := range res {
+				assert.NotEmpty(t, tc.text[mt.Start:mt.End])
+			}
+		})
+	}
+}
+
+func TestDictionary_Reload(t *testing.T) {
+	d := NewDictionary([]string{"赌博"})
+	assert.Equal(t, []string{"赌博"}, d.Matcher().Words("赌博，赌博"))
+	d.Reload([]string{"诈骗"})
+	assert.Empty(t, d.Matcher().Words("赌博"))
+	assert.Equal(t, []string{"诈骗"}, d.Matcher().Words("电信诈骗"))
+}
@@ -4,6 +4,7 @@ package main
 
 import (
 	"red-feed/interactive/events"
+	ioc2 "red-feed/interactive/ioc"
 	repository2 "red-feed/interactive/repository"
 	cache2 "red-feed/interactive/repository/cache"
 	dao2 "red-feed/interactive/repository/dao"
@@ -36,27 +37,61 @@ func InitApp() *App {
 
 		article.NewKafkaProducer,
 		events.NewInteractiveReadEventBatchConsumer,
+		events.NewInteractiveDeleteEventConsumer,
+		events.NewInteractiveStatsConsumer,
+		ioc.InitSearchConsumer,
 
 		// 初始化DAO层 和 Cache层
 		dao.NewGORMUserDAO,
 		dao2.NewInteractiveDAO,
+		dao2.NewCollectionDAO,
+		dao2.NewStatsDAO,
 		dao.NewGORMArticleDao,
+		dao.NewGORMArticleReviewDAO,
 		cache.NewUserCache,
 		cache.NewCodeCache,
 		cache.NewRedisArticleCache,
+		cache.NewRedisExportTaskCache,
+		cache.NewRedisFeedCache,
 		cache2.NewRedisInteractiveCache,
+		cache2.NewRedisEventDedupCache,
+		cache2.NewRedisReadLimitCache,
+		cache2.NewRedisCntDeltaCache,
+		cache2.NewRedisUvCache,
+		ioc2.InitReadGuard,
+		ioc.InitReadBatchConfig,
 
 		// 初始化Repo层
 		repository.NewUserRepository,
 		repository.NewCodeRepository,
 		repository.NewArticleRepository,
-		repository2.NewInteractiveRepository,
+		repository.NewArticleReviewRepository,
+		ioc.InitExportRepository,
+		repository.NewCachedFeedRepository,
+		ioc2.InitInteractiveRepository,
+		repository2.NewWriteBehindInteractiveRepository,
+		repository2.NewCollectionRepository,
+		repository2.NewUvRepository,
+		repository2.NewStatsRepository,
 
 		// 初始化Service层
 		service.NewUserService,
 		service.NewCodeService,
 		service.NewArticleService,
-		service2.NewInteractiveService,
+		service.NewNopFollowChecker,
+		service.NewNopFollowerCounter,
+		service.NewModerationService,
+		service.NewArchiveService,
+		ioc.InitFeedService,
+		ioc.InitSensitiveDictionary,
+		ioc.InitPreviewTokenService,
+		ioc.InitSearchService,
+		ioc.InitInteractiveService,
+		ioc.InitIntrGRPCClient,
+		ioc.InitRegistry,
+		ioc2.InitReactions,
+		ioc.InitBizRegistry,
+		service2.NewCollectionService,
 		ioc.InitWechatService,
 		ioc.InitSMSService,
 
@@ -64,6 +99,12 @@ func InitApp() *App {
 		web.NewUserHandler,
 		web.NewOAuth2WechatHandler,
 		web.NewArticleHandler,
+		web.NewSearchHandler,
+		ioc.InitModerationHandler,
+		web.NewArchiveHandler,
+		web.NewFeedHandler,
+		web.NewCollectionHandler,
+		web.NewCreatorHandler,
 
 		ijwt.NewRedisJWTHandler,
 		ioc.InitMiddlewares,
@@ -72,6 +113,15 @@ func InitApp() *App {
 		rankingServiceSet,
 		ioc.InitJobs,
 		ioc.InitRankingJob,
+		ioc.InitArticlePurgeJob,
+		ioc.InitScheduler,
+		ioc.InitLocalFuncExecutor,
+		service.NewCronJobService,
+		repository.NewCronJobRepository,
+		dao.NewGORMJobDAO,
+		ioc.InitArticleOutboxRelayJob,
+		dao.NewGORMArticleOutboxDAO,
+		repository.NewArticleEventRepository,
 		ioc.InitRLockClient,
 
 		ioc.InitLogger,
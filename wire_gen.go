@@ -9,6 +9,7 @@ package main
 import (
 	"github.com/google/wire"
 	"red-feed/interactive/events"
+	ioc2 "red-feed/interactive/ioc"
 	repository2 "red-feed/interactive/repository"
 	cache2 "red-feed/interactive/repository/cache"
 	dao2 "red-feed/interactive/repository/dao"
@@ -52,23 +53,73 @@ func InitApp() *App {
 	client := ioc.InitKafka()
 	syncProducer := ioc.NewSyncProducer(client)
 	producer := article.NewKafkaProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, producer, logger)
+	previewTokenService := ioc.InitPreviewTokenService()
+	followChecker := service.NewNopFollowChecker()
+	dictionary := ioc.InitSensitiveDictionary(logger)
+	articleReviewDAO := dao.NewGORMArticleReviewDAO(db)
+	articleReviewRepository := repository.NewArticleReviewRepository(articleReviewDAO)
+	moderationService := service.NewModerationService(dictionary, articleReviewRepository, articleRepository, producer, logger)
+	articleService := service.NewArticleService(articleRepository, producer, previewTokenService, followChecker, moderationService, logger)
 	interactiveDAO := dao2.NewInteractiveDAO(db)
-	interactiveCache := cache2.NewRedisInteractiveCache(cmdable)
-	interactiveRepository := repository2.NewInteractiveRepository(interactiveDAO, interactiveCache, logger)
-	interactiveService := service2.NewInteractiveService(interactiveRepository, logger)
-	articleHandler := web.NewArticleHandler(articleService, logger, interactiveService)
-	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler)
-	consumer := events.NewInteractiveReadEventBatchConsumer(client, interactiveRepository, logger)
-	v2 := ioc.NewConsumers(consumer)
+	bizRegistry := ioc.InitBizRegistry(articleService)
+	interactiveCache := cache2.NewRedisInteractiveCache(cmdable, bizRegistry)
+	cntDeltaCache := cache2.NewRedisCntDeltaCache(cmdable)
+	writeBehindInteractiveRepository := repository2.NewWriteBehindInteractiveRepository(interactiveDAO, interactiveCache, cntDeltaCache, logger)
+	interactiveRepository := ioc2.InitInteractiveRepository(interactiveDAO, interactiveCache, writeBehindInteractiveRepository, logger)
+	collectionDAO := dao2.NewCollectionDAO(db)
+	collectionRepository := repository2.NewCollectionRepository(collectionDAO, interactiveCache, logger)
+	statsDAO := dao2.NewStatsDAO(db)
+	statsRepository := repository2.NewStatsRepository(statsDAO)
+	reactions := ioc2.InitReactions()
+	registry := ioc.InitRegistry()
+	interactiveServiceClient := ioc.InitIntrGRPCClient(registry)
+	interactiveService := ioc.InitInteractiveService(interactiveRepository, collectionRepository, statsRepository, reactions, bizRegistry, interactiveServiceClient, logger)
+	articleHandler := web.NewArticleHandler(articleService, logger, interactiveService, userService)
+	searchService := ioc.InitSearchService(articleRepository, logger)
+	searchHandler := web.NewSearchHandler(searchService, logger)
+	moderationHandler := ioc.InitModerationHandler(moderationService, logger)
+	exportTaskCache := cache.NewRedisExportTaskCache(cmdable)
+	exportRepository := ioc.InitExportRepository(exportTaskCache)
+	archiveService := service.NewArchiveService(articleService, articleRepository, moderationService, exportRepository, logger)
+	archiveHandler := web.NewArchiveHandler(archiveService, logger)
+	feedCache := cache.NewRedisFeedCache(cmdable)
+	feedRepository := repository.NewCachedFeedRepository(feedCache)
+	feedService := ioc.InitFeedService(articleService, userService, feedRepository, logger)
+	feedHandler := web.NewFeedHandler(feedService, logger)
+	collectionService := service2.NewCollectionService(collectionRepository)
+	collectionHandler := web.NewCollectionHandler(collectionService, logger)
+	followerCounter := service.NewNopFollowerCounter()
+	creatorHandler := web.NewCreatorHandler(articleService, interactiveService, followerCounter, logger)
+	engine := ioc.InitWebServer(v, userHandler, oAuth2WechatHandler, articleHandler, searchHandler, moderationHandler, archiveHandler, feedHandler, collectionHandler, creatorHandler)
+	eventDedupCache := cache2.NewRedisEventDedupCache(cmdable)
+	readLimitCache := cache2.NewRedisReadLimitCache(cmdable)
+	readGuard := ioc2.InitReadGuard(readLimitCache, logger)
+	batchConfig := ioc.InitReadBatchConfig()
+	uvCache := cache2.NewRedisUvCache(cmdable)
+	uvRepository := repository2.NewUvRepository(interactiveDAO, interactiveCache, uvCache, logger)
+	consumer := events.NewInteractiveReadEventBatchConsumer(client, interactiveRepository, uvRepository, statsRepository, eventDedupCache, readGuard, batchConfig, logger)
+	interactiveDeleteEventConsumer := events.NewInteractiveDeleteEventConsumer(client, interactiveRepository, logger)
+	searchConsumer := ioc.InitSearchConsumer(client, searchService, logger)
+	interactiveStatsConsumer := events.NewInteractiveStatsConsumer(client, statsRepository, eventDedupCache, logger)
+	v2 := ioc.NewConsumers(consumer, interactiveDeleteEventConsumer, searchConsumer, interactiveStatsConsumer)
 	rankingService := service.NewBatchRankingService(articleService, interactiveService)
 	rlockClient := ioc.InitRLockClient(cmdable)
 	rankingJob := ioc.InitRankingJob(rankingService, rlockClient, logger)
-	cron := ioc.InitJobs(logger, rankingJob)
+	articlePurgeJob := ioc.InitArticlePurgeJob(articleService, logger)
+	articleOutboxDAO := dao.NewGORMArticleOutboxDAO(db)
+	articleEventRepository := repository.NewArticleEventRepository(articleOutboxDAO)
+	articleOutboxRelayJob := ioc.InitArticleOutboxRelayJob(articleEventRepository, producer)
+	cron := ioc.InitJobs(logger, rankingJob, articleOutboxRelayJob)
+	localFuncExecutor := ioc.InitLocalFuncExecutor(rankingService, articlePurgeJob)
+	jobDAO := dao.NewGORMJobDAO(db)
+	jobRepository := repository.NewCronJobRepository(jobDAO)
+	jobService := service.NewCronJobService(jobRepository, logger)
+	scheduler := ioc.InitScheduler(logger, localFuncExecutor, jobService, articlePurgeJob)
 	app := &App{
 		web:       engine,
 		consumers: v2,
 		cron:      cron,
+		scheduler: scheduler,
 	}
 	return app
 }